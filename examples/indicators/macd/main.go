@@ -54,7 +54,7 @@ func main() {
 			histogramValue := tick.GetField("histogram")
 
 			fmt.Printf("%s\t%.4f\t\t%.4f\t\t%.4f\n",
-				tick.Timestamp().Format("15:04:05"),
+				tick.Time().Format("15:04:05"),
 				macdValue,
 				signalValue,
 				histogramValue)
@@ -91,6 +91,21 @@ func WithMAType(p string) internal.PluginOptions {
 	return func(c internal.Options) { c.Set("maType", p) }
 }
 
+// WithFastMAType for MACDEXT, overrides WithMAType for the fast line
+func WithFastMAType(p string) internal.PluginOptions {
+	return func(c internal.Options) { c.Set("fastMAType", p) }
+}
+
+// WithSlowMAType for MACDEXT, overrides WithMAType for the slow line
+func WithSlowMAType(p string) internal.PluginOptions {
+	return func(c internal.Options) { c.Set("slowMAType", p) }
+}
+
+// WithSignalMAType for MACDEXT, overrides WithMAType for the signal line
+func WithSignalMAType(p string) internal.PluginOptions {
+	return func(c internal.Options) { c.Set("signalMAType", p) }
+}
+
 func With(name string, value any) internal.PluginOptions {
 	return func(c internal.Options) {
 		c.Set(name, value)
@@ -0,0 +1,105 @@
+package plugins
+
+import (
+	"bytes"
+	"encoding/gob"
+	"maps"
+	"reflect"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/series"
+	"github.com/rangertaha/gotal/internal/stream"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// Updater is the incremental part of an internal.Indicator.
+type Updater interface {
+	Update(input *tick.Tick) *tick.Tick
+	Reset()
+}
+
+// Compute resets the updater and replays the series through it, keeping the
+// non empty outputs. Indicators implement Compute with it so batch results
+// are exactly the live results.
+func Compute(name string, u Updater, input *series.Series) *series.Series {
+	u.Reset()
+
+	output := input.Spawn()
+	output.SetName(name)
+	for _, t := range input.Ticks() {
+		if out := u.Update(t); !out.IsEmpty() {
+			output.Add(out)
+		}
+	}
+	return output
+}
+
+// Projector is an Updater with outputs ahead of its last input, like the
+// leading spans of the Ichimoku cloud.
+type Projector interface {
+	Updater
+
+	// Projection returns the output fields of the intervals after the last input, the next one first
+	Projection() []map[string]float64
+}
+
+// Project is Compute followed by the projection, each output one more
+// interval of the series Duration after the last input. The interval is the
+// spacing of the last two inputs when the ticks have no duration.
+func Project(name string, p Projector, input *series.Series) *series.Series {
+	output := Compute(name, p, input)
+	if input.Len() == 0 {
+		return output
+	}
+
+	last := input.At(input.Len() - 1)
+	interval := input.Duration()
+	if interval <= 0 && input.Len() > 1 {
+		interval = last.Time().Sub(input.At(input.Len() - 2).Time())
+	}
+	if interval <= 0 {
+		return output
+	}
+	for k, fields := range p.Projection() {
+		projected := Output(last, fields)
+		projected.SetUnixNano(last.Time().Add(time.Duration(k+1) * interval).UnixNano())
+		output.Add(projected)
+	}
+	return output
+}
+
+// Stream runs the updater over a stream, dropping the empty outputs.
+func Stream(u Updater, input *stream.Stream) *stream.Stream {
+	return input.Map(func(t *tick.Tick) *tick.Tick {
+		if out := u.Update(t); !out.IsEmpty() {
+			return out
+		}
+		return nil
+	})
+}
+
+// Output returns a tick with the fields at the time of the input, carrying
+// its duration and a copy of its tags.
+func Output(input *tick.Tick, fields map[string]float64) *tick.Tick {
+	output := tick.New(tick.WithFields(fields), tick.WithTags(maps.Clone(input.Tags())))
+	output.SetDuration(input.Duration())
+	output.SetUnixNano(input.UnixNano())
+	return output
+}
+
+// Snapshot encodes an indicator state, a struct of exported fields. Gob keeps
+// floats exact, NaN and infinities included.
+func Snapshot(state any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(state); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Restore decodes a snapshot into a pointer to the indicator state. The state
+// is zeroed first, gob leaves out zero values.
+func Restore(data []byte, state any) error {
+	reflect.ValueOf(state).Elem().SetZero()
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(state)
+}
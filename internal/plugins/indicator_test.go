@@ -0,0 +1,26 @@
+package plugins
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+func TestOutputTags(t *testing.T) {
+	input := tick.New(
+		tick.WithTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+		tick.WithDuration(time.Minute),
+		tick.WithTags(map[string]string{"symbol": "BTC"}),
+	)
+	output := Output(input, map[string]float64{"sma": 1})
+	if output.Tags()["symbol"] != "BTC" || !output.Time().Equal(input.Time()) || output.Duration() != time.Minute {
+		t.Fatalf("output = %v at %v, want the tags and time of the input", output.Tags(), output.Time())
+	}
+
+	// tagging the output leaves the input alone
+	output.Tags()["signal"] = "buy"
+	if _, ok := input.Tags()["signal"]; ok {
+		t.Errorf("input tags = %v, shared with the output", input.Tags())
+	}
+}
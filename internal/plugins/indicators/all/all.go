@@ -3,6 +3,17 @@ package all
 import (
 
 	// indicators
+	_ "github.com/rangertaha/gotal/internal/plugins/indicators/bars"
+	_ "github.com/rangertaha/gotal/internal/plugins/indicators/candle"
+	_ "github.com/rangertaha/gotal/internal/plugins/indicators/cycle"
+	_ "github.com/rangertaha/gotal/internal/plugins/indicators/dmi"
+	_ "github.com/rangertaha/gotal/internal/plugins/indicators/ichimoku"
+	_ "github.com/rangertaha/gotal/internal/plugins/indicators/levels"
 	_ "github.com/rangertaha/gotal/internal/plugins/indicators/ma"
-	// _ "github.com/rangertaha/gotal/internal/plugins/indicators/macd"
+	_ "github.com/rangertaha/gotal/internal/plugins/indicators/macd"
+	_ "github.com/rangertaha/gotal/internal/plugins/indicators/momentum"
+	_ "github.com/rangertaha/gotal/internal/plugins/indicators/ohlc"
+	_ "github.com/rangertaha/gotal/internal/plugins/indicators/sar"
+	_ "github.com/rangertaha/gotal/internal/plugins/indicators/volatility"
+	_ "github.com/rangertaha/gotal/internal/plugins/indicators/volume"
 )
@@ -0,0 +1,226 @@
+package bars
+
+import (
+	"maps"
+	"math"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/series"
+	"github.com/rangertaha/gotal/internal/stream"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// kindTag is the tag naming the type of the bars, so indicators over them
+// know they are not time bars of a fixed duration.
+const kindTag = "bar"
+
+// builder turns ticks into bars.
+type builder interface {
+	// add adds the tick and returns the bars it completes, the oldest first
+	add(input *tick.Tick) []*tick.Tick
+
+	// warmup returns the fewest ticks completing a bar
+	warmup() int
+
+	// state returns a pointer to the state of the builder, with its fields
+	// exported so snapshots encode it
+	state() any
+}
+
+// sampler is the plugin of every bar type, it feeds the ticks to a builder.
+// Bars are stamped at the time of the tick completing them, without a
+// duration unless they are time bars, and tagged with their type. The bar
+// in progress is left out, so batch and streamed bars are the same.
+type sampler struct {
+	plugins.Plugin
+
+	kind    string
+	new     func() builder
+	builder builder
+	pending []*tick.Tick // bars completed but not returned yet, the oldest first
+}
+
+// samplerState is the snapshot of a sampler.
+type samplerState struct {
+	Builder []byte
+	Pending []record
+}
+
+// record is a bar in a snapshot.
+type record struct {
+	Time     int64
+	Duration time.Duration
+	Fields   map[string]float64
+	Tags     map[string]string
+}
+
+func newSampler(id, name, description, hcl, kind string, params internal.Options, fields []string, new func() builder) *sampler {
+	return &sampler{
+		Plugin: plugins.Plugin{
+			PID:         id,
+			Title:       name,
+			Summary:     description,
+			Template:    hcl,
+			Params:      params,
+			Fields:      fields,
+			Initialized: true,
+		},
+		kind:    kind,
+		new:     new,
+		builder: new(),
+	}
+}
+
+// trade returns the input fields of bars over trades, the price and the volume.
+func trade(params internal.Options) []string {
+	return []string{params.Field("price"), params.String("volume", "volume")}
+}
+
+func (i *sampler) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+// Compute returns the bars of the series.
+func (i *sampler) Compute(input *series.Series) (output *series.Series) {
+	b := i.new()
+	output = input.Spawn()
+	output.SetName(i.ID())
+	for _, t := range input.Ticks() {
+		output.Add(b.add(t)...)
+	}
+	return output
+}
+
+// Stream returns the bars of the stream.
+func (i *sampler) Stream(input *stream.Stream) *stream.Stream {
+	return input.FlatMap(i.new().add)
+}
+
+func (i *sampler) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the tick and returns the oldest bar completed and not returned
+// yet. A tick may complete several bars, the others are returned by Next or
+// by the following calls. When there is none, the output is an empty tick
+// tagged with the type of the bars.
+func (i *sampler) Update(input *tick.Tick) (output *tick.Tick) {
+	i.pending = append(i.pending, i.builder.add(input)...)
+	if len(i.pending) == 0 {
+		return i.empty()
+	}
+	return i.Next()
+}
+
+// Next returns the next bar completed by the ticks processed, an empty tick
+// when there is none left.
+func (i *sampler) Next() *tick.Tick {
+	if len(i.pending) == 0 {
+		return i.empty()
+	}
+	output := i.pending[0]
+	i.pending = i.pending[1:]
+	return output
+}
+
+// empty returns the output when no bar is completed, without fields but
+// tagged with the type of the bars.
+func (i *sampler) empty() *tick.Tick {
+	return tick.New(tick.WithTags(map[string]string{kindTag: i.kind}))
+}
+
+// Reset drops the bar in progress and the bars not returned yet.
+func (i *sampler) Reset() {
+	i.builder = i.new()
+	i.pending = nil
+}
+
+// Warmup returns the fewest ticks completing a bar, most bars take more.
+func (i *sampler) Warmup() int {
+	return i.builder.warmup()
+}
+
+func (i *sampler) Snapshot() ([]byte, error) {
+	builder, err := plugins.Snapshot(i.builder.state())
+	if err != nil {
+		return nil, err
+	}
+	s := samplerState{Builder: builder}
+	for _, bar := range i.pending {
+		s.Pending = append(s.Pending, record{Time: bar.UnixNano(), Duration: bar.Duration(), Fields: bar.Fields(), Tags: bar.Tags()})
+	}
+	return plugins.Snapshot(s)
+}
+
+func (i *sampler) Restore(state []byte) error {
+	var s samplerState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	b := i.new()
+	if err := plugins.Restore(s.Builder, b.state()); err != nil {
+		return err
+	}
+	pending := make([]*tick.Tick, len(s.Pending))
+	for k, r := range s.Pending {
+		pending[k] = tick.New(tick.WithFields(r.Fields), tick.WithTags(r.Tags), tick.WithDuration(r.Duration))
+		pending[k].SetUnixNano(r.Time)
+	}
+	i.builder, i.pending = b, pending
+	return nil
+}
+
+// bar accumulates the trades of a bar.
+type bar struct {
+	Open, High, Low, Close float64
+	Volume, Value          float64 // sums of the volume and of the price times the volume
+	Ticks                  int
+}
+
+func (b *bar) add(price, volume float64) {
+	if b.Ticks == 0 {
+		b.Open, b.High, b.Low = price, price, price
+	}
+	b.High, b.Low, b.Close = max(b.High, price), min(b.Low, price), price
+	b.Volume += volume
+	b.Value += price * volume
+	b.Ticks++
+}
+
+// fields returns the fields of the bar.
+func (b *bar) fields() map[string]float64 {
+	return map[string]float64{
+		"open":   b.Open,
+		"high":   b.High,
+		"low":    b.Low,
+		"close":  b.Close,
+		"volume": b.Volume,
+		"ticks":  float64(b.Ticks),
+	}
+}
+
+// read returns the price and the volume of the tick, a missing volume is 0.
+func read(input *tick.Tick, fields []string) (price, volume float64, ok bool) {
+	if price = input.GetField(fields[0]); math.IsNaN(price) {
+		return 0, 0, false
+	}
+	if volume = input.GetField(fields[1]); math.IsNaN(volume) {
+		volume = 0
+	}
+	return price, volume, true
+}
+
+// output returns the bar of the kind stamped at the time of the input, with
+// its tags.
+func output(input *tick.Tick, kind string, fields map[string]float64) *tick.Tick {
+	tags := maps.Clone(input.Tags())
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	tags[kindTag] = kind
+	t := tick.New(tick.WithFields(fields), tick.WithTags(tags))
+	t.SetUnixNano(input.UnixNano())
+	return t
+}
@@ -0,0 +1,414 @@
+package bars
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/series"
+	"github.com/rangertaha/gotal/internal/stream"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+var base = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// trades returns the prices and volumes every second.
+func trades(prices, volumes []float64) *series.Series {
+	s := series.New("trades")
+	for i, price := range prices {
+		fields := map[string]float64{"price": price}
+		if volumes != nil {
+			fields["volume"] = volumes[i]
+		}
+		s.Add(tick.New(
+			tick.WithTime(base.Add(time.Duration(i)*time.Second)),
+			tick.WithFields(fields),
+			tick.WithTags(map[string]string{"symbol": "BTC"}),
+		))
+	}
+	return s
+}
+
+func randomTrades(n int) *series.Series {
+	r := rand.New(rand.NewSource(25))
+	prices, volumes := make([]float64, n), make([]float64, n)
+	price := 100.0
+	for i := range n {
+		price += r.NormFloat64() * 0.5
+		prices[i], volumes[i] = price, 1+r.Float64()*9
+	}
+	return trades(prices, volumes)
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// check fails unless the bar has the fields.
+func check(t *testing.T, name string, k int, bar *tick.Tick, want map[string]float64) {
+	t.Helper()
+	for field, value := range want {
+		if got := bar.GetField(field); !near(got, value) {
+			t.Errorf("%s bar %d %s = %v, want %v", name, k, field, got, value)
+		}
+	}
+}
+
+func TestHeikinAshi(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	input := series.New("bars")
+	price := 100.0
+	for i := range 50 {
+		open := price
+		price += r.NormFloat64()
+		bar := tick.New(
+			tick.WithTime(base.Add(time.Duration(i)*time.Hour)),
+			tick.WithDuration(time.Hour),
+			tick.WithFields(map[string]float64{
+				"open": open, "high": math.Max(open, price) + r.Float64(), "low": math.Min(open, price) - r.Float64(), "close": price, "volume": 10,
+			}),
+		)
+		input.Add(bar)
+	}
+
+	output := heikinashiNew().Compute(input)
+	if output.Len() != input.Len() {
+		t.Fatalf("got %d bars, want %d", output.Len(), input.Len())
+	}
+	var prevOpen, prevClose float64
+	for k, bar := range input.Ticks() {
+		o, h, l, c := bar.GetField("open"), bar.GetField("high"), bar.GetField("low"), bar.GetField("close")
+		haClose, haOpen := (o+h+l+c)/4, (o+c)/2
+		if k > 0 {
+			haOpen = (prevOpen + prevClose) / 2
+		}
+		prevOpen, prevClose = haOpen, haClose
+
+		ha := output.At(k)
+		check(t, "HEIKINASHI", k, ha, map[string]float64{
+			"open": haOpen, "close": haClose, "high": math.Max(h, math.Max(haOpen, haClose)), "low": math.Min(l, math.Min(haOpen, haClose)), "volume": 10,
+		})
+		if ha.GetTag(kindTag) != "heikin_ashi" || !ha.Time().Equal(bar.Time()) || ha.Duration() != time.Hour {
+			t.Errorf("bar %d = %v at %v of %v, want a heikin_ashi bar at %v of 1h", k, ha.GetTag(kindTag), ha.Time(), ha.Duration(), bar.Time())
+		}
+	}
+}
+
+// renkoReference adds a brick above the top or below the bottom of the last
+// brick, a move of two boxes reversing the bricks.
+func renkoReference(prices []float64, box float64) (opens, closes []float64) {
+	high, low := prices[0], prices[0]
+	for _, price := range prices[1:] {
+		for price >= high+box {
+			opens, closes = append(opens, high), append(closes, high+box)
+			low, high = high, high+box
+		}
+		for price <= low-box {
+			opens, closes = append(opens, low), append(closes, low-box)
+			high, low = low, low-box
+		}
+	}
+	return opens, closes
+}
+
+func TestRenko(t *testing.T) {
+	input := randomTrades(500)
+	prices := make([]float64, input.Len())
+	for k, trade := range input.Ticks() {
+		prices[k] = trade.GetField("price")
+	}
+
+	output := renkoNew(opt.With("box", 1.0)).Compute(input)
+	opens, closes := renkoReference(prices, 1)
+	if output.Len() != len(opens) || len(opens) < 10 {
+		t.Fatalf("got %d bricks, want %d", output.Len(), len(opens))
+	}
+	ticks := 0.0
+	for k, brick := range output.Ticks() {
+		direction := 1.0
+		if closes[k] < opens[k] {
+			direction = -1
+		}
+		check(t, "RENKO", k, brick, map[string]float64{
+			"open": opens[k], "close": closes[k], "high": math.Max(opens[k], closes[k]), "low": math.Min(opens[k], closes[k]), "direction": direction,
+		})
+		ticks += brick.GetField("ticks")
+	}
+	if ticks > float64(input.Len()) {
+		t.Errorf("bricks have %v ticks, want at most %d", ticks, input.Len())
+	}
+
+	// the ATR of constant changes is the change
+	ramp := make([]float64, 40)
+	for k := range ramp {
+		ramp[k] = 100 + 0.5*float64(min(k, 40-k))
+	}
+	atr := renkoNew(opt.With("atr", 3), opt.With("box", 0.0)).Compute(trades(ramp, nil))
+	for k, brick := range atr.Ticks() {
+		if size := math.Abs(brick.GetField("close") - brick.GetField("open")); !near(size, 0.5) {
+			t.Errorf("ATR brick %d is %v, want 0.5", k, size)
+		}
+	}
+	if atr.Len() == 0 {
+		t.Error("got no ATR bricks")
+	}
+}
+
+func TestKagi(t *testing.T) {
+	prices := []float64{10, 11, 12, 11.5, 10, 10.5, 13, 12, 9, 10, 11.5, 10.5, 14, 12}
+	output := kagiNew(opt.With("reversal", 1.0)).Compute(trades(prices, nil))
+
+	want := []map[string]float64{
+		{"open": 10, "close": 12, "direction": 1, "yang": 1, "ticks": 4},
+		{"open": 12, "close": 10, "direction": -1, "yang": 1},
+		{"open": 10, "close": 13, "direction": 1, "yang": 1},
+		{"open": 13, "close": 9, "direction": -1, "yang": 0},
+		{"open": 9, "close": 11.5, "direction": 1, "yang": 0},
+		{"open": 11.5, "close": 10.5, "direction": -1, "yang": 0},
+		{"open": 10.5, "close": 14, "direction": 1, "yang": 1},
+	}
+	if output.Len() != len(want) {
+		t.Fatalf("got %d lines, want %d", output.Len(), len(want))
+	}
+	for k, line := range output.Ticks() {
+		check(t, "KAGI", k, line, want[k])
+	}
+
+	for _, test := range []struct {
+		prices []float64
+		lines  int
+	}{
+		{[]float64{100, 111, 99.8}, 1}, // 11.2 from 111 is over 10%
+		{[]float64{100, 111, 100}, 0},
+	} {
+		if got := kagiNew(opt.With("percent", 0.1)).Compute(trades(test.prices, nil)).Len(); got != test.lines {
+			t.Errorf("KAGI %v got %d lines, want %d", test.prices, got, test.lines)
+		}
+	}
+}
+
+func TestPNF(t *testing.T) {
+	prices := []float64{10, 11.2, 12.5, 13, 11, 10, 9.9, 12.2, 13.1, 14, 10.9}
+	output := pnfNew(opt.With("box", 1.0), opt.With("reversal", 3)).Compute(trades(prices, nil))
+
+	want := []map[string]float64{
+		{"open": 10, "close": 13, "high": 13, "low": 10, "direction": 1, "boxes": 4, "ticks": 5},
+		{"open": 12, "close": 10, "high": 12, "low": 10, "direction": -1, "boxes": 3, "ticks": 3},
+		{"open": 11, "close": 14, "high": 14, "low": 11, "direction": 1, "boxes": 4, "ticks": 2},
+	}
+	if output.Len() != len(want) {
+		t.Fatalf("got %d columns, want %d", output.Len(), len(want))
+	}
+	for k, column := range output.Ticks() {
+		check(t, "PNF", k, column, want[k])
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	input := randomTrades(1000)
+
+	for _, test := range []struct {
+		id   string
+		new  func(opts ...internal.PluginOptions) internal.Plugin
+		opts []internal.PluginOptions
+		done func(high, low, ticks, volume, value float64) bool
+	}{
+		{"RANGEBARS", rangeNew, []internal.PluginOptions{opt.With("range", 2.0)}, func(h, l, n, v, d float64) bool { return h-l >= 2 }},
+		{"TICKBARS", tickNew, []internal.PluginOptions{opt.With("ticks", 30)}, func(h, l, n, v, d float64) bool { return n >= 30 }},
+		{"VOLUMEBARS", volumeNew, []internal.PluginOptions{opt.With("size", 100.0)}, func(h, l, n, v, d float64) bool { return v >= 100 }},
+		{"DOLLARBARS", dollarNew, []internal.PluginOptions{opt.With("value", 20000.0)}, func(h, l, n, v, d float64) bool { return d >= 20000 }},
+	} {
+		var want []map[string]float64
+		var open, high, low, ticks, volume, value float64
+		for _, trade := range input.Ticks() {
+			price, size := trade.GetField("price"), trade.GetField("volume")
+			if ticks == 0 {
+				open, high, low = price, price, price
+			}
+			high, low = math.Max(high, price), math.Min(low, price)
+			ticks, volume, value = ticks+1, volume+size, value+price*size
+			if test.done(high, low, ticks, volume, value) {
+				want = append(want, map[string]float64{"open": open, "high": high, "low": low, "close": price, "volume": volume, "ticks": ticks})
+				ticks, volume, value = 0, 0, 0
+			}
+		}
+
+		output := test.new(test.opts...).Compute(input)
+		if output.Len() != len(want) || len(want) < 5 {
+			t.Fatalf("%s got %d bars, want %d", test.id, output.Len(), len(want))
+		}
+		for k, bar := range output.Ticks() {
+			check(t, test.id, k, bar, want[k])
+		}
+	}
+}
+
+// TestStream checks the streamed bars are the batch ones, tagged with their
+// type and the tags of the trades.
+func TestStream(t *testing.T) {
+	input := randomTrades(1000)
+
+	for _, test := range []struct {
+		kind string
+		new  func(opts ...internal.PluginOptions) internal.Plugin
+		opts []internal.PluginOptions
+	}{
+		{"renko", renkoNew, []internal.PluginOptions{opt.With("box", 0.5)}},
+		{"renko", renkoNew, []internal.PluginOptions{opt.With("atr", 14)}},
+		{"kagi", kagiNew, []internal.PluginOptions{opt.With("percent", 0.01)}},
+		{"pnf", pnfNew, []internal.PluginOptions{opt.With("box", 0.5)}},
+		{"range", rangeNew, []internal.PluginOptions{opt.With("range", 1.0)}},
+		{"tick", tickNew, []internal.PluginOptions{opt.With("ticks", 25)}},
+		{"volume", volumeNew, []internal.PluginOptions{opt.With("size", 50.0)}},
+		{"dollar", dollarNew, []internal.PluginOptions{opt.With("value", 10000.0)}},
+	} {
+		plugin := test.new(test.opts...)
+		if err := plugin.Init(); err != nil {
+			t.Fatalf("%s: %v", test.kind, err)
+		}
+		batch := plugin.Compute(input)
+		if batch.Len() == 0 {
+			t.Fatalf("%s: got no bars", test.kind)
+		}
+
+		live, err := plugin.(internal.Streamer).Stream(stream.New("trades", stream.WithTicks(input.Ticks()...))).Collect()
+		if err != nil {
+			t.Fatalf("%s: %v", test.kind, err)
+		}
+		if len(live) != batch.Len() {
+			t.Fatalf("%s: streamed %d bars, want %d", test.kind, len(live), batch.Len())
+		}
+
+		// processing the trades one by one returns every bar
+		one := plugin.(*sampler)
+		one.Reset()
+		var processed []*tick.Tick
+		for _, trade := range input.Ticks() {
+			for bar := one.Process(trade); !bar.IsEmpty(); bar = one.Next() {
+				processed = append(processed, bar)
+			}
+		}
+		if len(processed) != batch.Len() {
+			t.Fatalf("%s: processed %d bars, want %d", test.kind, len(processed), batch.Len())
+		}
+
+		for k, bar := range batch.Ticks() {
+			if bar.GetTag(kindTag) != test.kind || bar.GetTag("symbol") != "BTC" || bar.Duration() != 0 {
+				t.Errorf("%s bar %d has tags %v and duration %v", test.kind, k, bar.Tags(), bar.Duration())
+			}
+			if !live[k].Time().Equal(bar.Time()) {
+				t.Errorf("%s bar %d streamed at %v, want %v", test.kind, k, live[k].Time(), bar.Time())
+			}
+			check(t, test.kind, k, live[k], bar.Fields())
+			check(t, test.kind, k, processed[k], bar.Fields())
+		}
+	}
+}
+
+// TestProcess checks a trade completing several bricks returns them one by
+// one, the oldest first, and the others return an empty tick of the type.
+func TestProcess(t *testing.T) {
+	input := trades([]float64{100, 100.4, 103.5, 103.2, 101.1}, nil)
+	plugin := renkoNew(opt.With("box", 1.0)).(*sampler)
+
+	if bar := plugin.Process(input.At(0)); !bar.IsEmpty() || bar.GetTag(kindTag) != "renko" {
+		t.Errorf("first trade returned %v with tags %v, want an empty renko tick", bar.Fields(), bar.Tags())
+	}
+	plugin.Process(input.At(1))
+
+	// the jump to 103.5 completes three bricks
+	var closes []float64
+	for bar := plugin.Process(input.At(2)); !bar.IsEmpty(); bar = plugin.Next() {
+		closes = append(closes, bar.GetField("close"))
+	}
+	if want := []float64{101, 102, 103}; len(closes) != len(want) || closes[0] != want[0] || closes[2] != want[2] {
+		t.Errorf("bricks close at %v, want %v", closes, want)
+	}
+
+	// bricks not returned yet come first, Reset drops them
+	plugin.Reset()
+	plugin.Process(input.At(0))
+	plugin.Process(input.At(2))
+	if bar := plugin.Process(input.At(3)); bar.GetField("close") != 102 {
+		t.Errorf("second brick closes at %v, want 102", bar.GetField("close"))
+	}
+	plugin.Reset()
+	if bar := plugin.Next(); !bar.IsEmpty() || bar.GetTag(kindTag) != "renko" {
+		t.Errorf("Next() after Reset() = %v, want an empty renko tick", bar.Fields())
+	}
+}
+
+func TestParams(t *testing.T) {
+	for name, plugin := range map[string]internal.Plugin{
+		"renko without box":      renkoNew(opt.With("box", 0.0)),
+		"kagi without reversal":  kagiNew(opt.With("percent", 0.0)),
+		"kagi negative reversal": kagiNew(opt.With("reversal", -1.0)),
+		"pnf without box":        pnfNew(opt.With("box", 0.0)),
+		"range bars":             rangeNew(opt.With("range", -1.0)),
+		"tick bars":              tickNew(opt.With("ticks", 0)),
+		"volume bars":            volumeNew(opt.With("size", 0.0)),
+		"dollar bars":            dollarNew(opt.With("value", math.NaN())),
+	} {
+		if err := plugin.Init(); err == nil {
+			t.Errorf("%s: got no error", name)
+		}
+	}
+	if err := renkoNew(opt.With("atr", 14)).Init(); err != nil {
+		t.Errorf("renko with ATR: %v", err)
+	}
+}
+
+// TestSnapshot checks a sampler restored from its snapshot resumes the bar
+// in progress and returns the bars not returned yet.
+func TestSnapshot(t *testing.T) {
+	input := randomTrades(500)
+	for _, test := range []struct {
+		kind string
+		new  func(opts ...internal.PluginOptions) internal.Plugin
+		opts []internal.PluginOptions
+	}{
+		{"renko", renkoNew, []internal.PluginOptions{opt.With("box", 0.5)}},
+		{"renko", renkoNew, []internal.PluginOptions{opt.With("atr", 14)}},
+		{"kagi", kagiNew, []internal.PluginOptions{opt.With("percent", 0.01)}},
+		{"pnf", pnfNew, []internal.PluginOptions{opt.With("box", 0.5)}},
+		{"tick", tickNew, []internal.PluginOptions{opt.With("ticks", 25)}},
+		{"dollar", dollarNew, []internal.PluginOptions{opt.With("value", 10000.0)}},
+	} {
+		new := func() internal.Indicator { return test.new(test.opts...).(internal.Indicator) }
+		live, whole := new(), new()
+		var outputs, wholes []*tick.Tick
+		for k, trade := range input.Ticks() {
+			if k%100 == 50 {
+				state, err := live.Snapshot()
+				if err != nil {
+					t.Fatalf("%s: %v", test.kind, err)
+				}
+				live = new()
+				if err := live.Restore(state); err != nil {
+					t.Fatalf("%s: %v", test.kind, err)
+				}
+			}
+			if bar := live.Update(trade); !bar.IsEmpty() {
+				outputs = append(outputs, bar)
+			}
+			if bar := whole.Update(trade); !bar.IsEmpty() {
+				if k+1 < whole.Warmup() {
+					t.Errorf("%s: bar after %d trades, warmup %d", test.kind, k+1, whole.Warmup())
+				}
+				wholes = append(wholes, bar)
+			}
+		}
+		if len(outputs) == 0 || len(outputs) != len(wholes) {
+			t.Fatalf("%s: got %d bars, %d without the restart", test.kind, len(outputs), len(wholes))
+		}
+		for k, bar := range wholes {
+			if !outputs[k].Time().Equal(bar.Time()) || outputs[k].GetTag(kindTag) != test.kind {
+				t.Errorf("%s bar %d at %v with tags %v, want %v", test.kind, k, outputs[k].Time(), outputs[k].Tags(), bar.Time())
+			}
+			check(t, test.kind, k, outputs[k], bar.Fields())
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package bars
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// HA Close = (Open + High + Low + Close) / 4
+// HA Open = (Prev HA Open + Prev HA Close) / 2, (Open + Close) / 2 on the first bar
+// HA High = max(High, HA Open, HA Close), HA Low = min(Low, HA Open, HA Close)
+const heikinashiPluginID = "HEIKINASHI"
+const heikinashiPluginName = "Heikin-Ashi"
+const heikinashiPluginDescription = "Heikin-Ashi bars smooth the open, high, low and close of the bars with the ones of the previous bar."
+const heikinashiPluginHCL = `
+indicator "heikinashi" {
+  open = "open"
+  high = "high"
+  low = "low"
+  close = "close"
+}
+`
+
+// heikinashi builds a Heikin-Ashi bar of each OHLC bar, at its time and of
+// its duration.
+type heikinashi struct {
+	fields []string
+	volume string // volume field, carried over when the bar has it
+
+	heikinashiState
+}
+
+// heikinashiState is the snapshot of a heikinashi.
+type heikinashiState struct {
+	Count               int
+	PrevOpen, PrevClose float64
+}
+
+func heikinashiNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	fields := plugins.Bar(params, "open", "high", "low", "close")
+	volume := params.String("volume", "volume")
+	return newSampler(heikinashiPluginID, heikinashiPluginName, heikinashiPluginDescription, heikinashiPluginHCL, "heikin_ashi", params, fields, func() builder {
+		return &heikinashi{fields: fields, volume: volume}
+	})
+}
+
+func (b *heikinashi) warmup() int {
+	return 1
+}
+
+func (b *heikinashi) state() any {
+	return &b.heikinashiState
+}
+
+func (b *heikinashi) add(input *tick.Tick) []*tick.Tick {
+	values := make([]float64, len(b.fields))
+	for j, field := range b.fields {
+		if values[j] = input.GetField(field); math.IsNaN(values[j]) {
+			return nil
+		}
+	}
+	o, h, l, c := values[0], values[1], values[2], values[3]
+
+	haClose, haOpen := (o+h+l+c)/4, (o+c)/2
+	if b.Count > 0 {
+		haOpen = (b.PrevOpen + b.PrevClose) / 2
+	}
+	b.Count++
+	b.PrevOpen, b.PrevClose = haOpen, haClose
+
+	fields := map[string]float64{
+		"open":  haOpen,
+		"high":  max(h, haOpen, haClose),
+		"low":   min(l, haOpen, haClose),
+		"close": haClose,
+	}
+	if volume := input.GetField(b.volume); !math.IsNaN(volume) {
+		fields["volume"] = volume
+	}
+	bar := output(input, "heikin_ashi", fields)
+	bar.SetDuration(input.Duration())
+	bar.SetUnixNano(input.UnixNano())
+	return []*tick.Tick{bar}
+}
+
+func init() {
+	indicators.Add(heikinashiPluginID, heikinashiNew, indicators.OTHER)
+}
@@ -0,0 +1,139 @@
+package bars
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// A line follows the price in its direction and turns once the price moves
+// back from its extreme by the reversal amount, or by the reversal percent of
+// the extreme. A line turns yang, thick, when it rises above the previous
+// shoulder, the top of the last up line, and yin, thin, when it falls below
+// the previous waist, the bottom of the last down line.
+const kagiPluginID = "KAGI"
+const kagiPluginName = "Kagi"
+const kagiPluginDescription = "Kagi bars are the lines of the price between its reversals, thick above the last shoulder and thin below the last waist."
+const kagiPluginHCL = `
+indicator "kagi" {
+  field = "price"
+  reversal = 0
+  percent = 0.04
+}
+`
+
+// kagi builds a bar of each line once it turns, with its direction, 1 up and
+// -1 down, and yang, 1 when the line is thick and 0 when thin.
+type kagi struct {
+	fields   []string
+	reversal float64
+	percent  float64
+
+	kagiState
+}
+
+// kagiState is the snapshot of a kagi.
+type kagiState struct {
+	Started         bool
+	Direction       int
+	Start, Extreme  float64
+	Shoulder, Waist float64 // NaN until an up and a down line ended
+	Yang            bool
+	Bar             bar
+}
+
+func kagiNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	reversal, percent := params.Float("reversal", 0.0), params.Float("percent", 0.04)
+	if reversal < 0 || percent < 0 || reversal == 0 && percent == 0 {
+		params.AddError(fmt.Errorf("invalid kagi reversal: %v or %v percent", reversal, percent))
+		reversal, percent = 0, 0.04
+	}
+	fields := trade(params)
+	return newSampler(kagiPluginID, kagiPluginName, kagiPluginDescription, kagiPluginHCL, "kagi", params, fields, func() builder {
+		return &kagi{fields: fields, reversal: reversal, percent: percent, kagiState: kagiState{Shoulder: math.NaN(), Waist: math.NaN()}}
+	})
+}
+
+// warmup returns the first price, the one turning it and the one reversing it.
+func (b *kagi) warmup() int {
+	return 3
+}
+
+func (b *kagi) state() any {
+	return &b.kagiState
+}
+
+// turn returns the move from the extreme turning the line.
+func (b *kagi) turn() float64 {
+	if b.reversal > 0 {
+		return b.reversal
+	}
+	return b.percent * math.Abs(b.Extreme)
+}
+
+func (b *kagi) add(input *tick.Tick) []*tick.Tick {
+	price, volume, ok := read(input, b.fields)
+	if !ok {
+		return nil
+	}
+	if !b.Started {
+		b.Started, b.Start, b.Extreme = true, price, price
+		b.Bar.add(price, volume)
+		return nil
+	}
+
+	var bars []*tick.Tick
+	switch {
+	case b.Direction == 0 && math.Abs(price-b.Start) >= b.turn():
+		b.Direction, b.Extreme, b.Yang = sign(price-b.Start), price, price > b.Start
+	case b.Direction > 0 && price > b.Extreme, b.Direction < 0 && price < b.Extreme:
+		b.Extreme = price
+	case b.Direction != 0 && float64(b.Direction)*(b.Extreme-price) >= b.turn():
+		bars = append(bars, b.line(input))
+		b.Direction, b.Start, b.Extreme = -b.Direction, b.Extreme, price
+		b.Bar = bar{}
+	}
+	b.Bar.add(price, volume)
+	return bars
+}
+
+// line ends the line and returns its bar.
+func (b *kagi) line(input *tick.Tick) *tick.Tick {
+	if b.Direction > 0 {
+		if b.Extreme > b.Shoulder {
+			b.Yang = true
+		}
+		b.Shoulder = b.Extreme
+	} else {
+		if b.Extreme < b.Waist {
+			b.Yang = false
+		}
+		b.Waist = b.Extreme
+	}
+
+	fields := b.Bar.fields()
+	fields["open"], fields["close"] = b.Start, b.Extreme
+	fields["high"], fields["low"] = max(b.Start, b.Extreme), min(b.Start, b.Extreme)
+	fields["direction"] = float64(b.Direction)
+	fields["yang"] = 0
+	if b.Yang {
+		fields["yang"] = 1
+	}
+	return output(input, "kagi", fields)
+}
+
+func sign(x float64) int {
+	if x < 0 {
+		return -1
+	}
+	return 1
+}
+
+func init() {
+	indicators.Add(kagiPluginID, kagiNew, indicators.OTHER)
+}
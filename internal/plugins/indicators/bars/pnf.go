@@ -0,0 +1,129 @@
+package bars
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// A column of Xs rises a box each time the price reaches the next box above
+// it, a column of Os falls a box each time the price reaches the next box
+// below it, and a new column starts a box back once the price moves reversal
+// boxes the other way.
+const pnfPluginID = "PNF"
+const pnfPluginName = "Point and Figure"
+const pnfPluginDescription = "Point and Figure bars are the columns of rising and falling boxes of the price between its reversals."
+const pnfPluginHCL = `
+indicator "pnf" {
+  field = "price"
+  box = 1
+  reversal = 3
+}
+`
+
+// pnf builds a bar of each column once it ends, from its first to its last
+// box, with its direction, 1 for Xs and -1 for Os, and its boxes.
+type pnf struct {
+	fields   []string
+	box      float64
+	reversal int
+
+	pnfState
+}
+
+// pnfState is the snapshot of a pnf.
+type pnfState struct {
+	Started     bool
+	Direction   int
+	Base        float64 // box of the first price
+	Top, Bottom float64 // boxes of the column
+	Bar         bar
+}
+
+func pnfNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	box, reversal := params.Float("box", 1.0), max(params.Int("reversal", 3), 1)
+	if !(box > 0) {
+		params.AddError(fmt.Errorf("invalid point and figure box: %v", box))
+		box = 1
+	}
+	fields := trade(params)
+	return newSampler(pnfPluginID, pnfPluginName, pnfPluginDescription, pnfPluginHCL, "pnf", params, fields, func() builder {
+		return &pnf{fields: fields, box: box, reversal: reversal}
+	})
+}
+
+// warmup returns the first price, the one starting its column and the one
+// reversing it.
+func (b *pnf) warmup() int {
+	return 3
+}
+
+func (b *pnf) state() any {
+	return &b.pnfState
+}
+
+// floor and ceil return the box at or below and at or above the price,
+// tolerating the rounding of prices on a box.
+func (b *pnf) floor(price float64) float64 {
+	return math.Floor(price/b.box+1e-9) * b.box
+}
+
+func (b *pnf) ceil(price float64) float64 {
+	return math.Ceil(price/b.box-1e-9) * b.box
+}
+
+func (b *pnf) add(input *tick.Tick) []*tick.Tick {
+	price, volume, ok := read(input, b.fields)
+	if !ok {
+		return nil
+	}
+	if !b.Started {
+		b.Started, b.Base = true, b.floor(price)
+		b.Bar.add(price, volume)
+		return nil
+	}
+
+	var bars []*tick.Tick
+	r := float64(b.reversal) * b.box
+	switch {
+	case b.Direction == 0 && price >= b.Base+b.box:
+		b.Direction, b.Bottom, b.Top = 1, b.Base, b.floor(price)
+	case b.Direction == 0 && price <= b.Base-b.box:
+		b.Direction, b.Top, b.Bottom = -1, b.Base, b.ceil(price)
+	case b.Direction > 0 && price >= b.Top+b.box:
+		b.Top = b.floor(price)
+	case b.Direction < 0 && price <= b.Bottom-b.box:
+		b.Bottom = b.ceil(price)
+	case b.Direction > 0 && price <= b.Top-r:
+		bars = append(bars, b.column(input))
+		b.Direction, b.Top, b.Bottom = -1, b.Top-b.box, b.ceil(price)
+	case b.Direction < 0 && price >= b.Bottom+r:
+		bars = append(bars, b.column(input))
+		b.Direction, b.Bottom, b.Top = 1, b.Bottom+b.box, b.floor(price)
+	}
+	b.Bar.add(price, volume)
+	return bars
+}
+
+// column ends the column and returns its bar.
+func (b *pnf) column(input *tick.Tick) *tick.Tick {
+	fields := b.Bar.fields()
+	fields["open"], fields["close"] = b.Bottom, b.Top
+	if b.Direction < 0 {
+		fields["open"], fields["close"] = b.Top, b.Bottom
+	}
+	fields["high"], fields["low"] = b.Top, b.Bottom
+	fields["direction"] = float64(b.Direction)
+	fields["boxes"] = math.Round((b.Top-b.Bottom)/b.box) + 1
+	b.Bar = bar{}
+	return output(input, "pnf", fields)
+}
+
+func init() {
+	indicators.Add(pnfPluginID, pnfNew, indicators.OTHER)
+}
@@ -0,0 +1,162 @@
+package bars
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// A brick of one box is added each time the price moves a box beyond the
+// last brick in its direction, and the direction reverses once the price
+// moves reversal boxes the other way. The box is fixed, or the ATR of the
+// price changes when the ATR period is set.
+const renkoPluginID = "RENKO"
+const renkoPluginName = "Renko"
+const renkoPluginDescription = "Renko bars are bricks of a fixed price move, or of the ATR, regardless of time."
+const renkoPluginHCL = `
+indicator "renko" {
+  field = "price"
+  box = 1
+  atr = 0
+  reversal = 2
+}
+`
+
+// renko builds the bricks, with their direction, 1 up and -1 down. The
+// volume and the ticks of a move are on its first brick.
+type renko struct {
+	fields   []string
+	box      float64
+	reversal int
+
+	renkoState
+}
+
+// renkoState is the snapshot of a renko.
+type renkoState struct {
+	ATR       *atr // nil for a fixed box
+	Started   bool
+	Base      float64 // close of the last brick
+	Direction int
+	Bar       bar
+}
+
+func renkoNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	box, period, reversal := params.Float("box", 0.0), params.Int("atr", 0), max(params.Int("reversal", 2), 1)
+	if period <= 0 && !(box > 0) {
+		params.AddError(fmt.Errorf("invalid renko box: %v without an ATR period", box))
+		box = 1
+	}
+	fields := trade(params)
+	return newSampler(renkoPluginID, renkoPluginName, renkoPluginDescription, renkoPluginHCL, "renko", params, fields, func() builder {
+		b := &renko{fields: fields, box: box, reversal: reversal}
+		if period > 0 {
+			b.ATR = &atr{Period: period}
+		}
+		return b
+	})
+}
+
+// warmup returns the prices seeding the ATR and starting the bricks, and the
+// one completing the first brick.
+func (b *renko) warmup() int {
+	if b.ATR != nil {
+		return b.ATR.Period + 2
+	}
+	return 2
+}
+
+func (b *renko) state() any {
+	return &b.renkoState
+}
+
+func (b *renko) add(input *tick.Tick) (bars []*tick.Tick) {
+	price, volume, ok := read(input, b.fields)
+	if !ok {
+		return nil
+	}
+	b.Bar.add(price, volume)
+
+	box := b.box
+	if b.ATR != nil {
+		if !b.ATR.add(price) {
+			b.Base = price
+			return nil
+		}
+		box = b.ATR.Value
+	}
+	if !b.Started {
+		b.Started, b.Base = true, price
+		return nil
+	}
+	if !(box > 0) {
+		return nil
+	}
+
+	// a reversal starts from the open of the last brick
+	r := float64(b.reversal)
+	switch {
+	case b.Direction > 0 && price <= b.Base-r*box:
+		b.Base, b.Direction = b.Base-(r-1)*box, -1
+	case b.Direction < 0 && price >= b.Base+r*box:
+		b.Base, b.Direction = b.Base+(r-1)*box, 1
+	case b.Direction >= 0 && price >= b.Base+box:
+		b.Direction = 1
+	case b.Direction <= 0 && price <= b.Base-box:
+		b.Direction = -1
+	default:
+		return nil
+	}
+
+	step := box * float64(b.Direction)
+	for b.Direction > 0 && price >= b.Base+box || b.Direction < 0 && price <= b.Base-box {
+		fields := b.Bar.fields()
+		fields["open"], fields["close"] = b.Base, b.Base+step
+		fields["high"], fields["low"] = max(b.Base, b.Base+step), min(b.Base, b.Base+step)
+		fields["direction"] = float64(b.Direction)
+		bars = append(bars, output(input, "renko", fields))
+		b.Base += step
+		b.Bar = bar{}
+	}
+	return bars
+}
+
+// atr is the Wilder average of the absolute price changes, seeded with their
+// mean over the first period changes.
+type atr struct {
+	Period int
+	Count  int
+	Prev   float64
+	Value  float64
+}
+
+// add adds a price and reports whether the average is warmed up.
+func (a *atr) add(price float64) bool {
+	a.Count++
+	prev := a.Prev
+	a.Prev = price
+	if a.Count == 1 {
+		return false
+	}
+
+	change, n := math.Abs(price-prev), float64(a.Period)
+	switch {
+	case a.Count <= a.Period:
+		a.Value += change
+		return false
+	case a.Count == a.Period+1:
+		a.Value = (a.Value + change) / n
+	default:
+		a.Value = (a.Value*(n-1) + change) / n
+	}
+	return true
+}
+
+func init() {
+	indicators.Add(renkoPluginID, renkoNew, indicators.OTHER)
+}
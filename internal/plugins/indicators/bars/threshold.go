@@ -0,0 +1,136 @@
+package bars
+
+import (
+	"fmt"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// Threshold bars end on the tick taking a sum of the trades over a threshold:
+// the range of the prices, the count of the trades, their volume or their
+// value, the price times the volume.
+const rangePluginID = "RANGEBARS"
+const rangePluginName = "Range Bars"
+const rangePluginDescription = "Range Bars end once the high and the low of the prices are the range apart."
+const rangePluginHCL = `
+indicator "rangebars" {
+  field = "price"
+  range = 1
+}
+`
+
+const tickPluginID = "TICKBARS"
+const tickPluginName = "Tick Bars"
+const tickPluginDescription = "Tick Bars end every number of trades."
+const tickPluginHCL = `
+indicator "tickbars" {
+  field = "price"
+  ticks = 100
+}
+`
+
+const volumePluginID = "VOLUMEBARS"
+const volumePluginName = "Volume Bars"
+const volumePluginDescription = "Volume Bars end once the volume traded reaches the size."
+const volumePluginHCL = `
+indicator "volumebars" {
+  field = "price"
+  volume = "volume"
+  size = 1000
+}
+`
+
+const dollarPluginID = "DOLLARBARS"
+const dollarPluginName = "Dollar Bars"
+const dollarPluginDescription = "Dollar Bars end once the value traded, the price times the volume, reaches the value."
+const dollarPluginHCL = `
+indicator "dollarbars" {
+  field = "price"
+  volume = "volume"
+  value = 100000
+}
+`
+
+// threshold builds a bar of the trades until done reports the bar ended.
+type threshold struct {
+	fields []string
+	kind   string
+	least  int // fewest trades of a bar
+	done   func(b *bar) bool
+	Bar    bar
+}
+
+func (b *threshold) warmup() int {
+	return b.least
+}
+
+func (b *threshold) state() any {
+	return &b.Bar
+}
+
+func (b *threshold) add(input *tick.Tick) []*tick.Tick {
+	price, volume, ok := read(input, b.fields)
+	if !ok {
+		return nil
+	}
+	b.Bar.add(price, volume)
+	if !b.done(&b.Bar) {
+		return nil
+	}
+	fields := b.Bar.fields()
+	b.Bar = bar{}
+	return []*tick.Tick{output(input, b.kind, fields)}
+}
+
+// newThreshold returns the plugin of the bars ending once the measure of the
+// bar reaches the float parameter.
+func newThreshold(id, name, description, hcl, kind, param string, fallback float64, measure func(b *bar) float64, opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	limit := params.Float(param, fallback)
+	if !(limit > 0) {
+		params.AddError(fmt.Errorf("invalid %s bars %s: %v", kind, param, limit))
+		limit = fallback
+	}
+	fields := trade(params)
+	return newSampler(id, name, description, hcl, kind, params, fields, func() builder {
+		return &threshold{fields: fields, kind: kind, least: 1, done: func(b *bar) bool { return measure(b) >= limit }}
+	})
+}
+
+func rangeNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newThreshold(rangePluginID, rangePluginName, rangePluginDescription, rangePluginHCL, "range", "range", 1,
+		func(b *bar) float64 { return b.High - b.Low }, opts...)
+}
+
+func tickNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	ticks := params.Int("ticks", 100)
+	if ticks <= 0 {
+		params.AddError(fmt.Errorf("invalid tick bars ticks: %v", ticks))
+		ticks = 100
+	}
+	fields := trade(params)
+	return newSampler(tickPluginID, tickPluginName, tickPluginDescription, tickPluginHCL, "tick", params, fields, func() builder {
+		return &threshold{fields: fields, kind: "tick", least: ticks, done: func(b *bar) bool { return b.Ticks >= ticks }}
+	})
+}
+
+func volumeNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newThreshold(volumePluginID, volumePluginName, volumePluginDescription, volumePluginHCL, "volume", "size", 1000,
+		func(b *bar) float64 { return b.Volume }, opts...)
+}
+
+func dollarNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newThreshold(dollarPluginID, dollarPluginName, dollarPluginDescription, dollarPluginHCL, "dollar", "value", 100000,
+		func(b *bar) float64 { return b.Value }, opts...)
+}
+
+func init() {
+	indicators.Add(rangePluginID, rangeNew, indicators.OTHER)
+	indicators.Add(tickPluginID, tickNew, indicators.OTHER)
+	indicators.Add(volumePluginID, volumeNew, indicators.OTHER)
+	indicators.Add(dollarPluginID, dollarNew, indicators.OTHER)
+}
@@ -0,0 +1,259 @@
+package candle
+
+import (
+	"math"
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/series"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// candle is one OHLC bar.
+type candle struct {
+	Open, High, Low, Close float64
+}
+
+// body returns the length of the real body.
+func (c candle) body() float64 {
+	return math.Abs(c.Close - c.Open)
+}
+
+// top returns the top of the real body.
+func (c candle) top() float64 {
+	return math.Max(c.Close, c.Open)
+}
+
+// bottom returns the bottom of the real body.
+func (c candle) bottom() float64 {
+	return math.Min(c.Close, c.Open)
+}
+
+// upper returns the length of the upper shadow.
+func (c candle) upper() float64 {
+	return c.High - c.top()
+}
+
+// lower returns the length of the lower shadow.
+func (c candle) lower() float64 {
+	return c.bottom() - c.Low
+}
+
+// color is 1 for white (rising) candles and -1 for black ones.
+func (c candle) color() int {
+	if c.Close >= c.Open {
+		return 1
+	}
+	return -1
+}
+
+// bodyGapUp reports whether the real body of c opens a gap up from the one of prev.
+func bodyGapUp(c, prev candle) bool {
+	return c.bottom() > prev.top()
+}
+
+// bodyGapDown reports whether the real body of c opens a gap down from the one of prev.
+func bodyGapDown(c, prev candle) bool {
+	return c.top() < prev.bottom()
+}
+
+// gapUp reports whether c opens a gap up from prev, shadows included.
+func gapUp(c, prev candle) bool {
+	return c.Low > prev.High
+}
+
+// gapDown reports whether c opens a gap down from prev, shadows included.
+func gapDown(c, prev candle) bool {
+	return c.High < prev.Low
+}
+
+// history holds the last candles added.
+type history struct {
+	Candles []candle
+	Pos     int
+	Len     int
+}
+
+func newHistory(size int) history {
+	return history{Candles: make([]candle, max(size, 1))}
+}
+
+func (h *history) push(c candle) {
+	h.Candles[h.Pos] = c
+	h.Pos = (h.Pos + 1) % len(h.Candles)
+	h.Len = min(h.Len+1, len(h.Candles))
+}
+
+// at returns the candle added k candles ago, 0 is the newest.
+func (h *history) at(k int) candle {
+	return h.Candles[(h.Pos-1-k+2*len(h.Candles))%len(h.Candles)]
+}
+
+// engine detects candlestick patterns on the candles added, comparing their
+// bodies and shadows with the average ranges of the candles before them as
+// set by the Settings.
+type engine struct {
+	Settings Settings
+	History  history
+	Count    int // candles added
+
+	// patterns confirmed after they formed
+	Hikkake, HikkakeMod hikkake
+}
+
+func newEngine(settings Settings) *engine {
+	size := 0
+	for _, s := range settings {
+		size = max(size, s.Period)
+	}
+	// the longest patterns span 5 candles after the averaged ones
+	return &engine{Settings: settings, History: newHistory(size + 6)}
+}
+
+func (e *engine) add(c candle) {
+	e.History.push(c)
+	e.Count++
+}
+
+// at returns the candle added k candles ago, 0 is the newest.
+func (e *engine) at(k int) candle {
+	return e.History.at(k)
+}
+
+// avg returns the threshold of the setting for the candle k candles ago,
+// relative to the average range of the candles before it.
+func (e *engine) avg(t settingType, k int) float64 {
+	s := e.Settings[t]
+	value := s.Range.of(e.at(k))
+	if s.Period > 0 {
+		sum := 0.0
+		for j := k + s.Period; j > k; j-- {
+			sum += s.Range.of(e.at(j))
+		}
+		value = sum / float64(s.Period)
+	}
+	if s.Range == shadows {
+		return s.Factor * value / 2
+	}
+	return s.Factor * value
+}
+
+// detect returns the pattern detected at the last candle, 0 until its
+// lookback is filled.
+func (e *engine) detect(p *pattern, penetration float64) int {
+	lookback := p.lookback(&e.Settings)
+	if e.Count <= lookback-p.Scan {
+		return 0
+	}
+	result := p.Detect(e, penetration)
+	if e.Count <= lookback {
+		return 0
+	}
+	return result
+}
+
+// detector is the plugin of every candlestick pattern, it outputs the TA-Lib
+// value of the pattern on each candle and signals the detections.
+type detector struct {
+	plugins.Plugin
+
+	pattern     *pattern
+	settings    Settings
+	penetration float64
+	output      string
+	engine      *engine
+}
+
+// detectorState is the snapshot of a detector.
+type detectorState struct {
+	Engine *engine
+}
+
+func newDetector(p *pattern, opts ...internal.PluginOptions) *detector {
+	params := opt.New(opts...)
+	i := &detector{
+		Plugin: plugins.Plugin{
+			PID:         p.ID,
+			Title:       p.Name,
+			Summary:     p.Description,
+			Template:    p.template(),
+			Params:      params,
+			Fields:      plugins.Bar(params, "open", "high", "low", "close"),
+			Initialized: true,
+		},
+		pattern:     p,
+		settings:    newSettings(params),
+		penetration: params.Float("penetration", p.Penetration),
+		output:      params.Output(strings.ToLower(p.ID)),
+	}
+	i.Reset()
+	return i
+}
+
+func (i *detector) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+func (i *detector) Compute(input *series.Series) (output *series.Series) {
+	return plugins.Compute(i.ID(), i, input)
+}
+
+func (i *detector) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the candle and returns the pattern value, 100 or -100 when the
+// pattern is detected and 0 otherwise, with its signal. Ticks missing an
+// OHLC field are skipped.
+func (i *detector) Update(input *tick.Tick) (output *tick.Tick) {
+	c, ok := read(input, i.Fields)
+	if !ok {
+		return tick.New()
+	}
+	i.engine.add(c)
+	result := i.engine.detect(i.pattern, i.penetration)
+	if i.engine.Count < i.Warmup() {
+		return tick.New()
+	}
+
+	output = plugins.Output(input, map[string]float64{i.output: float64(result)})
+	if result != 0 {
+		signal, strength := i.pattern.signal(result)
+		output.SetSignal(signal, strength)
+	}
+	return output
+}
+
+// read returns the candle of the OHLC fields of the tick.
+func read(input *tick.Tick, fields []string) (c candle, ok bool) {
+	values := make([]float64, len(fields))
+	for j, field := range fields {
+		if values[j] = input.GetField(field); math.IsNaN(values[j]) {
+			return c, false
+		}
+	}
+	return candle{Open: values[0], High: values[1], Low: values[2], Close: values[3]}, true
+}
+
+func (i *detector) Reset() {
+	i.engine = newEngine(i.settings)
+}
+
+func (i *detector) Warmup() int {
+	return i.pattern.lookback(&i.settings) + 1
+}
+
+func (i *detector) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(detectorState{Engine: i.engine})
+}
+
+func (i *detector) Restore(state []byte) error {
+	var s detectorState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.engine = s.Engine
+	return nil
+}
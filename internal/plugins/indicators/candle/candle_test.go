@@ -0,0 +1,193 @@
+package candle
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+func bars(cs ...candle) *series.Series {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := series.New("bars")
+	for i, c := range cs {
+		s.Add(tick.New(
+			tick.WithTime(base.Add(time.Duration(i)*time.Minute)),
+			tick.WithFields(map[string]float64{"open": c.Open, "high": c.High, "low": c.Low, "close": c.Close}),
+		))
+	}
+	return s
+}
+
+// flat returns n candles alternating between white and black bodies of 1
+// with shadows of 0.5, so the averages are known: bodies of 1 and ranges of 2.
+func flat(n int) []candle {
+	cs := make([]candle, n)
+	for i := range cs {
+		cs[i] = candle{Open: 100, High: 101.5, Low: 99.5, Close: 101}
+		if i%2 == 1 {
+			cs[i].Open, cs[i].Close = 101, 100
+		}
+	}
+	return cs
+}
+
+func randomCandles(n int) []candle {
+	r := rand.New(rand.NewSource(21))
+	cs := make([]candle, n)
+	price := 100.0
+	for i := range cs {
+		open := price + r.NormFloat64()*0.3
+		price = open + r.NormFloat64()
+		// some doji
+		if r.Intn(8) == 0 {
+			price = open + r.NormFloat64()*0.02
+		}
+		cs[i] = candle{
+			Open:  open,
+			High:  math.Max(open, price) + math.Abs(r.NormFloat64())*0.5,
+			Low:   math.Min(open, price) - math.Abs(r.NormFloat64())*0.5,
+			Close: price,
+		}
+	}
+	return cs
+}
+
+// last returns the output of the pattern at the last candle.
+func last(t *testing.T, id string, cs []candle, opts ...internal.PluginOptions) *tick.Tick {
+	t.Helper()
+	p := find(id)
+	if p == nil {
+		t.Fatalf("unknown pattern %s", id)
+	}
+	output := newDetector(p, opts...).Compute(bars(cs...))
+	if output.Len() == 0 {
+		t.Fatalf("%s: no output", id)
+	}
+	return output.At(output.Len() - 1)
+}
+
+func TestPatterns(t *testing.T) {
+	for _, test := range []struct {
+		id       string
+		candles  []candle
+		want     int
+		signal   sig.Signal
+		strength sig.Strength
+	}{
+		{"CDLDOJI", []candle{{100, 101, 99, 100.05}}, 100, sig.NEUTRAL, sig.WEAK},
+		{"CDLHAMMER", []candle{{99.6, 99.85, 98, 99.8}}, 100, sig.BULLISH, sig.MEDIUM},
+		{"CDLENGULFING", []candle{{99.8, 101.6, 99.7, 101.5}}, 100, sig.BULLISH, sig.MEDIUM},
+		{"CDLENGULFING", []candle{{100, 101.5, 99.5, 101}, {101.2, 101.3, 99.4, 99.5}}, -100, sig.BEARISH, sig.MEDIUM},
+		{"CDLMORNINGSTAR", []candle{{102, 102.2, 98.8, 99}, {98.5, 98.6, 98.2, 98.3}, {98.6, 101.1, 98.5, 101}}, 100, sig.BULLISH, sig.STRONG},
+		{"CDLEVENINGSTAR", []candle{{99, 102.2, 98.8, 102}, {102.5, 102.8, 102.4, 102.7}, {102.4, 102.5, 99.9, 100}}, -100, sig.BEARISH, sig.STRONG},
+		{"CDL3WHITESOLDIERS", []candle{{100, 101.25, 99.9, 101.2}, {101, 102.35, 100.9, 102.3}, {102.1, 103.45, 102, 103.4}}, 100, sig.BULLISH, sig.STRONG},
+		{"CDL3BLACKCROWS", []candle{{100, 101.5, 99.5, 101}, {101.2, 101.3, 99.95, 100}, {100.5, 100.6, 99.15, 99.2}, {99.7, 99.8, 98.35, 98.4}}, -100, sig.BEARISH, sig.STRONG},
+		{"CDLHIKKAKE", []candle{{99, 102, 98, 101}, {100.5, 101, 99, 99.5}, {100, 100.5, 98.5, 99}}, 100, sig.BULLISH, sig.STRONG},
+		{"CDLHIKKAKE", []candle{{99, 102, 98, 101}, {100.5, 101, 99, 99.5}, {100, 100.5, 98.5, 99}, {99.5, 101.6, 99.4, 101.5}}, 200, sig.BULLISH, sig.STRONG},
+	} {
+		name := fmt.Sprintf("%s %v", test.id, test.candles)
+		out := last(t, test.id, append(flat(12), test.candles...))
+		if got := int(out.GetField(strings.ToLower(test.id))); got != test.want {
+			t.Errorf("%s = %d, want %d", name, got, test.want)
+			continue
+		}
+		if !out.HasSignal(test.signal) || out.GetSignal(test.signal) != test.strength || len(out.Signals()) != 1 {
+			t.Errorf("%s: signals %v, want %v %v", name, out.Signals(), test.signal, test.strength)
+		}
+	}
+}
+
+func TestSettings(t *testing.T) {
+	doji := append(flat(12), candle{100, 101, 99, 100.05})
+	if got := last(t, "CDLDOJI", doji, opt.With("body_doji", 0.01)).GetField("cdldoji"); got != 0 {
+		t.Errorf("CDLDOJI with body_doji 0.01 = %v, want 0", got)
+	}
+	if got := last(t, "CDLDOJI", doji, opt.With("body_doji", 0.5)).GetField("cdldoji"); got != 100 {
+		t.Errorf("CDLDOJI with body_doji 0.5 = %v, want 100", got)
+	}
+
+	// the period sets the warm-up
+	i := newDetector(find("CDLDOJI"), opt.With("body_doji_period", 3))
+	if i.Warmup() != 4 {
+		t.Errorf("Warmup() = %d with body_doji_period 3, want 4", i.Warmup())
+	}
+
+	for _, o := range []internal.PluginOptions{opt.With("body_doji", -1.0), opt.With("body_doji_period", -1)} {
+		if err := newDetector(find("CDLDOJI"), o).Init(); err == nil {
+			t.Error("Init() with an invalid setting succeeded")
+		}
+	}
+}
+
+func TestWarmup(t *testing.T) {
+	cs := randomCandles(300)
+	detected := 0
+	for _, p := range patterns {
+		i := newDetector(p)
+		output := i.Compute(bars(cs...))
+		if output.Len() != len(cs)-i.Warmup()+1 {
+			t.Errorf("%s: got %d outputs with Warmup() %d, want %d", p.ID, output.Len(), i.Warmup(), len(cs)-i.Warmup()+1)
+		}
+		for k := range output.Len() {
+			if output.At(k).GetField(strings.ToLower(p.ID)) != 0 {
+				detected++
+			}
+		}
+	}
+	if detected < 100 {
+		t.Errorf("detected %d patterns over random candles, want at least 100", detected)
+	}
+}
+
+func TestCandles(t *testing.T) {
+	cs := randomCandles(300)
+	input := bars(cs...)
+
+	// every pattern as by its own indicator
+	all := candlesNew().(internal.Indicator)
+	output := all.Compute(input)
+	if output.Len() != len(cs)-all.Warmup()+1 {
+		t.Fatalf("got %d outputs with Warmup() %d", output.Len(), all.Warmup())
+	}
+	offset := len(cs) - output.Len()
+	for _, p := range patterns {
+		want := newDetector(p).Compute(input)
+		field := strings.ToLower(p.ID)
+		for k := range output.Len() {
+			if got, want := output.At(k).GetField(field), want.At(k+offset-(len(cs)-want.Len())).GetField(field); got != want {
+				t.Errorf("%s[%d] = %v, want %v", field, k, got, want)
+			}
+		}
+	}
+
+	// selected patterns, with or without the prefix
+	i := candlesNew(opt.With("patterns", []string{"doji", "CDLMORNINGSTAR"}))
+	if err := i.Init(); err != nil {
+		t.Fatal(err)
+	}
+	out := i.Compute(bars(append(flat(12), candle{102, 102.2, 98.8, 99}, candle{98.5, 98.6, 98.2, 98.3}, candle{98.6, 101.1, 98.5, 101})...))
+	got := out.At(out.Len() - 1)
+	if len(got.Fields()) != 2 || got.GetField("cdlmorningstar") != 100 || got.GetSignal(sig.BULLISH) != sig.STRONG {
+		t.Errorf("CANDLES = %v with signals %v, want a morning star", got.Fields(), got.Signals())
+	}
+
+	if err := candlesNew(opt.With("patterns", []string{"CDLUNKNOWN"})).Init(); err == nil {
+		t.Error("Init() with an unknown pattern succeeded")
+	}
+}
+
+func TestCandlesSnapshot(t *testing.T) {
+	indicatortest.SnapshotReplay(t, func() internal.Indicator {
+		return candlesNew().(internal.Indicator)
+	}, bars(randomCandles(120)...), 40)
+}
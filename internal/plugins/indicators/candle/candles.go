@@ -0,0 +1,161 @@
+package candle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// CANDLES runs every pattern selected on one engine, each output as by its
+// own indicator, and signals the strongest bullish, bearish and neutral
+// detections of each candle.
+const candlesPluginID = "CANDLES"
+const candlesPluginName = "Candlestick Patterns"
+const candlesPluginDescription = "Candlestick Patterns detects the TA-Lib candlestick patterns at once, all of them unless selected."
+const candlesPluginHCL = `
+indicator "candles" {
+  patterns = ["CDLDOJI", "CDLENGULFING", "CDLHAMMER"]
+  body_long = 1.0
+  body_long_period = 10
+}
+`
+
+type candles struct {
+	plugins.Plugin
+
+	Patterns     []string `hcl:"patterns,optional"` // IDs of the patterns to detect
+	patterns     []*pattern
+	penetrations map[string]float64 // penetrations of the patterns using one
+	settings     Settings
+	engine       *engine
+}
+
+func candlesNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	i := &candles{
+		Plugin: plugins.Plugin{
+			PID:         candlesPluginID,
+			Title:       candlesPluginName,
+			Summary:     candlesPluginDescription,
+			Template:    candlesPluginHCL,
+			Params:      params,
+			Fields:      plugins.Bar(params, "open", "high", "low", "close"),
+			Initialized: true,
+		},
+		Patterns:     params.Strings("patterns", []string{}),
+		penetrations: map[string]float64{},
+		settings:     newSettings(params),
+	}
+
+	i.patterns = patterns
+	if len(i.Patterns) > 0 {
+		i.patterns = nil
+		for _, id := range i.Patterns {
+			p := find(id)
+			if p == nil {
+				params.AddError(fmt.Errorf("unknown candlestick pattern: %q", id))
+				continue
+			}
+			i.patterns = append(i.patterns, p)
+		}
+	}
+
+	// one penetration applies to every pattern using it when set
+	for _, p := range i.patterns {
+		if p.Penetration > 0 {
+			i.penetrations[p.ID] = params.Float("penetration", p.Penetration)
+		}
+	}
+	i.Reset()
+	return i
+}
+
+// find returns the pattern of the ID, with or without its CDL prefix.
+func find(id string) *pattern {
+	id = strings.ToUpper(id)
+	for _, p := range patterns {
+		if p.ID == id || p.ID == "CDL"+id {
+			return p
+		}
+	}
+	return nil
+}
+
+func (i *candles) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+func (i *candles) Compute(input *series.Series) (output *series.Series) {
+	return plugins.Compute(i.ID(), i, input)
+}
+
+func (i *candles) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the candle and returns the value of every pattern once all
+// of them can be detected.
+func (i *candles) Update(input *tick.Tick) (output *tick.Tick) {
+	c, ok := read(input, i.Fields)
+	if !ok {
+		return tick.New()
+	}
+	i.engine.add(c)
+
+	fields := make(map[string]float64, len(i.patterns))
+	signals := map[sig.Signal]sig.Strength{}
+	for _, p := range i.patterns {
+		result := i.engine.detect(p, i.penetrations[p.ID])
+		fields[strings.ToLower(p.ID)] = float64(result)
+		if result == 0 {
+			continue
+		}
+		signal, strength := p.signal(result)
+		if current, ok := signals[signal]; !ok || strength > current {
+			signals[signal] = strength
+		}
+	}
+	if i.engine.Count < i.Warmup() {
+		return tick.New()
+	}
+
+	output = plugins.Output(input, fields)
+	output.SetSignals(signals)
+	return output
+}
+
+func (i *candles) Reset() {
+	i.engine = newEngine(i.settings)
+}
+
+func (i *candles) Warmup() int {
+	lookback := 0
+	for _, p := range i.patterns {
+		lookback = max(lookback, p.lookback(&i.settings))
+	}
+	return lookback + 1
+}
+
+func (i *candles) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(detectorState{Engine: i.engine})
+}
+
+func (i *candles) Restore(state []byte) error {
+	var s detectorState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.engine = s.Engine
+	return nil
+}
+
+func init() {
+	indicators.Add(candlesPluginID, candlesNew, indicators.PATTERN)
+}
@@ -0,0 +1,222 @@
+package candle
+
+// The patterns of two candles, c1 is the first and c0 the last.
+
+func counterattack(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c1.color() == -c0.color() &&
+		c1.body() > e.avg(bodyLong, 1) &&
+		c0.body() > e.avg(bodyLong, 0) &&
+		c0.Close <= c1.Close+e.avg(equal, 1) &&
+		c0.Close >= c1.Close-e.avg(equal, 1) {
+		return c0.color() * 100
+	}
+	return 0
+}
+
+func darkCloudCover(e *engine, penetration float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c1.color() == 1 && c1.body() > e.avg(bodyLong, 1) &&
+		c0.color() == -1 && c0.Open > c1.High &&
+		c0.Close > c1.Open && c0.Close < c1.Close-c1.body()*penetration {
+		return -100
+	}
+	return 0
+}
+
+func dojiStar(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c1.body() > e.avg(bodyLong, 1) &&
+		c0.body() <= e.avg(bodyDoji, 0) &&
+		((c1.color() == 1 && bodyGapUp(c0, c1)) || (c1.color() == -1 && bodyGapDown(c0, c1))) {
+		return -c1.color() * 100
+	}
+	return 0
+}
+
+func engulfing(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if (c0.color() == 1 && c1.color() == -1 &&
+		((c0.Close >= c1.Open && c0.Open < c1.Close) || (c0.Close > c1.Open && c0.Open <= c1.Close))) ||
+		(c0.color() == -1 && c1.color() == 1 &&
+			((c0.Open >= c1.Close && c0.Close < c1.Open) || (c0.Open > c1.Close && c0.Close <= c1.Open))) {
+		return c0.color() * 100
+	}
+	return 0
+}
+
+func hammer(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c0.body() < e.avg(bodyShort, 0) &&
+		c0.lower() > e.avg(shadowLong, 0) &&
+		c0.upper() < e.avg(shadowVeryShort, 0) &&
+		// the body is below or near the previous low
+		c0.bottom() <= c1.Low+e.avg(near, 1) {
+		return 100
+	}
+	return 0
+}
+
+func hangingMan(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c0.body() < e.avg(bodyShort, 0) &&
+		c0.lower() > e.avg(shadowLong, 0) &&
+		c0.upper() < e.avg(shadowVeryShort, 0) &&
+		// the body is above or near the previous high
+		c0.bottom() >= c1.High-e.avg(near, 1) {
+		return -100
+	}
+	return 0
+}
+
+// inside reports whether the real body of c lies within the one of prev.
+func inside(c, prev candle) bool {
+	return c.top() < prev.top() && c.bottom() > prev.bottom()
+}
+
+func harami(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c1.body() > e.avg(bodyLong, 1) && c0.body() <= e.avg(bodyShort, 0) && inside(c0, c1) {
+		return -c1.color() * 100
+	}
+	return 0
+}
+
+func haramiCross(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c1.body() > e.avg(bodyLong, 1) && c0.body() <= e.avg(bodyDoji, 0) && inside(c0, c1) {
+		return -c1.color() * 100
+	}
+	return 0
+}
+
+func homingPigeon(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c1.color() == -1 && c0.color() == -1 &&
+		c1.body() > e.avg(bodyLong, 1) &&
+		c0.body() <= e.avg(bodyShort, 0) &&
+		c0.Open < c1.Open && c0.Close > c1.Close {
+		return 100
+	}
+	return 0
+}
+
+func inNeck(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c1.color() == -1 && c1.body() > e.avg(bodyLong, 1) &&
+		c0.color() == 1 && c0.Open < c1.Low &&
+		c0.Close <= c1.Close+e.avg(equal, 1) && c0.Close >= c1.Close {
+		return -100
+	}
+	return 0
+}
+
+func invertedHammer(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c0.body() < e.avg(bodyShort, 0) &&
+		c0.upper() > e.avg(shadowLong, 0) &&
+		c0.lower() < e.avg(shadowVeryShort, 0) &&
+		bodyGapDown(c0, c1) {
+		return 100
+	}
+	return 0
+}
+
+// kick reports whether the candles are marubozu of opposite colors separated
+// by a gap.
+func kick(e *engine) bool {
+	c1, c0 := e.at(1), e.at(0)
+	return c1.color() == -c0.color() &&
+		c1.body() > e.avg(bodyLong, 1) &&
+		c1.upper() < e.avg(shadowVeryShort, 1) &&
+		c1.lower() < e.avg(shadowVeryShort, 1) &&
+		c0.body() > e.avg(bodyLong, 0) &&
+		c0.upper() < e.avg(shadowVeryShort, 0) &&
+		c0.lower() < e.avg(shadowVeryShort, 0) &&
+		((c1.color() == -1 && gapUp(c0, c1)) || (c1.color() == 1 && gapDown(c0, c1)))
+}
+
+func kicking(e *engine, _ float64) int {
+	if kick(e) {
+		return e.at(0).color() * 100
+	}
+	return 0
+}
+
+func kickingByLength(e *engine, _ float64) int {
+	if !kick(e) {
+		return 0
+	}
+	longer := e.at(0)
+	if c1 := e.at(1); longer.body() <= c1.body() {
+		longer = c1
+	}
+	return longer.color() * 100
+}
+
+func matchingLow(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c1.color() == -1 && c0.color() == -1 &&
+		c0.Close <= c1.Close+e.avg(equal, 1) &&
+		c0.Close >= c1.Close-e.avg(equal, 1) {
+		return 100
+	}
+	return 0
+}
+
+func onNeck(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c1.color() == -1 && c1.body() > e.avg(bodyLong, 1) &&
+		c0.color() == 1 && c0.Open < c1.Low &&
+		c0.Close <= c1.Low+e.avg(equal, 1) &&
+		c0.Close >= c1.Low-e.avg(equal, 1) {
+		return -100
+	}
+	return 0
+}
+
+func piercing(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c1.color() == -1 && c1.body() > e.avg(bodyLong, 1) &&
+		c0.color() == 1 && c0.body() > e.avg(bodyLong, 0) &&
+		c0.Open < c1.Low &&
+		c0.Close < c1.Open && c0.Close > c1.Close+c1.body()*0.5 {
+		return 100
+	}
+	return 0
+}
+
+func separatingLines(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c1.color() == -c0.color() &&
+		c0.Open <= c1.Open+e.avg(equal, 1) &&
+		c0.Open >= c1.Open-e.avg(equal, 1) &&
+		c0.body() > e.avg(bodyLong, 0) &&
+		((c0.color() == 1 && c0.lower() < e.avg(shadowVeryShort, 0)) ||
+			(c0.color() == -1 && c0.upper() < e.avg(shadowVeryShort, 0))) {
+		return c0.color() * 100
+	}
+	return 0
+}
+
+func shootingStar(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c0.body() < e.avg(bodyShort, 0) &&
+		c0.upper() > e.avg(shadowLong, 0) &&
+		c0.lower() < e.avg(shadowVeryShort, 0) &&
+		bodyGapUp(c0, c1) {
+		return -100
+	}
+	return 0
+}
+
+func thrusting(e *engine, _ float64) int {
+	c1, c0 := e.at(1), e.at(0)
+	if c1.color() == -1 && c1.body() > e.avg(bodyLong, 1) &&
+		c0.color() == 1 && c0.Open < c1.Low &&
+		c0.Close > c1.Close+e.avg(equal, 1) &&
+		c0.Close <= c1.Close+c1.body()*0.5 {
+		return -100
+	}
+	return 0
+}
@@ -0,0 +1,179 @@
+package candle
+
+// The patterns of four and five candles, and those confirmed after they
+// formed; c0 is the last candle.
+
+func threeBlackCrows(e *engine, _ float64) int {
+	c3, c2, c1, c0 := e.at(3), e.at(2), e.at(1), e.at(0)
+	if c3.color() == 1 &&
+		c2.color() == -1 && c2.lower() < e.avg(shadowVeryShort, 2) &&
+		c1.color() == -1 && c1.lower() < e.avg(shadowVeryShort, 1) &&
+		c0.color() == -1 && c0.lower() < e.avg(shadowVeryShort, 0) &&
+		// each opens within the previous body
+		c1.Open < c2.Open && c1.Open > c2.Close &&
+		c0.Open < c1.Open && c0.Open > c1.Close &&
+		// the first closes under the high of the white candle
+		c3.High > c2.Close &&
+		c2.Close > c1.Close && c1.Close > c0.Close {
+		return -100
+	}
+	return 0
+}
+
+func threeLineStrike(e *engine, _ float64) int {
+	c3, c2, c1, c0 := e.at(3), e.at(2), e.at(1), e.at(0)
+	if c3.color() == c2.color() && c2.color() == c1.color() && c0.color() == -c1.color() &&
+		// the second and third open within or near the previous body
+		c2.Open >= c3.bottom()-e.avg(near, 3) && c2.Open <= c3.top()+e.avg(near, 3) &&
+		c1.Open >= c2.bottom()-e.avg(near, 2) && c1.Open <= c2.top()+e.avg(near, 2) &&
+		((c1.color() == 1 && c1.Close > c2.Close && c2.Close > c3.Close &&
+			c0.Open > c1.Close && c0.Close < c3.Open) ||
+			(c1.color() == -1 && c1.Close < c2.Close && c2.Close < c3.Close &&
+				c0.Open < c1.Close && c0.Close > c3.Open)) {
+		return c1.color() * 100
+	}
+	return 0
+}
+
+func breakaway(e *engine, _ float64) int {
+	c4, c3, c2, c1, c0 := e.at(4), e.at(3), e.at(2), e.at(1), e.at(0)
+	if c4.body() > e.avg(bodyLong, 4) &&
+		c4.color() == c3.color() && c3.color() == c1.color() && c1.color() == -c0.color() &&
+		((c4.color() == -1 && bodyGapDown(c3, c4) &&
+			c2.High < c3.High && c2.Low < c3.Low &&
+			c1.High < c2.High && c1.Low < c2.Low &&
+			c0.Close > c3.Open && c0.Close < c4.Close) ||
+			(c4.color() == 1 && bodyGapUp(c3, c4) &&
+				c2.High > c3.High && c2.Low > c3.Low &&
+				c1.High > c2.High && c1.Low > c2.Low &&
+				c0.Close < c3.Open && c0.Close > c4.Close)) {
+		return c0.color() * 100
+	}
+	return 0
+}
+
+func concealBabySwallow(e *engine, _ float64) int {
+	c3, c2, c1, c0 := e.at(3), e.at(2), e.at(1), e.at(0)
+	if c3.color() == -1 && c2.color() == -1 && c1.color() == -1 && c0.color() == -1 &&
+		// two marubozu
+		c3.lower() < e.avg(shadowVeryShort, 3) && c3.upper() < e.avg(shadowVeryShort, 3) &&
+		c2.lower() < e.avg(shadowVeryShort, 2) && c2.upper() < e.avg(shadowVeryShort, 2) &&
+		// then a gap down with an upper shadow reaching into the second body
+		bodyGapDown(c1, c2) &&
+		c1.upper() > e.avg(shadowVeryShort, 1) &&
+		c1.High > c2.Close &&
+		// and a candle engulfing it, shadows included
+		c0.High > c1.High && c0.Low < c1.Low {
+		return 100
+	}
+	return 0
+}
+
+func ladderBottom(e *engine, _ float64) int {
+	c4, c3, c2, c1, c0 := e.at(4), e.at(3), e.at(2), e.at(1), e.at(0)
+	if c4.color() == -1 && c3.color() == -1 && c2.color() == -1 &&
+		c4.Open > c3.Open && c3.Open > c2.Open &&
+		c4.Close > c3.Close && c3.Close > c2.Close &&
+		c1.color() == -1 && c1.upper() > e.avg(shadowVeryShort, 1) &&
+		c0.color() == 1 && c0.Open > c1.Open && c0.Close > c1.High {
+		return 100
+	}
+	return 0
+}
+
+func matHold(e *engine, penetration float64) int {
+	c4, c3, c2, c1, c0 := e.at(4), e.at(3), e.at(2), e.at(1), e.at(0)
+	if c4.body() > e.avg(bodyLong, 4) &&
+		c3.body() < e.avg(bodyShort, 3) &&
+		c2.body() < e.avg(bodyShort, 2) &&
+		c1.body() < e.avg(bodyShort, 1) &&
+		c4.color() == 1 && c3.color() == -1 && c0.color() == 1 &&
+		bodyGapUp(c3, c4) &&
+		// the third and fourth hold within the first body
+		c2.bottom() < c4.Close && c1.bottom() < c4.Close &&
+		c2.bottom() > c4.Close-c4.body()*penetration &&
+		c1.bottom() > c4.Close-c4.body()*penetration &&
+		// the second to fourth fall
+		c2.top() < c3.Open && c1.top() < c2.top() &&
+		c0.Open > c1.Close && c0.Close > max(c3.High, c2.High, c1.High) {
+		return 100
+	}
+	return 0
+}
+
+func riseFallThreeMethods(e *engine, _ float64) int {
+	c4, c3, c2, c1, c0 := e.at(4), e.at(3), e.at(2), e.at(1), e.at(0)
+	// prices are multiplied by the color of the first candle, so the rising
+	// conditions also match the falling pattern
+	color := float64(c4.color())
+	if c4.body() > e.avg(bodyLong, 4) &&
+		c3.body() < e.avg(bodyShort, 3) &&
+		c2.body() < e.avg(bodyShort, 2) &&
+		c1.body() < e.avg(bodyShort, 1) &&
+		c0.body() > e.avg(bodyLong, 0) &&
+		c4.color() == -c3.color() && c3.color() == c2.color() && c2.color() == c1.color() && c1.color() == -c0.color() &&
+		// the small bodies hold within the first range
+		c3.bottom() < c4.High && c3.top() > c4.Low &&
+		c2.bottom() < c4.High && c2.top() > c4.Low &&
+		c1.bottom() < c4.High && c1.top() > c4.Low &&
+		c2.Close*color < c3.Close*color && c1.Close*color < c2.Close*color &&
+		c0.Open*color > c1.Close*color && c0.Close*color > c4.Close*color {
+		return c4.color() * 100
+	}
+	return 0
+}
+
+// hikkake is a pattern waiting for its confirmation.
+type hikkake struct {
+	Result    int     // the pattern detected, 0 if none
+	Since     int     // candles since the pattern
+	High, Low float64 // range of the inside candle before the false breakout
+}
+
+// formed records the pattern at the last candles.
+func (h *hikkake) formed(c1, c0 candle) int {
+	h.Result, h.Since, h.High, h.Low = 100, 0, c1.High, c1.Low
+	if c0.High > c1.High {
+		h.Result = -100
+	}
+	return h.Result
+}
+
+// confirm returns the confirmation of the pattern by a close beyond the
+// inside candle within 3 candles.
+func (h *hikkake) confirm(c candle) int {
+	if h.Since++; h.Result == 0 || h.Since > 3 {
+		return 0
+	}
+	if (h.Result > 0 && c.Close > h.High) || (h.Result < 0 && c.Close < h.Low) {
+		result := 2 * h.Result
+		h.Result = 0
+		return result
+	}
+	return 0
+}
+
+// breakout reports whether c0 breaks out of c1 on one side only.
+func breakout(c1, c0 candle) bool {
+	return (c0.High < c1.High && c0.Low < c1.Low) || (c0.High > c1.High && c0.Low > c1.Low)
+}
+
+func hikkakePattern(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c1.High < c2.High && c1.Low > c2.Low && breakout(c1, c0) {
+		return e.Hikkake.formed(c1, c0)
+	}
+	return e.Hikkake.confirm(c0)
+}
+
+func hikkakeModPattern(e *engine, _ float64) int {
+	c3, c2, c1, c0 := e.at(3), e.at(2), e.at(1), e.at(0)
+	if c2.High < c3.High && c2.Low > c3.Low &&
+		c1.High < c2.High && c1.Low > c2.Low &&
+		// the second closes near its low before a bullish false breakout, or near its high
+		((c0.High < c1.High && c0.Low < c1.Low && c2.Close <= c2.Low+e.avg(near, 2)) ||
+			(c0.High > c1.High && c0.Low > c1.Low && c2.Close >= c2.High-e.avg(near, 2))) {
+		return e.HikkakeMod.formed(c1, c0)
+	}
+	return e.HikkakeMod.confirm(c0)
+}
@@ -0,0 +1,327 @@
+package candle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	sig "github.com/rangertaha/gotal/internal/signals"
+)
+
+// pattern is a candlestick pattern following its TA-Lib definition. Detect
+// returns 100 for a bullish pattern and -100 for a bearish one, 200 or -200
+// when a pattern is confirmed, and 0 otherwise.
+type pattern struct {
+	ID, Name, Description string
+
+	Candles     int           // candles forming the pattern
+	Span        int           // candles before the last one the pattern looks at
+	Uses        []settingType // settings of the thresholds
+	Penetration float64       // default penetration of the last candle into the first, if used
+	Neutral     bool          // the pattern signals indecision rather than a direction
+	Scan        int           // candles detected before the lookback to carry state
+
+	Detect func(e *engine, penetration float64) int
+}
+
+// lookback returns the candles needed before the first detection.
+func (p *pattern) lookback(settings *Settings) int {
+	period := 0
+	for _, t := range p.Uses {
+		period = max(period, settings[t].Period)
+	}
+	return period + p.Span
+}
+
+// signal returns the signal of a detection, stronger for patterns of more
+// candles and for confirmations.
+func (p *pattern) signal(result int) (sig.Signal, sig.Strength) {
+	strength := sig.WEAK
+	switch {
+	case p.Candles >= 3 || result >= 200 || result <= -200:
+		strength = sig.STRONG
+	case p.Candles == 2:
+		strength = sig.MEDIUM
+	}
+
+	switch {
+	case p.Neutral:
+		return sig.NEUTRAL, strength
+	case result > 0:
+		return sig.BULLISH, strength
+	default:
+		return sig.BEARISH, strength
+	}
+}
+
+// template returns the HCL of the pattern with its default settings.
+func (p *pattern) template() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nindicator %q {\n", strings.ToLower(p.ID))
+	for _, t := range p.Uses {
+		s := defaultSettings[t]
+		fmt.Fprintf(&b, "  %s = %v\n  %s_period = %d\n", s.Name, s.Factor, s.Name, s.Period)
+	}
+	if p.Penetration > 0 {
+		fmt.Fprintf(&b, "  penetration = %v\n", p.Penetration)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func init() {
+	for _, p := range patterns {
+		indicators.Add(p.ID, func(opts ...internal.PluginOptions) internal.Plugin {
+			return newDetector(p, opts...)
+		}, indicators.PATTERN)
+	}
+}
+
+// patterns are the TA-Lib candlestick patterns.
+var patterns = []*pattern{
+	{
+		ID: "CDL2CROWS", Name: "Two Crows", Candles: 3, Span: 2, Uses: []settingType{bodyLong}, Detect: twoCrows,
+		Description: "Two Crows is a long white candle followed by two black ones gapping up, the second closing within the first body.",
+	},
+	{
+		ID: "CDL3BLACKCROWS", Name: "Three Black Crows", Candles: 3, Span: 3, Uses: []settingType{shadowVeryShort}, Detect: threeBlackCrows,
+		Description: "Three Black Crows are three declining black candles closing near their lows after a white candle.",
+	},
+	{
+		ID: "CDL3INSIDE", Name: "Three Inside Up/Down", Candles: 3, Span: 2, Uses: []settingType{bodyShort, bodyLong}, Detect: threeInside,
+		Description: "Three Inside Up/Down is a harami confirmed by a third candle closing beyond the open of the first.",
+	},
+	{
+		ID: "CDL3LINESTRIKE", Name: "Three-Line Strike", Candles: 4, Span: 3, Uses: []settingType{near}, Detect: threeLineStrike,
+		Description: "Three-Line Strike is three candles of one color in a row struck back by a fourth candle beyond the first open.",
+	},
+	{
+		ID: "CDL3OUTSIDE", Name: "Three Outside Up/Down", Candles: 3, Span: 3, Detect: threeOutside,
+		Description: "Three Outside Up/Down is an engulfing pattern confirmed by a third candle closing further.",
+	},
+	{
+		ID: "CDL3STARSINSOUTH", Name: "Three Stars In The South", Candles: 3, Span: 2, Uses: []settingType{shadowVeryShort, shadowLong, bodyLong, bodyShort}, Detect: threeStarsInSouth,
+		Description: "Three Stars In The South are three shrinking black candles with rising lows, ending in a small marubozu.",
+	},
+	{
+		ID: "CDL3WHITESOLDIERS", Name: "Three Advancing White Soldiers", Candles: 3, Span: 2, Uses: []settingType{shadowVeryShort, bodyShort, far, near}, Detect: threeWhiteSoldiers,
+		Description: "Three Advancing White Soldiers are three rising white candles of similar bodies closing near their highs.",
+	},
+	{
+		ID: "CDLABANDONEDBABY", Name: "Abandoned Baby", Candles: 3, Span: 2, Uses: []settingType{bodyDoji, bodyLong, bodyShort}, Penetration: 0.3, Detect: abandonedBaby,
+		Description: "Abandoned Baby is a doji gapping away from a long candle, then a candle gapping back the other way.",
+	},
+	{
+		ID: "CDLADVANCEBLOCK", Name: "Advance Block", Candles: 3, Span: 2, Uses: []settingType{shadowLong, shadowShort, far, near, bodyLong}, Detect: advanceBlock,
+		Description: "Advance Block is three rising white candles losing strength, with shrinking bodies or growing upper shadows.",
+	},
+	{
+		ID: "CDLBELTHOLD", Name: "Belt-hold", Candles: 1, Uses: []settingType{bodyLong, shadowVeryShort}, Detect: beltHold,
+		Description: "Belt-hold is a long candle opening at its low when white, or at its high when black.",
+	},
+	{
+		ID: "CDLBREAKAWAY", Name: "Breakaway", Candles: 5, Span: 4, Uses: []settingType{bodyLong}, Detect: breakaway,
+		Description: "Breakaway is a long candle, a gap and three candles extending the move, then a candle closing back into the gap.",
+	},
+	{
+		ID: "CDLCLOSINGMARUBOZU", Name: "Closing Marubozu", Candles: 1, Uses: []settingType{bodyLong, shadowVeryShort}, Detect: closingMarubozu,
+		Description: "Closing Marubozu is a long candle without a shadow on its closing side.",
+	},
+	{
+		ID: "CDLCONCEALBABYSWALL", Name: "Concealing Baby Swallow", Candles: 4, Span: 3, Uses: []settingType{shadowVeryShort}, Detect: concealBabySwallow,
+		Description: "Concealing Baby Swallow is two black marubozu, a black candle gapping down with an upper shadow, and a black candle engulfing it.",
+	},
+	{
+		ID: "CDLCOUNTERATTACK", Name: "Counterattack", Candles: 2, Span: 1, Uses: []settingType{equal, bodyLong}, Detect: counterattack,
+		Description: "Counterattack is two long candles of opposite colors closing at the same price.",
+	},
+	{
+		ID: "CDLDARKCLOUDCOVER", Name: "Dark Cloud Cover", Candles: 2, Span: 1, Uses: []settingType{bodyLong}, Penetration: 0.5, Detect: darkCloudCover,
+		Description: "Dark Cloud Cover is a long white candle followed by a black one opening above its high and closing deep into its body.",
+	},
+	{
+		ID: "CDLDOJI", Name: "Doji", Candles: 1, Uses: []settingType{bodyDoji}, Neutral: true, Detect: doji,
+		Description: "Doji is a candle opening and closing at about the same price.",
+	},
+	{
+		ID: "CDLDOJISTAR", Name: "Doji Star", Candles: 2, Span: 1, Uses: []settingType{bodyDoji, bodyLong}, Detect: dojiStar,
+		Description: "Doji Star is a doji gapping away from a long candle.",
+	},
+	{
+		ID: "CDLDRAGONFLYDOJI", Name: "Dragonfly Doji", Candles: 1, Uses: []settingType{bodyDoji, shadowVeryShort}, Neutral: true, Detect: dragonflyDoji,
+		Description: "Dragonfly Doji is a doji at the high of the candle, with a long lower shadow.",
+	},
+	{
+		ID: "CDLENGULFING", Name: "Engulfing Pattern", Candles: 2, Span: 2, Detect: engulfing,
+		Description: "Engulfing Pattern is a candle whose real body engulfs the one of the previous candle of the opposite color.",
+	},
+	{
+		ID: "CDLEVENINGDOJISTAR", Name: "Evening Doji Star", Candles: 3, Span: 2, Uses: []settingType{bodyDoji, bodyLong, bodyShort}, Penetration: 0.3, Detect: eveningDojiStar,
+		Description: "Evening Doji Star is a long white candle, a doji gapping up and a black candle closing deep into the first.",
+	},
+	{
+		ID: "CDLEVENINGSTAR", Name: "Evening Star", Candles: 3, Span: 2, Uses: []settingType{bodyShort, bodyLong}, Penetration: 0.3, Detect: eveningStar,
+		Description: "Evening Star is a long white candle, a short candle gapping up and a black candle closing deep into the first.",
+	},
+	{
+		ID: "CDLGAPSIDESIDEWHITE", Name: "Up/Down-gap side-by-side white lines", Candles: 3, Span: 2, Uses: []settingType{near, equal}, Detect: gapSideSideWhite,
+		Description: "Up/Down-gap side-by-side white lines are two similar white candles opening together after a gap.",
+	},
+	{
+		ID: "CDLGRAVESTONEDOJI", Name: "Gravestone Doji", Candles: 1, Uses: []settingType{bodyDoji, shadowVeryShort}, Neutral: true, Detect: gravestoneDoji,
+		Description: "Gravestone Doji is a doji at the low of the candle, with a long upper shadow.",
+	},
+	{
+		ID: "CDLHAMMER", Name: "Hammer", Candles: 2, Span: 1, Uses: []settingType{bodyShort, shadowLong, shadowVeryShort, near}, Detect: hammer,
+		Description: "Hammer is a small body at the top of a long lower shadow, at or below the previous low.",
+	},
+	{
+		ID: "CDLHANGINGMAN", Name: "Hanging Man", Candles: 2, Span: 1, Uses: []settingType{bodyShort, shadowLong, shadowVeryShort, near}, Detect: hangingMan,
+		Description: "Hanging Man is a small body at the top of a long lower shadow, at or above the previous high.",
+	},
+	{
+		ID: "CDLHARAMI", Name: "Harami Pattern", Candles: 2, Span: 1, Uses: []settingType{bodyShort, bodyLong}, Detect: harami,
+		Description: "Harami Pattern is a short candle within the real body of the previous long candle.",
+	},
+	{
+		ID: "CDLHARAMICROSS", Name: "Harami Cross Pattern", Candles: 2, Span: 1, Uses: []settingType{bodyDoji, bodyLong}, Detect: haramiCross,
+		Description: "Harami Cross Pattern is a doji within the real body of the previous long candle.",
+	},
+	{
+		ID: "CDLHIGHWAVE", Name: "High-Wave Candle", Candles: 1, Uses: []settingType{bodyShort, shadowVeryLong}, Neutral: true, Detect: highWave,
+		Description: "High-Wave Candle is a short body with very long shadows on both sides.",
+	},
+	{
+		ID: "CDLHIKKAKE", Name: "Hikkake Pattern", Candles: 3, Span: 5, Scan: 3, Detect: hikkakePattern,
+		Description: "Hikkake Pattern is an inside bar followed by a false breakout, confirmed when a later close breaks the other way.",
+	},
+	{
+		ID: "CDLHIKKAKEMOD", Name: "Modified Hikkake Pattern", Candles: 4, Span: 5, Uses: []settingType{near}, Scan: 3, Detect: hikkakeModPattern,
+		Description: "Modified Hikkake Pattern is a hikkake after two inside bars, the first closing near its low or high.",
+	},
+	{
+		ID: "CDLHOMINGPIGEON", Name: "Homing Pigeon", Candles: 2, Span: 1, Uses: []settingType{bodyShort, bodyLong}, Detect: homingPigeon,
+		Description: "Homing Pigeon is a short black candle within the real body of the previous long black candle.",
+	},
+	{
+		ID: "CDLIDENTICAL3CROWS", Name: "Identical Three Crows", Candles: 3, Span: 2, Uses: []settingType{shadowVeryShort, equal}, Detect: identicalThreeCrows,
+		Description: "Identical Three Crows are three declining black candles, each opening at the previous close.",
+	},
+	{
+		ID: "CDLINNECK", Name: "In-Neck Pattern", Candles: 2, Span: 1, Uses: []settingType{equal, bodyLong}, Detect: inNeck,
+		Description: "In-Neck Pattern is a long black candle followed by a white one opening below its low and closing just above its close.",
+	},
+	{
+		ID: "CDLINVERTEDHAMMER", Name: "Inverted Hammer", Candles: 2, Span: 1, Uses: []settingType{bodyShort, shadowLong, shadowVeryShort}, Detect: invertedHammer,
+		Description: "Inverted Hammer is a small body at the bottom of a long upper shadow, gapping down.",
+	},
+	{
+		ID: "CDLKICKING", Name: "Kicking", Candles: 2, Span: 1, Uses: []settingType{shadowVeryShort, bodyLong}, Detect: kicking,
+		Description: "Kicking is two marubozu of opposite colors separated by a gap.",
+	},
+	{
+		ID: "CDLKICKINGBYLENGTH", Name: "Kicking - bull/bear determined by the longer marubozu", Candles: 2, Span: 1, Uses: []settingType{shadowVeryShort, bodyLong}, Detect: kickingByLength,
+		Description: "Kicking by length is a kicking pattern whose direction is the color of the longer marubozu.",
+	},
+	{
+		ID: "CDLLADDERBOTTOM", Name: "Ladder Bottom", Candles: 5, Span: 4, Uses: []settingType{shadowVeryShort}, Detect: ladderBottom,
+		Description: "Ladder Bottom is three declining black candles, a black one with an upper shadow and a white one gapping up.",
+	},
+	{
+		ID: "CDLLONGLEGGEDDOJI", Name: "Long Legged Doji", Candles: 1, Uses: []settingType{bodyDoji, shadowLong}, Neutral: true, Detect: longLeggedDoji,
+		Description: "Long Legged Doji is a doji with a long shadow.",
+	},
+	{
+		ID: "CDLLONGLINE", Name: "Long Line Candle", Candles: 1, Uses: []settingType{bodyLong, shadowShort}, Detect: longLine,
+		Description: "Long Line Candle is a long body with short shadows.",
+	},
+	{
+		ID: "CDLMARUBOZU", Name: "Marubozu", Candles: 1, Uses: []settingType{bodyLong, shadowVeryShort}, Detect: marubozu,
+		Description: "Marubozu is a long body without shadows.",
+	},
+	{
+		ID: "CDLMATCHINGLOW", Name: "Matching Low", Candles: 2, Span: 1, Uses: []settingType{equal}, Detect: matchingLow,
+		Description: "Matching Low is two black candles closing at the same price.",
+	},
+	{
+		ID: "CDLMATHOLD", Name: "Mat Hold", Candles: 5, Span: 4, Uses: []settingType{bodyShort, bodyLong}, Penetration: 0.5, Detect: matHold,
+		Description: "Mat Hold is a long white candle, three small candles gapping up and falling within it, and a white candle closing above them.",
+	},
+	{
+		ID: "CDLMORNINGDOJISTAR", Name: "Morning Doji Star", Candles: 3, Span: 2, Uses: []settingType{bodyDoji, bodyLong, bodyShort}, Penetration: 0.3, Detect: morningDojiStar,
+		Description: "Morning Doji Star is a long black candle, a doji gapping down and a white candle closing deep into the first.",
+	},
+	{
+		ID: "CDLMORNINGSTAR", Name: "Morning Star", Candles: 3, Span: 2, Uses: []settingType{bodyShort, bodyLong}, Penetration: 0.3, Detect: morningStar,
+		Description: "Morning Star is a long black candle, a short candle gapping down and a white candle closing deep into the first.",
+	},
+	{
+		ID: "CDLONNECK", Name: "On-Neck Pattern", Candles: 2, Span: 1, Uses: []settingType{equal, bodyLong}, Detect: onNeck,
+		Description: "On-Neck Pattern is a long black candle followed by a white one opening below its low and closing at its low.",
+	},
+	{
+		ID: "CDLPIERCING", Name: "Piercing Pattern", Candles: 2, Span: 1, Uses: []settingType{bodyLong}, Detect: piercing,
+		Description: "Piercing Pattern is a long black candle followed by a long white one opening below its low and closing above its midpoint.",
+	},
+	{
+		ID: "CDLRICKSHAWMAN", Name: "Rickshaw Man", Candles: 1, Uses: []settingType{bodyDoji, shadowLong, near}, Neutral: true, Detect: rickshawMan,
+		Description: "Rickshaw Man is a long legged doji with its body near the middle of the range.",
+	},
+	{
+		ID: "CDLRISEFALL3METHODS", Name: "Rising/Falling Three Methods", Candles: 5, Span: 4, Uses: []settingType{bodyShort, bodyLong}, Detect: riseFallThreeMethods,
+		Description: "Rising/Falling Three Methods is a long candle, three small candles countering it within its range, and a long candle resuming the move.",
+	},
+	{
+		ID: "CDLSEPARATINGLINES", Name: "Separating Lines", Candles: 2, Span: 1, Uses: []settingType{shadowVeryShort, bodyLong, equal}, Detect: separatingLines,
+		Description: "Separating Lines are two candles of opposite colors opening at the same price, the second a long belt-hold.",
+	},
+	{
+		ID: "CDLSHOOTINGSTAR", Name: "Shooting Star", Candles: 2, Span: 1, Uses: []settingType{bodyShort, shadowLong, shadowVeryShort}, Detect: shootingStar,
+		Description: "Shooting Star is a small body at the bottom of a long upper shadow, gapping up.",
+	},
+	{
+		ID: "CDLSHORTLINE", Name: "Short Line Candle", Candles: 1, Uses: []settingType{bodyShort, shadowShort}, Detect: shortLine,
+		Description: "Short Line Candle is a short body with short shadows.",
+	},
+	{
+		ID: "CDLSPINNINGTOP", Name: "Spinning Top", Candles: 1, Uses: []settingType{bodyShort}, Neutral: true, Detect: spinningTop,
+		Description: "Spinning Top is a small body with shadows longer than the body.",
+	},
+	{
+		ID: "CDLSTALLEDPATTERN", Name: "Stalled Pattern", Candles: 3, Span: 2, Uses: []settingType{bodyLong, bodyShort, shadowVeryShort, near}, Detect: stalledPattern,
+		Description: "Stalled Pattern is two long white candles followed by a small one riding on the shoulder of the second.",
+	},
+	{
+		ID: "CDLSTICKSANDWICH", Name: "Stick Sandwich", Candles: 3, Span: 2, Uses: []settingType{equal}, Detect: stickSandwich,
+		Description: "Stick Sandwich is two black candles closing at the same price around a white one.",
+	},
+	{
+		ID: "CDLTAKURI", Name: "Takuri", Candles: 1, Uses: []settingType{bodyDoji, shadowVeryShort, shadowVeryLong}, Detect: takuri,
+		Description: "Takuri is a dragonfly doji with a very long lower shadow.",
+	},
+	{
+		ID: "CDLTASUKIGAP", Name: "Tasuki Gap", Candles: 3, Span: 2, Uses: []settingType{near}, Detect: tasukiGap,
+		Description: "Tasuki Gap is a gap followed by two candles of similar size and opposite colors, the second not closing the gap.",
+	},
+	{
+		ID: "CDLTHRUSTING", Name: "Thrusting Pattern", Candles: 2, Span: 1, Uses: []settingType{equal, bodyLong}, Detect: thrusting,
+		Description: "Thrusting Pattern is a long black candle followed by a white one opening below its low and closing below its midpoint.",
+	},
+	{
+		ID: "CDLTRISTAR", Name: "Tristar Pattern", Candles: 3, Span: 2, Uses: []settingType{bodyDoji}, Detect: tristar,
+		Description: "Tristar Pattern is three doji, the second gapping away from the others.",
+	},
+	{
+		ID: "CDLUNIQUE3RIVER", Name: "Unique 3 River", Candles: 3, Span: 2, Uses: []settingType{bodyShort, bodyLong}, Detect: uniqueThreeRiver,
+		Description: "Unique 3 River is a long black candle, a black harami with a lower low, and a small white candle.",
+	},
+	{
+		ID: "CDLUPSIDEGAP2CROWS", Name: "Upside Gap Two Crows", Candles: 3, Span: 2, Uses: []settingType{bodyShort, bodyLong}, Detect: upsideGapTwoCrows,
+		Description: "Upside Gap Two Crows is a long white candle followed by two black candles gapping up, the second engulfing the first.",
+	},
+	{
+		ID: "CDLXSIDEGAP3METHODS", Name: "Upside/Downside Gap Three Methods", Candles: 3, Span: 2, Detect: xSideGapThreeMethods,
+		Description: "Upside/Downside Gap Three Methods is two candles of one color separated by a gap, and a candle of the other color closing the gap.",
+	},
+}
@@ -0,0 +1,91 @@
+package candle
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+)
+
+// rangeType is the range of a candle a setting compares with.
+type rangeType int
+
+const (
+	realBody rangeType = iota // length of the real body
+	highLow                   // high minus low
+	shadows                   // both shadows, halved when averaged
+)
+
+func (r rangeType) of(c candle) float64 {
+	switch r {
+	case realBody:
+		return c.body()
+	case highLow:
+		return c.High - c.Low
+	default:
+		return c.upper() + c.lower()
+	}
+}
+
+// settingType names the thresholds of the patterns, as the TA-Lib candle
+// settings.
+type settingType int
+
+const (
+	bodyLong settingType = iota
+	bodyVeryLong
+	bodyShort
+	bodyDoji
+	shadowLong
+	shadowVeryLong
+	shadowShort
+	shadowVeryShort
+	near
+	far
+	equal
+)
+
+// setting is a threshold of Factor times the average Range of the Period
+// candles before the candle compared, or of the candle itself when the
+// period is 0.
+type setting struct {
+	Name   string
+	Range  rangeType
+	Period int
+	Factor float64
+}
+
+// Settings are the thresholds of every pattern, configured by their name for
+// the factor and with a "_period" suffix for the period.
+type Settings [equal + 1]setting
+
+// defaultSettings are the TA-Lib defaults.
+var defaultSettings = Settings{
+	bodyLong:        {"body_long", realBody, 10, 1.0},
+	bodyVeryLong:    {"body_very_long", realBody, 10, 3.0},
+	bodyShort:       {"body_short", realBody, 10, 1.0},
+	bodyDoji:        {"body_doji", highLow, 10, 0.1},
+	shadowLong:      {"shadow_long", realBody, 0, 1.0},
+	shadowVeryLong:  {"shadow_very_long", realBody, 0, 2.0},
+	shadowShort:     {"shadow_short", shadows, 10, 1.0},
+	shadowVeryShort: {"shadow_very_short", highLow, 10, 0.1},
+	near:            {"near", highLow, 5, 0.2},
+	far:             {"far", highLow, 5, 0.6},
+	equal:           {"equal", highLow, 5, 0.05},
+}
+
+// newSettings returns the default settings overridden by the params,
+// recording an error for negative values.
+func newSettings(params internal.Options) Settings {
+	settings := defaultSettings
+	for t := range settings {
+		s := &settings[t]
+		s.Factor = params.Float(s.Name, s.Factor)
+		s.Period = params.Int(s.Name+"_period", s.Period)
+		if s.Period < 0 || s.Factor < 0 || math.IsNaN(s.Factor) {
+			params.AddError(fmt.Errorf("invalid candle setting %s: factor %v over %d candles", s.Name, s.Factor, s.Period))
+			s.Factor, s.Period = defaultSettings[t].Factor, defaultSettings[t].Period
+		}
+	}
+	return settings
+}
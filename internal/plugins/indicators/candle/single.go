@@ -0,0 +1,131 @@
+package candle
+
+// The patterns of one candle.
+
+func beltHold(e *engine, _ float64) int {
+	c := e.at(0)
+	if c.body() > e.avg(bodyLong, 0) &&
+		((c.color() == 1 && c.lower() < e.avg(shadowVeryShort, 0)) ||
+			(c.color() == -1 && c.upper() < e.avg(shadowVeryShort, 0))) {
+		return c.color() * 100
+	}
+	return 0
+}
+
+func closingMarubozu(e *engine, _ float64) int {
+	c := e.at(0)
+	if c.body() > e.avg(bodyLong, 0) &&
+		((c.color() == 1 && c.upper() < e.avg(shadowVeryShort, 0)) ||
+			(c.color() == -1 && c.lower() < e.avg(shadowVeryShort, 0))) {
+		return c.color() * 100
+	}
+	return 0
+}
+
+func doji(e *engine, _ float64) int {
+	if e.at(0).body() <= e.avg(bodyDoji, 0) {
+		return 100
+	}
+	return 0
+}
+
+func dragonflyDoji(e *engine, _ float64) int {
+	c := e.at(0)
+	if c.body() <= e.avg(bodyDoji, 0) &&
+		c.upper() < e.avg(shadowVeryShort, 0) &&
+		c.lower() > e.avg(shadowVeryShort, 0) {
+		return 100
+	}
+	return 0
+}
+
+func gravestoneDoji(e *engine, _ float64) int {
+	c := e.at(0)
+	if c.body() <= e.avg(bodyDoji, 0) &&
+		c.lower() < e.avg(shadowVeryShort, 0) &&
+		c.upper() > e.avg(shadowVeryShort, 0) {
+		return 100
+	}
+	return 0
+}
+
+func highWave(e *engine, _ float64) int {
+	c := e.at(0)
+	if c.body() < e.avg(bodyShort, 0) &&
+		c.upper() > e.avg(shadowVeryLong, 0) &&
+		c.lower() > e.avg(shadowVeryLong, 0) {
+		return c.color() * 100
+	}
+	return 0
+}
+
+func longLeggedDoji(e *engine, _ float64) int {
+	c := e.at(0)
+	if c.body() <= e.avg(bodyDoji, 0) &&
+		(c.lower() > e.avg(shadowLong, 0) || c.upper() > e.avg(shadowLong, 0)) {
+		return 100
+	}
+	return 0
+}
+
+func longLine(e *engine, _ float64) int {
+	c := e.at(0)
+	if c.body() > e.avg(bodyLong, 0) &&
+		c.upper() < e.avg(shadowShort, 0) &&
+		c.lower() < e.avg(shadowShort, 0) {
+		return c.color() * 100
+	}
+	return 0
+}
+
+func marubozu(e *engine, _ float64) int {
+	c := e.at(0)
+	if c.body() > e.avg(bodyLong, 0) &&
+		c.upper() < e.avg(shadowVeryShort, 0) &&
+		c.lower() < e.avg(shadowVeryShort, 0) {
+		return c.color() * 100
+	}
+	return 0
+}
+
+func rickshawMan(e *engine, _ float64) int {
+	c := e.at(0)
+	middle := c.Low + (c.High-c.Low)/2
+	if c.body() <= e.avg(bodyDoji, 0) &&
+		c.lower() > e.avg(shadowLong, 0) &&
+		c.upper() > e.avg(shadowLong, 0) &&
+		// the body is near the middle of the range
+		c.bottom() <= middle+e.avg(near, 0) &&
+		c.top() >= middle-e.avg(near, 0) {
+		return 100
+	}
+	return 0
+}
+
+func shortLine(e *engine, _ float64) int {
+	c := e.at(0)
+	if c.body() < e.avg(bodyShort, 0) &&
+		c.upper() < e.avg(shadowShort, 0) &&
+		c.lower() < e.avg(shadowShort, 0) {
+		return c.color() * 100
+	}
+	return 0
+}
+
+func spinningTop(e *engine, _ float64) int {
+	c := e.at(0)
+	if c.body() < e.avg(bodyShort, 0) && c.upper() > c.body() && c.lower() > c.body() {
+		return c.color() * 100
+	}
+	return 0
+}
+
+func takuri(e *engine, _ float64) int {
+	c := e.at(0)
+	if c.body() <= e.avg(bodyDoji, 0) &&
+		c.upper() < e.avg(shadowVeryShort, 0) &&
+		c.lower() > e.avg(shadowVeryLong, 0) {
+		return 100
+	}
+	return 0
+}
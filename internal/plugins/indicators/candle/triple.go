@@ -0,0 +1,273 @@
+package candle
+
+import "math"
+
+// The patterns of three candles, c2 is the first and c0 the last.
+
+func twoCrows(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.color() == 1 && c2.body() > e.avg(bodyLong, 2) &&
+		c1.color() == -1 && bodyGapUp(c1, c2) &&
+		// the last opens within the second body and closes within the first
+		c0.color() == -1 && c0.Open < c1.Open && c0.Open > c1.Close &&
+		c0.Close > c2.Open && c0.Close < c2.Close {
+		return -100
+	}
+	return 0
+}
+
+func threeInside(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.body() > e.avg(bodyLong, 2) &&
+		c1.body() <= e.avg(bodyShort, 1) &&
+		inside(c1, c2) &&
+		((c2.color() == 1 && c0.color() == -1 && c0.Close < c2.Open) ||
+			(c2.color() == -1 && c0.color() == 1 && c0.Close > c2.Open)) {
+		return -c2.color() * 100
+	}
+	return 0
+}
+
+func threeOutside(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if (c1.color() == 1 && c2.color() == -1 && c1.Close > c2.Open && c1.Open < c2.Close && c0.Close > c1.Close) ||
+		(c1.color() == -1 && c2.color() == 1 && c1.Open > c2.Close && c1.Close < c2.Open && c0.Close < c1.Close) {
+		return c1.color() * 100
+	}
+	return 0
+}
+
+func threeStarsInSouth(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.color() == -1 && c1.color() == -1 && c0.color() == -1 &&
+		// the first is long with a long lower shadow
+		c2.body() > e.avg(bodyLong, 2) && c2.lower() > e.avg(shadowLong, 2) &&
+		// the second is smaller, opens within the first range and keeps above its low
+		c1.body() < c2.body() && c1.Open > c2.Close && c1.Open <= c2.High &&
+		c1.Low < c2.Close && c1.Low >= c2.Low && c1.lower() > e.avg(shadowVeryShort, 1) &&
+		// the last is a small marubozu within the second range
+		c0.body() < e.avg(bodyShort, 0) &&
+		c0.lower() < e.avg(shadowVeryShort, 0) && c0.upper() < e.avg(shadowVeryShort, 0) &&
+		c0.Low > c1.Low && c0.High < c1.High {
+		return 100
+	}
+	return 0
+}
+
+func threeWhiteSoldiers(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.color() == 1 && c2.upper() < e.avg(shadowVeryShort, 2) &&
+		c1.color() == 1 && c1.upper() < e.avg(shadowVeryShort, 1) &&
+		c0.color() == 1 && c0.upper() < e.avg(shadowVeryShort, 0) &&
+		c0.Close > c1.Close && c1.Close > c2.Close &&
+		// each opens within or near the previous body
+		c1.Open > c2.Open && c1.Open <= c2.Close+e.avg(near, 2) &&
+		c0.Open > c1.Open && c0.Open <= c1.Close+e.avg(near, 1) &&
+		// and is not far shorter than it
+		c1.body() > c2.body()-e.avg(far, 2) &&
+		c0.body() > c1.body()-e.avg(far, 1) &&
+		c0.body() > e.avg(bodyShort, 0) {
+		return 100
+	}
+	return 0
+}
+
+func abandonedBaby(e *engine, penetration float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.body() > e.avg(bodyLong, 2) &&
+		c1.body() <= e.avg(bodyDoji, 1) &&
+		c0.body() > e.avg(bodyShort, 0) &&
+		((c2.color() == 1 && c0.color() == -1 && c0.Close < c2.Close-c2.body()*penetration &&
+			gapUp(c1, c2) && gapDown(c0, c1)) ||
+			(c2.color() == -1 && c0.color() == 1 && c0.Close > c2.Close+c2.body()*penetration &&
+				gapDown(c1, c2) && gapUp(c0, c1))) {
+		return c0.color() * 100
+	}
+	return 0
+}
+
+func advanceBlock(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.color() == 1 && c1.color() == 1 && c0.color() == 1 &&
+		c0.Close > c1.Close && c1.Close > c2.Close &&
+		c1.Open > c2.Open && c1.Open <= c2.Close+e.avg(near, 2) &&
+		c0.Open > c1.Open && c0.Open <= c1.Close+e.avg(near, 1) &&
+		c2.body() > e.avg(bodyLong, 2) &&
+		c2.upper() < e.avg(shadowShort, 2) &&
+		// the advance weakens
+		((c1.body() < c2.body()-e.avg(far, 2) && c0.body() < c1.body()+e.avg(near, 1)) ||
+			c0.body() < c1.body()-e.avg(far, 1) ||
+			(c0.body() < c1.body() && c1.body() < c2.body() &&
+				(c0.upper() > e.avg(shadowShort, 0) || c1.upper() > e.avg(shadowShort, 1))) ||
+			(c0.body() < c1.body() && c0.upper() > e.avg(shadowLong, 0))) {
+		return -100
+	}
+	return 0
+}
+
+func eveningDojiStar(e *engine, penetration float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.body() > e.avg(bodyLong, 2) && c2.color() == 1 &&
+		c1.body() <= e.avg(bodyDoji, 1) && bodyGapUp(c1, c2) &&
+		c0.body() > e.avg(bodyShort, 0) && c0.color() == -1 &&
+		c0.Close < c2.Close-c2.body()*penetration {
+		return -100
+	}
+	return 0
+}
+
+func eveningStar(e *engine, penetration float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.body() > e.avg(bodyLong, 2) && c2.color() == 1 &&
+		c1.body() <= e.avg(bodyShort, 1) && bodyGapUp(c1, c2) &&
+		c0.body() > e.avg(bodyShort, 0) && c0.color() == -1 &&
+		c0.Close < c2.Close-c2.body()*penetration {
+		return -100
+	}
+	return 0
+}
+
+func gapSideSideWhite(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	up := bodyGapUp(c1, c2) && bodyGapUp(c0, c2)
+	if (up || (bodyGapDown(c1, c2) && bodyGapDown(c0, c2))) &&
+		c1.color() == 1 && c0.color() == 1 &&
+		// of about the same size, opening together
+		c0.body() >= c1.body()-e.avg(near, 1) && c0.body() <= c1.body()+e.avg(near, 1) &&
+		c0.Open >= c1.Open-e.avg(equal, 1) && c0.Open <= c1.Open+e.avg(equal, 1) {
+		if up {
+			return 100
+		}
+		return -100
+	}
+	return 0
+}
+
+func identicalThreeCrows(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.color() == -1 && c2.lower() < e.avg(shadowVeryShort, 2) &&
+		c1.color() == -1 && c1.lower() < e.avg(shadowVeryShort, 1) &&
+		c0.color() == -1 && c0.lower() < e.avg(shadowVeryShort, 0) &&
+		c2.Close > c1.Close && c1.Close > c0.Close &&
+		// each opens at the previous close
+		c1.Open <= c2.Close+e.avg(equal, 2) && c1.Open >= c2.Close-e.avg(equal, 2) &&
+		c0.Open <= c1.Close+e.avg(equal, 1) && c0.Open >= c1.Close-e.avg(equal, 1) {
+		return -100
+	}
+	return 0
+}
+
+func morningDojiStar(e *engine, penetration float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.body() > e.avg(bodyLong, 2) && c2.color() == -1 &&
+		c1.body() <= e.avg(bodyDoji, 1) && bodyGapDown(c1, c2) &&
+		c0.body() > e.avg(bodyShort, 0) && c0.color() == 1 &&
+		c0.Close > c2.Close+c2.body()*penetration {
+		return 100
+	}
+	return 0
+}
+
+func morningStar(e *engine, penetration float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.body() > e.avg(bodyLong, 2) && c2.color() == -1 &&
+		c1.body() <= e.avg(bodyShort, 1) && bodyGapDown(c1, c2) &&
+		c0.body() > e.avg(bodyShort, 0) && c0.color() == 1 &&
+		c0.Close > c2.Close+c2.body()*penetration {
+		return 100
+	}
+	return 0
+}
+
+func stalledPattern(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.color() == 1 && c1.color() == 1 && c0.color() == 1 &&
+		c0.Close > c1.Close && c1.Close > c2.Close &&
+		c2.body() > e.avg(bodyLong, 2) &&
+		c1.body() > e.avg(bodyLong, 1) &&
+		c1.upper() < e.avg(shadowVeryShort, 1) &&
+		c1.Open > c2.Open && c1.Open <= c2.Close+e.avg(near, 2) &&
+		c0.body() < e.avg(bodyShort, 0) &&
+		// the last rides on the shoulder of the second
+		c0.Open >= c1.Close-c0.body()-e.avg(near, 1) {
+		return -100
+	}
+	return 0
+}
+
+func stickSandwich(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.color() == -1 && c1.color() == 1 && c0.color() == -1 &&
+		c1.Low > c2.Close &&
+		c0.Close <= c2.Close+e.avg(equal, 2) &&
+		c0.Close >= c2.Close-e.avg(equal, 2) {
+		return 100
+	}
+	return 0
+}
+
+func tasukiGap(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	similar := math.Abs(c1.body()-c0.body()) < e.avg(near, 1)
+	if (bodyGapUp(c1, c2) &&
+		c1.color() == 1 && c0.color() == -1 &&
+		c0.Open < c1.Close && c0.Open > c1.Open &&
+		c0.Close < c1.Open && c0.Close > c2.top() && similar) ||
+		(bodyGapDown(c1, c2) &&
+			c1.color() == -1 && c0.color() == 1 &&
+			c0.Open < c1.Open && c0.Open > c1.Close &&
+			c0.Close > c1.Open && c0.Close < c2.bottom() && similar) {
+		return c1.color() * 100
+	}
+	return 0
+}
+
+func tristar(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	// the three doji are compared with the average before the first
+	threshold := e.avg(bodyDoji, 2)
+	if c2.body() > threshold || c1.body() > threshold || c0.body() > threshold {
+		return 0
+	}
+	switch {
+	case bodyGapUp(c1, c2) && c0.top() < c1.top():
+		return -100
+	case bodyGapDown(c1, c2) && c0.bottom() > c1.bottom():
+		return 100
+	}
+	return 0
+}
+
+func uniqueThreeRiver(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.body() > e.avg(bodyLong, 2) && c2.color() == -1 &&
+		// a black harami with a lower low
+		c1.color() == -1 && c1.Close > c2.Close && c1.Open <= c2.Open && c1.Low < c2.Low &&
+		c0.body() < e.avg(bodyShort, 0) && c0.color() == 1 && c0.Open > c1.Low {
+		return 100
+	}
+	return 0
+}
+
+func upsideGapTwoCrows(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.color() == 1 && c2.body() > e.avg(bodyLong, 2) &&
+		c1.color() == -1 && c1.body() <= e.avg(bodyShort, 1) && bodyGapUp(c1, c2) &&
+		// the last engulfs the second and closes above the first
+		c0.color() == -1 && c0.Open > c1.Open && c0.Close < c1.Close && c0.Close > c2.Close {
+		return -100
+	}
+	return 0
+}
+
+func xSideGapThreeMethods(e *engine, _ float64) int {
+	c2, c1, c0 := e.at(2), e.at(1), e.at(0)
+	if c2.color() == c1.color() && c1.color() == -c0.color() &&
+		// the last opens within the second body and closes within the first
+		c0.Open < c1.top() && c0.Open > c1.bottom() &&
+		c0.Close < c2.top() && c0.Close > c2.bottom() &&
+		((c2.color() == 1 && bodyGapUp(c1, c2)) || (c2.color() == -1 && bodyGapDown(c1, c2))) {
+		return c2.color() * 100
+	}
+	return 0
+}
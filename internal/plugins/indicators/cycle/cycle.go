@@ -0,0 +1,21 @@
+package cycle
+
+import (
+	"encoding/gob"
+
+	"github.com/rangertaha/gotal/internal"
+)
+
+func init() {
+	gob.Register(&dcperiodKernel{})
+	gob.Register(&dcphaseKernel{})
+	gob.Register(&phasorKernel{})
+	gob.Register(&sineKernel{})
+	gob.Register(&trendmodeKernel{})
+	gob.Register(&trendlineKernel{})
+}
+
+// price returns the input field, the value of the ticks unless set.
+func price(params internal.Options) []string {
+	return []string{params.Field("value")}
+}
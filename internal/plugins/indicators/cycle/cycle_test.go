@@ -0,0 +1,163 @@
+package cycle
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+)
+
+// The cycle indicators are checked on waves of a known period, shaped as the
+// Sine and Square generators of the mock provider.
+
+// sine returns n values of a sine wave of the period around the mean.
+func sine(period, amplitude, mean float64, n int) []float64 {
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = mean + amplitude*math.Sin(2*math.Pi*float64(i)/period)
+	}
+	return xs
+}
+
+// square returns n values of a square wave of the period, 2 * amplitude high.
+func square(period int, amplitude float64, n int) []float64 {
+	xs := make([]float64, n)
+	for i := range xs {
+		if (2*i/period)%2 == 0 {
+			xs[i] = 2 * amplitude
+		}
+	}
+	return xs
+}
+
+// degrees returns the angle from -180 to 180 degrees.
+func degrees(angle float64) float64 {
+	return math.Remainder(angle, 360)
+}
+
+func TestDCPeriod(t *testing.T) {
+	for _, period := range []float64{10, 15, 20, 30, 40} {
+		output := dcperiodNew().Compute(indicatortest.Values(sine(period, 10, 10, 300)...))
+		if output.Len() != 300-periodLookback {
+			t.Fatalf("period %v: got %d outputs, want %d", period, output.Len(), 300-periodLookback)
+		}
+		for i, got := range indicatortest.Fields(output, "ht_dcperiod")[100:] {
+			if math.Abs(got-period) > 0.5 {
+				t.Errorf("period %v: output %d = %v", period, i+100, got)
+			}
+		}
+	}
+
+	for _, period := range []int{10, 16, 20} {
+		output := dcperiodNew().Compute(indicatortest.Values(square(period, 1, 300)...))
+		got := indicatortest.Fields(output, "ht_dcperiod")
+		if last := got[len(got)-1]; math.Abs(last-float64(period)) > 0.1*float64(period) {
+			t.Errorf("square period %d: measured %v", period, last)
+		}
+	}
+}
+
+func TestPhasor(t *testing.T) {
+	for _, period := range []float64{15, 20, 30} {
+		output := phasorNew().Compute(indicatortest.Values(sine(period, 10, 10, 300)...))
+		inphase, quadrature := indicatortest.Fields(output, "inphase"), indicatortest.Fields(output, "quadrature")
+		for i := 100; i < output.Len(); i++ {
+			// the phasor turns with the wave at about its amplitude
+			if amplitude := math.Hypot(inphase[i], quadrature[i]); amplitude < 8.5 || amplitude > 11.5 {
+				t.Errorf("period %v: output %d has amplitude %v", period, i, amplitude)
+			}
+		}
+	}
+}
+
+func TestDCPhaseAndSine(t *testing.T) {
+	const period = 20.0
+	input := indicatortest.Values(sine(period, 10, 10, 300)...)
+
+	phases := indicatortest.Fields(dcphaseNew().Compute(input), "ht_dcphase")
+	sines := sineNew().Compute(input)
+	if len(phases) != 300-phaseLookback || sines.Len() != 300-phaseLookback {
+		t.Fatalf("got %d phases and %d sines, want %d", len(phases), sines.Len(), 300-phaseLookback)
+	}
+
+	crossings := 0
+	for i := 100; i < len(phases); i++ {
+		// the phase of the input wave at the bar
+		want := 360 * float64(i+phaseLookback) / period
+		if d := degrees(phases[i] - want); math.Abs(d) > 2 {
+			t.Errorf("phase %d = %v, want %v", i, phases[i], degrees(want))
+		}
+		if phases[i] < -45 || phases[i] > 315 {
+			t.Errorf("phase %d = %v out of range", i, phases[i])
+		}
+
+		out, prev := sines.At(i), sines.At(i-1)
+		if got, want := out.GetField("sine"), math.Sin(want*deg2Rad); math.Abs(got-want) > 0.05 {
+			t.Errorf("sine %d = %v, want %v", i, got, want)
+		}
+		if got, want := out.GetField("leadsine"), math.Sin((want+45)*deg2Rad); math.Abs(got-want) > 0.05 {
+			t.Errorf("leadsine %d = %v, want %v", i, got, want)
+		}
+		if (out.GetField("sine") > out.GetField("leadsine")) != (prev.GetField("sine") > prev.GetField("leadsine")) {
+			crossings++
+		}
+	}
+
+	// the lines cross twice a cycle
+	if want := 2 * (len(phases) - 100) / int(period); crossings < want-1 || crossings > want+1 {
+		t.Errorf("got %d crossings, want %d", crossings, want)
+	}
+}
+
+func TestTrendline(t *testing.T) {
+	// the trendline removes the cycle
+	output := trendlineNew().Compute(indicatortest.Values(sine(20, 10, 10, 300)...))
+	for i, got := range indicatortest.Fields(output, "ht_trendline")[100:] {
+		if math.Abs(got-10) > 0.05 {
+			t.Errorf("output %d = %v, want 10", i+100, got)
+		}
+	}
+}
+
+func TestTrendMode(t *testing.T) {
+	ramp := make([]float64, 300)
+	for i := range ramp {
+		ramp[i] = 100 + float64(i)
+	}
+
+	for _, test := range []struct {
+		name  string
+		input []float64
+		want  float64
+	}{
+		{"cycle 15", sine(15, 1, 100, 300), 0},
+		{"cycle 30", sine(30, 1, 100, 300), 0},
+		{"trend", ramp, 1},
+	} {
+		output := trendmodeNew().Compute(indicatortest.Values(test.input...))
+		for i, got := range indicatortest.Fields(output, "ht_trendmode")[100:] {
+			if got != test.want {
+				t.Errorf("%s: output %d = %v, want %v", test.name, i+100, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestCycleSnapshot(t *testing.T) {
+	input := indicatortest.Values(indicatortest.RandomWalk(200, 5)...)
+	for id, new := range map[string]func(...internal.PluginOptions) internal.Plugin{
+		dcperiodPluginID: dcperiodNew, dcphasePluginID: dcphaseNew, phasorPluginID: phasorNew,
+		sinePluginID: sineNew, trendmodePluginID: trendmodeNew, trendlinePluginID: trendlineNew,
+	} {
+		t.Run(id, func(t *testing.T) {
+			live := indicatortest.SnapshotReplay(t, func() internal.Indicator {
+				return new().(internal.Indicator)
+			}, input, 50)
+			if got, want := new().Compute(input).Len(), input.Len()-live.Warmup()+1; got != want {
+				t.Errorf("got %d outputs, want %d", got, want)
+			}
+		})
+	}
+}
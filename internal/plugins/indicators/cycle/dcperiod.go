@@ -0,0 +1,48 @@
+package cycle
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// Period = 360 / arctan(Im / Re) of the homodyne discriminator, limited to
+// 6..50 bars and to a change of 0.67..1.5 times the previous period, then
+// smoothed twice
+const dcperiodPluginID = "HT_DCPERIOD"
+const dcperiodPluginName = "Hilbert Transform - Dominant Cycle Period"
+const dcperiodPluginDescription = "Hilbert Transform Dominant Cycle Period is the period in bars of the dominant price cycle."
+const dcperiodPluginHCL = `
+indicator "ht_dcperiod" {}
+`
+
+type dcperiodKernel struct {
+	Cycle  *DominantCycle
+	Output string
+}
+
+func (k *dcperiodKernel) Add(values []float64) map[string]float64 {
+	if !k.Cycle.Add(values[0]) || k.Cycle.Count <= periodLookback {
+		return nil
+	}
+	return map[string]float64{k.Output: k.Cycle.SmoothPeriod}
+}
+
+func (k *dcperiodKernel) Warmup() int {
+	return periodLookback + 1
+}
+
+func dcperiodNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	output := params.Output(strings.ToLower(dcperiodPluginID))
+	return plugins.NewKernelIndicator(dcperiodPluginID, dcperiodPluginName, dcperiodPluginDescription, dcperiodPluginHCL, params, price(params), func() plugins.Kernel {
+		return &dcperiodKernel{Cycle: NewDominantCycle(periodStart), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(dcperiodPluginID, dcperiodNew, indicators.CYCLE)
+}
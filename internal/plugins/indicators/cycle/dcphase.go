@@ -0,0 +1,51 @@
+package cycle
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// Phase = arctan(Σ sin(2πk/n) * P[k] / Σ cos(2πk/n) * P[k]) + 90 + 360/n
+// over the smoothed prices P of the last n = DCPeriod bars
+const dcphasePluginID = "HT_DCPHASE"
+const dcphasePluginName = "Hilbert Transform - Dominant Cycle Phase"
+const dcphasePluginDescription = "Hilbert Transform Dominant Cycle Phase is the phase in degrees of the dominant price cycle, from -45 to 315."
+const dcphasePluginHCL = `
+indicator "ht_dcphase" {}
+`
+
+type dcphaseKernel struct {
+	Cycle  *DominantCycle
+	Phase  phase
+	Output string
+}
+
+func (k *dcphaseKernel) Add(values []float64) map[string]float64 {
+	if !k.Cycle.Add(values[0]) {
+		return nil
+	}
+	if phase := k.Phase.add(k.Cycle); k.Cycle.Count > phaseLookback {
+		return map[string]float64{k.Output: phase}
+	}
+	return nil
+}
+
+func (k *dcphaseKernel) Warmup() int {
+	return phaseLookback + 1
+}
+
+func dcphaseNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	output := params.Output(strings.ToLower(dcphasePluginID))
+	return plugins.NewKernelIndicator(dcphasePluginID, dcphasePluginName, dcphasePluginDescription, dcphasePluginHCL, params, price(params), func() plugins.Kernel {
+		return &dcphaseKernel{Cycle: NewDominantCycle(phaseStart), Phase: newPhase(), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(dcphasePluginID, dcphaseNew, indicators.CYCLE)
+}
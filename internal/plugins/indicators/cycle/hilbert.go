@@ -0,0 +1,226 @@
+package cycle
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal/plugins"
+)
+
+const (
+	rad2Deg = 180.0 / math.Pi
+	deg2Rad = math.Pi / 180.0
+)
+
+// TA-Lib lookbacks and transform starts of the indicators measuring the
+// period, and of those measuring the phase which takes longer to settle.
+const (
+	periodLookback, periodStart = 32, 12
+	phaseLookback, phaseStart   = 63, 37
+)
+
+// DominantCycle measures the dominant cycle of the prices with the Hilbert
+// transform discriminator of John Ehlers, the machinery shared by MAMA and
+// the HT indicators. It follows TA-Lib: the prices are smoothed by a WMA of 4
+// and the transform starts once Start prices were added.
+type DominantCycle struct {
+	Start int // prices only smoothed before the transform starts
+	Count int // prices added
+
+	// price smoother
+	Prices           plugins.Ring
+	WMASub, WMASum   float64
+	TrailingWMAValue float64
+
+	HilbertIdx                     int
+	Detrender, Q1, JI, JQ          hilbert
+	I1ForOddPrev2, I1ForOddPrev3   float64
+	I1ForEvenPrev2, I1ForEvenPrev3 float64
+	PrevI2, PrevQ2, Re, Im         float64
+
+	// measures of the last price
+	Smoothed     float64 // the smoothed price
+	InPhase      float64 // in-phase component, the detrended price delayed by 3 bars
+	Quadrature   float64 // quadrature component
+	Period       float64 // dominant cycle period
+	SmoothPeriod float64 // dominant cycle period smoothed once more
+}
+
+// NewDominantCycle returns a DominantCycle starting the transform after start
+// prices, TA-Lib uses 12 and 37 of them.
+func NewDominantCycle(start int) *DominantCycle {
+	return &DominantCycle{Start: max(start, 4), Prices: plugins.NewRing(4)}
+}
+
+// Add adds a price and reports whether the measures were updated, which they
+// are once Start prices were added.
+func (c *DominantCycle) Add(price float64) bool {
+	c.Prices.Push(price)
+	today := c.Count
+	c.Count++
+
+	if today < 3 {
+		c.WMASub += price
+		c.WMASum += price * float64(today+1)
+		return false
+	}
+	c.Smoothed = c.smooth(price)
+	if today < c.Start {
+		return false
+	}
+
+	adjustedPrevPeriod := 0.075*c.Period + 0.54
+
+	var q2, i2 float64
+	if today%2 == 0 {
+		detrender := c.Detrender.transform(c.Smoothed, c.HilbertIdx, true, adjustedPrevPeriod)
+		c.Quadrature = c.Q1.transform(detrender, c.HilbertIdx, true, adjustedPrevPeriod)
+		c.InPhase = c.I1ForEvenPrev3
+		ji := c.JI.transform(c.InPhase, c.HilbertIdx, true, adjustedPrevPeriod)
+		jq := c.JQ.transform(c.Quadrature, c.HilbertIdx, true, adjustedPrevPeriod)
+		if c.HilbertIdx++; c.HilbertIdx == 3 {
+			c.HilbertIdx = 0
+		}
+		c.I1ForOddPrev3 = c.I1ForOddPrev2
+		c.I1ForOddPrev2 = detrender
+		q2 = 0.2*(c.Quadrature+ji) + 0.8*c.PrevQ2
+		i2 = 0.2*(c.InPhase-jq) + 0.8*c.PrevI2
+	} else {
+		detrender := c.Detrender.transform(c.Smoothed, c.HilbertIdx, false, adjustedPrevPeriod)
+		c.Quadrature = c.Q1.transform(detrender, c.HilbertIdx, false, adjustedPrevPeriod)
+		c.InPhase = c.I1ForOddPrev3
+		ji := c.JI.transform(c.InPhase, c.HilbertIdx, false, adjustedPrevPeriod)
+		jq := c.JQ.transform(c.Quadrature, c.HilbertIdx, false, adjustedPrevPeriod)
+		c.I1ForEvenPrev3 = c.I1ForEvenPrev2
+		c.I1ForEvenPrev2 = detrender
+		q2 = 0.2*(c.Quadrature+ji) + 0.8*c.PrevQ2
+		i2 = 0.2*(c.InPhase-jq) + 0.8*c.PrevI2
+	}
+
+	// homodyne discriminator
+	c.Re = 0.2*(i2*c.PrevI2+q2*c.PrevQ2) + 0.8*c.Re
+	c.Im = 0.2*(i2*c.PrevQ2-q2*c.PrevI2) + 0.8*c.Im
+	c.PrevQ2, c.PrevI2 = q2, i2
+
+	prev := c.Period
+	if c.Im != 0 && c.Re != 0 {
+		c.Period = 360 / (math.Atan(c.Im/c.Re) * rad2Deg)
+	}
+	c.Period = math.Min(c.Period, 1.5*prev)
+	c.Period = math.Max(c.Period, 0.67*prev)
+	c.Period = math.Min(math.Max(c.Period, 6), 50)
+	c.Period = 0.2*c.Period + 0.8*prev
+
+	c.SmoothPeriod = 0.33*c.Period + 0.67*c.SmoothPeriod
+	return true
+}
+
+// smooth adds the price to the WMA of the last 4 prices.
+func (c *DominantCycle) smooth(price float64) float64 {
+	c.WMASub += price
+	c.WMASub -= c.TrailingWMAValue
+	c.WMASum += price * 4
+	c.TrailingWMAValue = c.Prices.At(3)
+	smoothed := c.WMASum * 0.1
+	c.WMASum -= c.WMASub
+	return smoothed
+}
+
+// hilbert is the state of one Hilbert transform, kept separately for the odd
+// and even input positions as in the TA-Lib implementation.
+type hilbert struct {
+	Odd, Even                   [3]float64
+	PrevOdd, PrevEven           float64
+	PrevInputOdd, PrevInputEven float64
+}
+
+func (h *hilbert) transform(input float64, index int, even bool, adjustedPrevPeriod float64) float64 {
+	const a, b = 0.0962, 0.5769
+
+	values, prev, prevInput := &h.Odd, &h.PrevOdd, &h.PrevInputOdd
+	if even {
+		values, prev, prevInput = &h.Even, &h.PrevEven, &h.PrevInputEven
+	}
+
+	t := a * input
+	out := -values[index]
+	values[index] = t
+	out += t
+	out -= *prev
+	*prev = b * *prevInput
+	out += *prev
+	*prevInput = input
+	return out * adjustedPrevPeriod
+}
+
+// phase measures the dominant cycle phase with a discrete Fourier transform
+// of the smoothed prices over one dominant cycle period.
+type phase struct {
+	Smoothed plugins.Ring // the last 50 smoothed prices
+	Phase    float64      // in degrees, from -45 to 315
+}
+
+func newPhase() phase {
+	return phase{Smoothed: plugins.NewRing(50)}
+}
+
+// add adds the last measures of the cycle and returns the phase.
+func (p *phase) add(c *DominantCycle) float64 {
+	p.Smoothed.Push(c.Smoothed)
+
+	var re, im float64
+	n := int(c.SmoothPeriod + 0.5)
+	for k := range n {
+		angle := float64(k) * 2 * math.Pi / float64(n)
+		re += math.Sin(angle) * p.Smoothed.At(k)
+		im += math.Cos(angle) * p.Smoothed.At(k)
+	}
+
+	// the phase is kept and moved by a quarter turn when undefined
+	switch {
+	case math.Abs(im) > 0:
+		p.Phase = math.Atan(re/im) * rad2Deg
+	case re < 0:
+		p.Phase -= 90
+	case re > 0:
+		p.Phase += 90
+	}
+	p.Phase += 90
+
+	// compensate the one bar lag of the WMA smoother
+	p.Phase += 360 / c.SmoothPeriod
+	if im < 0 {
+		p.Phase += 180
+	}
+	if p.Phase > 315 {
+		p.Phase -= 360
+	}
+	return p.Phase
+}
+
+// trendline is the instantaneous trendline, the average of the prices over
+// one dominant cycle period smoothed by a WMA of 4.
+type trendline struct {
+	Prices plugins.Ring // the last 50 prices
+	Trends [3]float64   // the last averages, the newest first
+}
+
+func newTrendline() trendline {
+	return trendline{Prices: plugins.NewRing(50)}
+}
+
+// add returns the trendline at the last measures of the cycle, every price
+// must have been pushed to Prices.
+func (t *trendline) add(c *DominantCycle) float64 {
+	trend := 0.0
+	n := int(c.SmoothPeriod + 0.5)
+	for k := range n {
+		trend += t.Prices.At(k)
+	}
+	if n > 0 {
+		trend /= float64(n)
+	}
+
+	line := (4*trend + 3*t.Trends[0] + 2*t.Trends[1] + t.Trends[2]) / 10
+	t.Trends = [3]float64{trend, t.Trends[0], t.Trends[1]}
+	return line
+}
@@ -0,0 +1,46 @@
+package cycle
+
+import (
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// InPhase = Detrender[3], Quadrature = Hilbert(Detrender)
+// where Detrender = Hilbert(WMA(Price, 4))
+const phasorPluginID = "HT_PHASOR"
+const phasorPluginName = "Hilbert Transform - Phasor Components"
+const phasorPluginDescription = "Hilbert Transform Phasor Components are the in-phase and quadrature components of the detrended prices."
+const phasorPluginHCL = `
+indicator "ht_phasor" {}
+`
+
+type phasorKernel struct {
+	Cycle *DominantCycle
+}
+
+func (k *phasorKernel) Add(values []float64) map[string]float64 {
+	if !k.Cycle.Add(values[0]) || k.Cycle.Count <= periodLookback {
+		return nil
+	}
+	return map[string]float64{
+		"inphase":    k.Cycle.InPhase,
+		"quadrature": k.Cycle.Quadrature,
+	}
+}
+
+func (k *phasorKernel) Warmup() int {
+	return periodLookback + 1
+}
+
+func phasorNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	return plugins.NewKernelIndicator(phasorPluginID, phasorPluginName, phasorPluginDescription, phasorPluginHCL, params, price(params), func() plugins.Kernel {
+		return &phasorKernel{Cycle: NewDominantCycle(periodStart)}
+	})
+}
+
+func init() {
+	indicators.Add(phasorPluginID, phasorNew, indicators.CYCLE)
+}
@@ -0,0 +1,51 @@
+package cycle
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// Sine = sin(DCPhase), LeadSine = sin(DCPhase + 45)
+const sinePluginID = "HT_SINE"
+const sinePluginName = "Hilbert Transform - SineWave"
+const sinePluginDescription = "Hilbert Transform SineWave is the sine of the dominant cycle phase with a 45 degree lead, the lines cross at the cycle turns."
+const sinePluginHCL = `
+indicator "ht_sine" {}
+`
+
+type sineKernel struct {
+	Cycle *DominantCycle
+	Phase phase
+}
+
+func (k *sineKernel) Add(values []float64) map[string]float64 {
+	if !k.Cycle.Add(values[0]) {
+		return nil
+	}
+	if phase := k.Phase.add(k.Cycle); k.Cycle.Count > phaseLookback {
+		return map[string]float64{
+			"sine":     math.Sin(phase * deg2Rad),
+			"leadsine": math.Sin((phase + 45) * deg2Rad),
+		}
+	}
+	return nil
+}
+
+func (k *sineKernel) Warmup() int {
+	return phaseLookback + 1
+}
+
+func sineNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	return plugins.NewKernelIndicator(sinePluginID, sinePluginName, sinePluginDescription, sinePluginHCL, params, price(params), func() plugins.Kernel {
+		return &sineKernel{Cycle: NewDominantCycle(phaseStart), Phase: newPhase()}
+	})
+}
+
+func init() {
+	indicators.Add(sinePluginID, sineNew, indicators.CYCLE)
+}
@@ -0,0 +1,113 @@
+package cycle
+
+import (
+	"testing"
+
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+)
+
+// talibOutputs are the TA-Lib outputs of the indicators with the default parameters.
+var talibOutputs = []indicatortest.TALibCase{
+	{Name: dcperiodPluginID, New: dcperiodNew, Field: "ht_dcperiod", Want: []float64{
+		15.64669602, 17.21176483, 18.93320878, 20.68262150, 22.31306487,
+		23.72915916, 24.81972563, 25.66252661, 26.29989884, 26.68843107,
+		26.90803200, 27.05145676, 27.17218677, 27.25421345, 27.23205555,
+		27.12527849, 27.10217624, 27.51425906, 28.62828602, 29.50743928,
+		29.69391429, 29.35499825, 28.84545558, 28.70444704, 29.14248790,
+		29.77092184, 30.23144521, 31.56848862, 31.71762197, 32.04398709,
+		31.55030182, 30.55419787, 29.26538552, 27.83752462, 26.43054814,
+		25.16166542, 24.13081995, 23.50315985, 22.81571018, 21.88859176,
+		21.09215919, 21.10574285, 21.81224352, 21.77928684, 22.47371424,
+		22.73594471, 22.64216980, 22.40146820, 22.06437838, 21.86069626,
+		22.00260479, 22.22150693, 22.21639562, 21.99546465, 21.68374528,
+		21.40879221, 21.25864329, 21.26939612, 21.36890452, 21.43293275,
+		21.48492211, 21.58434458, 21.54998997, 21.34189755, 21.39008924,
+		22.13147945, 22.11340300, 21.62046120,
+	}},
+	{Name: dcphasePluginID, New: dcphaseNew, Field: "ht_dcphase", Want: []float64{
+		256.49949726, 263.39162997, 270.43992004, 283.49529948, 289.15459932,
+		300.55600880, 302.71314402, 310.51058000, -43.82263120, -39.88699697,
+		-39.50103012, -38.73403055, -34.06195386, -31.33169062, -26.34287911,
+		-20.58219375, -12.73448527, -4.53033337, 1.25360591, 9.76323868,
+		17.64767753, 28.21637566, 39.17090117, 50.69162929, 60.79956869,
+		79.91538292, 93.59262226, 110.54665654, 153.53268782, 182.35314869,
+		194.57328019, 188.26137904, 168.08516072, 151.64373983, 144.76605353,
+		143.92542848, 144.46764418,
+	}},
+	{Name: phasorPluginID, New: phasorNew, Field: "inphase", Want: []float64{
+		0.23179409, -0.44441393, -0.69072329, -1.33234379, -0.98045230,
+		-1.24925902, -1.39389009, -1.15829846, -1.05678431, -0.58881071,
+		-0.21978091, -0.02971710, 0.02004001, -0.29987091, -0.35232941,
+		-0.30114854, 0.47061872, 0.75802986, 0.82979882, 0.61476014,
+		-0.02415536, 0.15234570, 0.17420078, 0.48364808, 0.79994719,
+		0.88949615, -0.13431514, -1.07448261, -0.71453279, -1.04239248,
+		-0.43443815, 0.52110894, 0.16576525, 0.39054252, 0.39818796,
+		-0.56668320, -1.28693609, -0.65570516, -0.46745221, 0.07852881,
+		0.02624196, -0.96764144, -1.62238769, -1.50621074, -1.66245531,
+		-1.72675432, -1.16384968, -0.98630801, -0.13138344, 0.53633493,
+		0.61138201, 0.46443567, 0.22138206, -0.25221934, -0.23965672,
+		0.11263299, 0.31660467, 0.19014161, 0.15549238, 0.42970490,
+		0.34153925, 0.36347320, 0.31847312, -0.44865553, -0.54103279,
+		0.10397162, 0.65844768, 1.84750851,
+	}},
+	{Name: phasorPluginID, New: phasorNew, Field: "quadrature", Want: []float64{
+		-2.14857372, -1.45441768, -1.54465487, -0.71893455, -0.04890593,
+		-0.67384879, 0.31438730, 0.68721034, 1.15611946, 1.61501549,
+		1.05363891, 0.53417180, -0.33207223, -0.38529964, 0.19485647,
+		1.42532053, 1.78820794, 0.61346398, -0.10405614, -1.48883216,
+		-0.84633909, 0.31185894, 0.58523462, 0.92007603, 0.31017947,
+		-1.74376859, -3.67119242, -1.30486808, -0.05266088, 0.56022724,
+		3.12145308, 1.28333970, -0.07921885, 0.13219873, -1.60395318,
+		-2.38435928, -0.18177870, 0.95822593, 0.84977350, 0.54208041,
+		-1.39844448, -2.13278654, -1.02928342, -0.31421363, -0.27978646,
+		1.00496472, 1.42513364, 1.81107042, 2.37823360, 1.20413334,
+		0.06260976, -0.52067388, -1.01737982, -0.64913237, 0.39691477,
+		0.66651255, 0.23208152, -0.07819990, 0.34688402, 0.23198765,
+		-0.21499142, -0.17341779, -1.08666217, -1.00100282, 0.97344073,
+		1.90309836, 2.86046466, 2.61558470,
+	}},
+	{Name: sinePluginID, New: sineNew, Field: "sine", Want: []float64{
+		-0.97236787, -0.99335596, -0.99997052, -0.97238907, -0.94463666,
+		-0.86113261, -0.84138683, -0.76028603, -0.69242821, -0.64127551,
+		-0.63609209, -0.62570608, -0.56008901, -0.51999164, -0.44374198,
+		-0.35155073, -0.22043332, -0.07898687, 0.02187780, 0.16957722,
+		0.30316296, 0.47280263, 0.63163565, 0.77374767, 0.87291840,
+		0.98455023, 0.99803481, 0.93638670, 0.44568717, -0.04105865,
+		-0.25161804, -0.14368917, 0.20645761, 0.47495254, 0.57691636,
+		0.58883770, 0.58116261,
+	}},
+	{Name: sinePluginID, New: sineNew, Field: "leadsine", Want: []float64{
+		-0.85264475, -0.78378419, -0.70165679, -0.52256851, -0.43594437,
+		-0.24943348, -0.21280624, -0.07827501, 0.02054752, 0.08912034,
+		0.09582786, 0.10914393, 0.18974745, 0.23630074, 0.31990403,
+		0.41338743, 0.53384351, 0.64904539, 0.72240748, 0.81677489,
+		0.88819802, 0.95740207, 0.99482926, 0.99507007, 0.96222004,
+		0.81999824, 0.66140845, 0.41395211, -0.31784563, -0.73554335,
+		-0.86227759, -0.80137263, -0.54588498, -0.28641987, -0.16962564,
+		-0.15514884, -0.16449061,
+	}},
+	{Name: trendlinePluginID, New: trendlineNew, Field: "ht_trendline", Want: []float64{
+		102.00757690, 101.98427872, 101.95730654, 101.94981584, 101.93650305,
+		101.91840741, 101.87694098, 101.83063547, 101.76491872, 101.67824138,
+		101.58255632, 101.48314583, 101.39090775, 101.31324892, 101.22800605,
+		101.14788609, 101.05108065, 100.89416285, 100.72225215, 100.54833532,
+		100.42102034, 100.27803714, 100.12084051, 99.95494627, 99.82526962,
+		99.70072964, 99.59229167, 99.48912500, 99.40030040, 99.33810079,
+		99.33661265, 99.39240909, 99.49613636, 99.62713636, 99.77518182,
+		99.92017194, 100.05192577,
+	}},
+	{Name: trendmodePluginID, New: trendmodeNew, Field: "ht_trendmode", Want: []float64{
+		0.00000000, 0.00000000, 0.00000000, 0.00000000, 0.00000000,
+		0.00000000, 0.00000000, 1.00000000, 1.00000000, 1.00000000,
+		1.00000000, 1.00000000, 1.00000000, 1.00000000, 1.00000000,
+		1.00000000, 1.00000000, 1.00000000, 1.00000000, 1.00000000,
+		1.00000000, 0.00000000, 0.00000000, 0.00000000, 1.00000000,
+		0.00000000, 0.00000000, 0.00000000, 0.00000000, 0.00000000,
+		0.00000000, 0.00000000, 1.00000000, 1.00000000, 1.00000000,
+		1.00000000, 1.00000000,
+	}},
+}
+
+func TestTALib(t *testing.T) {
+	indicatortest.TALibCases(t, talibOutputs)
+}
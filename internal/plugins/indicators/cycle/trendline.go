@@ -0,0 +1,52 @@
+package cycle
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// Trend = SMA(Price, DCPeriod)
+// Trendline = (4 * Trend + 3 * Trend[1] + 2 * Trend[2] + Trend[3]) / 10
+const trendlinePluginID = "HT_TRENDLINE"
+const trendlinePluginName = "Hilbert Transform - Instantaneous Trendline"
+const trendlinePluginDescription = "Hilbert Transform Instantaneous Trendline is the average of the prices over the dominant cycle period, which removes the cycle."
+const trendlinePluginHCL = `
+indicator "ht_trendline" {}
+`
+
+type trendlineKernel struct {
+	Cycle     *DominantCycle
+	Trendline trendline
+	Output    string
+}
+
+func (k *trendlineKernel) Add(values []float64) map[string]float64 {
+	k.Trendline.Prices.Push(values[0])
+	if !k.Cycle.Add(values[0]) {
+		return nil
+	}
+	if line := k.Trendline.add(k.Cycle); k.Cycle.Count > phaseLookback {
+		return map[string]float64{k.Output: line}
+	}
+	return nil
+}
+
+func (k *trendlineKernel) Warmup() int {
+	return phaseLookback + 1
+}
+
+func trendlineNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	output := params.Output(strings.ToLower(trendlinePluginID))
+	return plugins.NewKernelIndicator(trendlinePluginID, trendlinePluginName, trendlinePluginDescription, trendlinePluginHCL, params, price(params), func() plugins.Kernel {
+		return &trendlineKernel{Cycle: NewDominantCycle(phaseStart), Trendline: newTrendline(), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(trendlinePluginID, trendlineNew, indicators.TREND)
+}
@@ -0,0 +1,81 @@
+package cycle
+
+import (
+	"math"
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// TrendMode = 0 in a cycle, from a crossing of the SineWave lines for half a
+// dominant cycle period, and while the phase advances by 0.67..1.5 times
+// 360 / DCPeriod per bar; 1 otherwise and whenever the smoothed price is 1.5%
+// or more away from the trendline
+const trendmodePluginID = "HT_TRENDMODE"
+const trendmodePluginName = "Hilbert Transform - Trend vs Cycle Mode"
+const trendmodePluginDescription = "Hilbert Transform Trend vs Cycle Mode is 1 when the prices trend and 0 when they follow the dominant cycle."
+const trendmodePluginHCL = `
+indicator "ht_trendmode" {}
+`
+
+type trendmodeKernel struct {
+	Cycle          *DominantCycle
+	Phase          phase
+	Trendline      trendline
+	Sine, LeadSine float64
+	Days           int // bars since the SineWave lines crossed
+	Output         string
+}
+
+func (k *trendmodeKernel) Add(values []float64) map[string]float64 {
+	k.Trendline.Prices.Push(values[0])
+	if !k.Cycle.Add(values[0]) {
+		return nil
+	}
+
+	prevPhase, prevSine, prevLeadSine := k.Phase.Phase, k.Sine, k.LeadSine
+	phase := k.Phase.add(k.Cycle)
+	k.Sine, k.LeadSine = math.Sin(phase*deg2Rad), math.Sin((phase+45)*deg2Rad)
+	line := k.Trendline.add(k.Cycle)
+
+	trend := 1.0
+	if (k.Sine > k.LeadSine && prevSine <= prevLeadSine) || (k.Sine < k.LeadSine && prevSine >= prevLeadSine) {
+		k.Days, trend = 0, 0
+	}
+	k.Days++
+
+	period := k.Cycle.SmoothPeriod
+	if float64(k.Days) < 0.5*period {
+		trend = 0
+	}
+	if change := phase - prevPhase; period != 0 && change > 0.67*360/period && change < 1.5*360/period {
+		trend = 0
+	}
+	if line != 0 && math.Abs((k.Cycle.Smoothed-line)/line) >= 0.015 {
+		trend = 1
+	}
+
+	if k.Cycle.Count <= phaseLookback {
+		return nil
+	}
+	return map[string]float64{k.Output: trend}
+}
+
+func (k *trendmodeKernel) Warmup() int {
+	return phaseLookback + 1
+}
+
+func trendmodeNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	output := params.Output(strings.ToLower(trendmodePluginID))
+	return plugins.NewKernelIndicator(trendmodePluginID, trendmodePluginName, trendmodePluginDescription, trendmodePluginHCL, params, price(params), func() plugins.Kernel {
+		return &trendmodeKernel{Cycle: NewDominantCycle(phaseStart), Phase: newPhase(), Trendline: newTrendline(), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(trendmodePluginID, trendmodeNew, indicators.CYCLE)
+}
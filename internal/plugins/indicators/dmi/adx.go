@@ -0,0 +1,27 @@
+package dmi
+
+import (
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// ADX = Wilder average of DX, with the DX and DI lines as outputs
+const adxPluginID = "ADX"
+const adxPluginName = "Average Directional Movement Index"
+const adxPluginDescription = "Average Directional Movement Index measures the strength of a trend regardless of its direction."
+const adxPluginHCL = `
+indicator "adx" {
+  period = 14
+  strong = 25
+  weak = 20
+}
+`
+
+func adxNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newDMI(adxPluginID, adxPluginName, adxPluginDescription, adxPluginHCL, opt.New(opts...))
+}
+
+func init() {
+	indicators.Add(adxPluginID, adxNew, indicators.TREND)
+}
@@ -0,0 +1,27 @@
+package dmi
+
+import (
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// ADXR = (ADX + ADX[n-1]) / 2, with the ADX and DI lines as outputs
+const adxrPluginID = "ADXR"
+const adxrPluginName = "Average Directional Movement Index Rating"
+const adxrPluginDescription = "Average Directional Movement Index Rating is the average of the ADX and the ADX a period earlier."
+const adxrPluginHCL = `
+indicator "adxr" {
+  period = 14
+  strong = 25
+  weak = 20
+}
+`
+
+func adxrNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newDMI(adxrPluginID, adxrPluginName, adxrPluginDescription, adxrPluginHCL, opt.New(opts...))
+}
+
+func init() {
+	indicators.Add(adxrPluginID, adxrNew, indicators.TREND)
+}
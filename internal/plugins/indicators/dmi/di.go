@@ -0,0 +1,39 @@
+package dmi
+
+import (
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// PLUS_DI = 100 * +DM / TR, MINUS_DI = 100 * -DM / TR, both Wilder smoothed
+const plusdiPluginID = "PLUS_DI"
+const plusdiPluginName = "Plus Directional Indicator"
+const plusdiPluginDescription = "Plus Directional Indicator is the smoothed upward movement in percent of the smoothed true range."
+const plusdiPluginHCL = `
+indicator "plus_di" {
+  period = 14
+}
+`
+
+const minusdiPluginID = "MINUS_DI"
+const minusdiPluginName = "Minus Directional Indicator"
+const minusdiPluginDescription = "Minus Directional Indicator is the smoothed downward movement in percent of the smoothed true range."
+const minusdiPluginHCL = `
+indicator "minus_di" {
+  period = 14
+}
+`
+
+func plusdiNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newDMI(plusdiPluginID, plusdiPluginName, plusdiPluginDescription, plusdiPluginHCL, opt.New(opts...))
+}
+
+func minusdiNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newDMI(minusdiPluginID, minusdiPluginName, minusdiPluginDescription, minusdiPluginHCL, opt.New(opts...))
+}
+
+func init() {
+	indicators.Add(plusdiPluginID, plusdiNew, indicators.TREND)
+	indicators.Add(minusdiPluginID, minusdiNew, indicators.TREND)
+}
@@ -0,0 +1,39 @@
+package dmi
+
+import (
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// PLUS_DM = Wilder smoothed +DM, MINUS_DM = Wilder smoothed -DM
+const plusdmPluginID = "PLUS_DM"
+const plusdmPluginName = "Plus Directional Movement"
+const plusdmPluginDescription = "Plus Directional Movement is the smoothed upward extension of the high beyond the previous high."
+const plusdmPluginHCL = `
+indicator "plus_dm" {
+  period = 14
+}
+`
+
+const minusdmPluginID = "MINUS_DM"
+const minusdmPluginName = "Minus Directional Movement"
+const minusdmPluginDescription = "Minus Directional Movement is the smoothed downward extension of the low beyond the previous low."
+const minusdmPluginHCL = `
+indicator "minus_dm" {
+  period = 14
+}
+`
+
+func plusdmNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newDMI(plusdmPluginID, plusdmPluginName, plusdmPluginDescription, plusdmPluginHCL, opt.New(opts...))
+}
+
+func minusdmNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newDMI(minusdmPluginID, minusdmPluginName, minusdmPluginDescription, minusdmPluginHCL, opt.New(opts...))
+}
+
+func init() {
+	indicators.Add(plusdmPluginID, plusdmNew, indicators.TREND)
+	indicators.Add(minusdmPluginID, minusdmNew, indicators.TREND)
+}
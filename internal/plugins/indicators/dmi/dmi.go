@@ -0,0 +1,217 @@
+package dmi
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// +DM = High - PrevHigh when it is positive and larger than PrevLow - Low, else 0
+// -DM = PrevLow - Low when it is positive and larger than High - PrevHigh, else 0
+// TR = max(High - Low, |High - PrevClose|, |Low - PrevClose|)
+//
+// The directional movements and the true range are summed over the first
+// n-1 bars and Wilder smoothed after, X = X - X/n + Value, as in TA-Lib.
+// +DI = 100 * +DM / TR, -DI = 100 * -DM / TR
+// DX = 100 * |+DI - -DI| / (+DI + -DI)
+// ADX = Wilder average of DX, seeded with the mean of the first n DX
+// ADXR = (ADX + ADX[n-1]) / 2
+
+// dmi is the plugin of every directional movement indicator. They share the
+// computation and differ in the warm-up and the output fields of the ID.
+// The ADX and ADXR ticks carry the trend direction of the DI lines as a
+// BULLISH, BEARISH or NEUTRAL signal, STRONG when the ADX is at or above the
+// strong threshold, WEAK below the weak one and MEDIUM between them.
+type dmi struct {
+	plugins.Plugin
+
+	Period int     `hcl:"period,optional"` // smoothing period
+	Strong float64 `hcl:"strong,optional"` // ADX at or above which the trend is strong
+	Weak   float64 `hcl:"weak,optional"`   // ADX below which the trend is weak
+
+	state dmiState
+}
+
+// dmiState is the state of the smoothing, also the snapshot of a dmi.
+type dmiState struct {
+	Count                        int
+	PrevHigh, PrevLow, PrevClose float64
+	PlusDM, MinusDM, TR          float64
+	DX, SumDX, ADX               float64
+	ADXs                         []float64 // last Period ADX values, a ring
+	Pos                          int
+}
+
+func newDMI(id, name, description, hcl string, params internal.Options) *dmi {
+	i := &dmi{
+		Plugin: plugins.Plugin{
+			PID:         id,
+			Title:       name,
+			Summary:     description,
+			Template:    hcl,
+			Params:      params,
+			Fields:      plugins.Bar(params, "high", "low", "close"),
+			Initialized: true,
+		},
+		Period: max(params.Period(14), 1),
+		Strong: params.Float("strong", 25.0),
+		Weak:   params.Float("weak", 20.0),
+	}
+	i.Reset()
+	return i
+}
+
+func (i *dmi) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+func (i *dmi) Compute(input *series.Series) (output *series.Series) {
+	return plugins.Compute(i.ID(), i, input)
+}
+
+func (i *dmi) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the bar and returns the fields of the indicator once warmed up.
+func (i *dmi) Update(input *tick.Tick) (output *tick.Tick) {
+	high, low, last := input.GetField(i.Fields[0]), input.GetField(i.Fields[1]), input.GetField(i.Fields[2])
+	if math.IsNaN(high) || math.IsNaN(low) || math.IsNaN(last) {
+		return tick.New()
+	}
+
+	s, n := &i.state, float64(i.Period)
+	s.Count++
+	prevHigh, prevLow, prevClose := s.PrevHigh, s.PrevLow, s.PrevClose
+	s.PrevHigh, s.PrevLow, s.PrevClose = high, low, last
+	if s.Count == 1 {
+		return tick.New()
+	}
+
+	plusDM, minusDM := 0.0, 0.0
+	switch up, down := high-prevHigh, prevLow-low; {
+	case up > 0 && up > down:
+		plusDM = up
+	case down > 0 && down > up:
+		minusDM = down
+	}
+	tr := max(high-low, math.Abs(high-prevClose), math.Abs(low-prevClose))
+
+	// bars after the first
+	bars := s.Count - 1
+	if bars < i.Period {
+		s.PlusDM += plusDM
+		s.MinusDM += minusDM
+		s.TR += tr
+	} else {
+		s.PlusDM += plusDM - s.PlusDM/n
+		s.MinusDM += minusDM - s.MinusDM/n
+		s.TR += tr - s.TR/n
+	}
+
+	plusDI, minusDI := 0.0, 0.0
+	if bars >= i.Period && !plugins.IsZero(s.TR) {
+		plusDI, minusDI = 100*(s.PlusDM/s.TR), 100*(s.MinusDM/s.TR)
+
+		// DX keeps its previous value when undefined, ADX ignores it
+		if sum := plusDI + minusDI; !plugins.IsZero(sum) {
+			s.DX = 100 * (math.Abs(plusDI-minusDI) / sum)
+			if bars < 2*i.Period {
+				s.SumDX += s.DX
+			} else {
+				s.ADX = (s.ADX*(n-1) + s.DX) / n
+			}
+		}
+	}
+	if bars == 2*i.Period-1 {
+		s.ADX = s.SumDX / n
+	}
+	if bars >= 2*i.Period-1 {
+		s.ADXs[s.Pos] = s.ADX
+		s.Pos = (s.Pos + 1) % len(s.ADXs)
+	}
+
+	if s.Count < i.Warmup() {
+		return tick.New()
+	}
+
+	var fields map[string]float64
+	switch i.ID() {
+	case plusdmPluginID:
+		fields = map[string]float64{"plus_dm": s.PlusDM}
+	case minusdmPluginID:
+		fields = map[string]float64{"minus_dm": s.MinusDM}
+	case plusdiPluginID:
+		fields = map[string]float64{"plus_di": plusDI}
+	case minusdiPluginID:
+		fields = map[string]float64{"minus_di": minusDI}
+	case dxPluginID:
+		fields = map[string]float64{"dx": s.DX, "plus_di": plusDI, "minus_di": minusDI}
+	case adxPluginID:
+		fields = map[string]float64{"adx": s.ADX, "dx": s.DX, "plus_di": plusDI, "minus_di": minusDI}
+	case adxrPluginID:
+		// the oldest ADX in the ring is Period-1 bars old
+		adxr := (s.ADX + s.ADXs[s.Pos]) / 2
+		fields = map[string]float64{"adxr": adxr, "adx": s.ADX, "plus_di": plusDI, "minus_di": minusDI}
+	}
+	output = plugins.Output(input, fields)
+
+	if _, ok := fields["adx"]; ok {
+		i.signal(output, plusDI, minusDI)
+	}
+	return output
+}
+
+// signal sets the trend direction of the DI lines with the strength of the ADX.
+func (i *dmi) signal(output *tick.Tick, plusDI, minusDI float64) {
+	strength := sig.MEDIUM
+	switch adx := i.state.ADX; {
+	case adx >= i.Strong:
+		strength = sig.STRONG
+	case adx < i.Weak:
+		strength = sig.WEAK
+	}
+
+	switch {
+	case plusDI > minusDI:
+		output.SetSignal(sig.BULLISH, strength)
+	case plusDI < minusDI:
+		output.SetSignal(sig.BEARISH, strength)
+	default:
+		output.SetSignal(sig.NEUTRAL, strength)
+	}
+}
+
+func (i *dmi) Reset() {
+	i.state = dmiState{ADXs: make([]float64, i.Period)}
+}
+
+// Warmup returns the bars before the first output of the ID.
+func (i *dmi) Warmup() int {
+	switch i.ID() {
+	case plusdmPluginID, minusdmPluginID:
+		return max(i.Period, 2)
+	case adxPluginID:
+		return 2 * i.Period
+	case adxrPluginID:
+		return 3*i.Period - 1
+	}
+	return i.Period + 1
+}
+
+func (i *dmi) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(i.state)
+}
+
+func (i *dmi) Restore(state []byte) error {
+	var s dmiState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.state = s
+	return nil
+}
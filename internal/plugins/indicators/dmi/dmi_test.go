@@ -0,0 +1,182 @@
+package dmi
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+	sig "github.com/rangertaha/gotal/internal/signals"
+)
+
+// reference is the directional movement system computed over the whole
+// input as TA-Lib does, indexed by bar, NaN before the first output.
+type reference struct {
+	plusDM, minusDM, plusDI, minusDI, dx, adx, adxr []float64
+}
+
+func nans(n int) []float64 {
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = math.NaN()
+	}
+	return xs
+}
+
+func compute(b indicatortest.Bars, n int) reference {
+	size := len(b.Close)
+	ref := reference{nans(size), nans(size), nans(size), nans(size), nans(size), nans(size), nans(size)}
+
+	pdm, mdm, tr := make([]float64, size), make([]float64, size), make([]float64, size)
+	for i := 1; i < size; i++ {
+		up, down := b.High[i]-b.High[i-1], b.Low[i-1]-b.Low[i]
+		if up > 0 && up > down {
+			pdm[i] = up
+		}
+		if down > 0 && down > up {
+			mdm[i] = down
+		}
+		tr[i] = math.Max(b.High[i]-b.Low[i], math.Max(math.Abs(b.High[i]-b.Close[i-1]), math.Abs(b.Low[i]-b.Close[i-1])))
+	}
+
+	// sums of the first n-1 values, smoothed after
+	smooth := func(xs []float64) []float64 {
+		out := nans(size)
+		sum := 0.0
+		for i := 1; i < size; i++ {
+			if i < n {
+				sum += xs[i]
+			} else {
+				sum = sum - sum/float64(n) + xs[i]
+			}
+			if i >= n-1 && i >= 1 {
+				out[i] = sum
+			}
+		}
+		return out
+	}
+	sp, sm, st := smooth(pdm), smooth(mdm), smooth(tr)
+	copy(ref.plusDM, sp)
+	copy(ref.minusDM, sm)
+
+	prevDX, sumDX, adx := 0.0, 0.0, 0.0
+	for i := n; i < size; i++ {
+		ref.plusDI[i], ref.minusDI[i] = 0, 0
+		defined := false
+		if math.Abs(st[i]) >= 1e-8 {
+			ref.plusDI[i], ref.minusDI[i] = 100*sp[i]/st[i], 100*sm[i]/st[i]
+			if sum := ref.plusDI[i] + ref.minusDI[i]; math.Abs(sum) >= 1e-8 {
+				prevDX, defined = 100*math.Abs(ref.plusDI[i]-ref.minusDI[i])/sum, true
+			}
+		}
+		ref.dx[i] = prevDX
+
+		switch {
+		case i < 2*n:
+			if defined {
+				sumDX += prevDX
+			}
+			if i == 2*n-1 {
+				adx = sumDX / float64(n)
+				ref.adx[i] = adx
+			}
+		default:
+			if defined {
+				adx = (adx*float64(n-1) + prevDX) / float64(n)
+			}
+			ref.adx[i] = adx
+		}
+		if i >= 3*n-2 {
+			ref.adxr[i] = (ref.adx[i] + ref.adx[i-n+1]) / 2
+		}
+	}
+	return ref
+}
+
+func TestDMI(t *testing.T) {
+	b := indicatortest.RandomBars(200, 5)
+	input := b.Series(time.Hour)
+
+	for _, n := range []int{1, 2, 5, 14} {
+		ref := compute(b, n)
+		for _, test := range []struct {
+			new  func(...internal.PluginOptions) internal.Plugin
+			main []float64 // the first output is where it starts
+			want map[string][]float64
+		}{
+			{plusdmNew, ref.plusDM, map[string][]float64{"plus_dm": ref.plusDM}},
+			{minusdmNew, ref.minusDM, map[string][]float64{"minus_dm": ref.minusDM}},
+			{plusdiNew, ref.plusDI, map[string][]float64{"plus_di": ref.plusDI}},
+			{minusdiNew, ref.minusDI, map[string][]float64{"minus_di": ref.minusDI}},
+			{dxNew, ref.dx, map[string][]float64{"dx": ref.dx, "plus_di": ref.plusDI, "minus_di": ref.minusDI}},
+			{adxNew, ref.adx, map[string][]float64{"adx": ref.adx, "dx": ref.dx, "plus_di": ref.plusDI, "minus_di": ref.minusDI}},
+			{adxrNew, ref.adxr, map[string][]float64{"adxr": ref.adxr, "adx": ref.adx, "plus_di": ref.plusDI}},
+		} {
+			i := test.new(opt.WithPeriod(n)).(internal.Indicator)
+			output := i.Compute(input)
+			name := fmt.Sprintf("%s(%d)", i.ID(), n)
+
+			first := 0
+			for math.IsNaN(test.main[first]) {
+				first++
+			}
+			if i.Warmup() != first+1 || output.Len() != len(b.Close)-first {
+				t.Fatalf("%s: Warmup() = %d with %d outputs, want %d and %d", name, i.Warmup(), output.Len(), first+1, len(b.Close)-first)
+			}
+			for field, want := range test.want {
+				for j, w := range want[first:] {
+					if got := output.At(j).GetField(field); math.Abs(got-w) > 1e-9*math.Max(1, math.Abs(w)) {
+						t.Errorf("%s %s[%d] = %v, want %v", name, field, j, got, w)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestADXSignals(t *testing.T) {
+	b := indicatortest.RandomBars(300, 5)
+	output := adxNew(opt.WithPeriod(10), opt.With("strong", 40.0), opt.With("weak", 25.0)).Compute(b.Series(time.Hour))
+
+	seen := map[sig.Strength]bool{}
+	for i := range output.Len() {
+		out := output.At(i)
+		adx, plus, minus := out.GetField("adx"), out.GetField("plus_di"), out.GetField("minus_di")
+
+		direction := sig.NEUTRAL
+		if plus > minus {
+			direction = sig.BULLISH
+		} else if plus < minus {
+			direction = sig.BEARISH
+		}
+		strength := sig.MEDIUM
+		if adx >= 40 {
+			strength = sig.STRONG
+		} else if adx < 25 {
+			strength = sig.WEAK
+		}
+
+		if len(out.SignalNames()) != 1 || !out.HasSignal(direction) || out.GetSignal(direction) != strength {
+			t.Errorf("output %d: adx %v, +DI %v, -DI %v with signals %v", i, adx, plus, minus, out.SignalNames())
+		}
+		seen[strength] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("got strengths %v, want all three", seen)
+	}
+
+	// the DI lines carry no trend strength
+	if out := plusdiNew().Compute(b.Series(time.Hour)).At(0); len(out.SignalNames()) != 0 {
+		t.Errorf("PLUS_DI output has signals %v", out.SignalNames())
+	}
+}
+
+func TestDMISnapshot(t *testing.T) {
+	input := indicatortest.RandomBars(80, 5).Series(time.Hour)
+	indicatortest.SnapshotReplay(t, func() internal.Indicator {
+		return adxrNew(opt.WithPeriod(6)).(internal.Indicator)
+	}, input, 20)
+}
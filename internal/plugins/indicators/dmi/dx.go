@@ -0,0 +1,25 @@
+package dmi
+
+import (
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// DX = 100 * |+DI - -DI| / (+DI + -DI), with the DI lines as outputs
+const dxPluginID = "DX"
+const dxPluginName = "Directional Movement Index"
+const dxPluginDescription = "Directional Movement Index is the spread of the directional indicators relative to their sum."
+const dxPluginHCL = `
+indicator "dx" {
+  period = 14
+}
+`
+
+func dxNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newDMI(dxPluginID, dxPluginName, dxPluginDescription, dxPluginHCL, opt.New(opts...))
+}
+
+func init() {
+	indicators.Add(dxPluginID, dxNew, indicators.TREND)
+}
@@ -0,0 +1,146 @@
+package dmi
+
+import (
+	"testing"
+
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+)
+
+// talibOutputs are the TA-Lib outputs of the indicators with the default parameters.
+var talibOutputs = []indicatortest.TALibCase{
+	{Name: plusdmPluginID, New: plusdmNew, Field: "plus_dm", Want: []float64{
+		5.22000000, 4.84714286, 4.50091837, 4.17942420, 3.88089390,
+		3.60368719, 4.34628096, 4.03583232, 3.74755859, 4.44987583,
+		4.98202756, 5.32616844, 4.94572784, 5.24246157, 4.86800003,
+		4.52028574, 4.19740819, 3.89759332, 3.61919379, 3.36067995,
+		3.12063138, 2.89772914, 2.69074849, 2.49855217, 2.32008416,
+		2.15436386, 2.00048073, 1.85758925, 1.72490430, 1.60169685,
+		2.90728993, 2.69962637, 2.50679591, 2.32773906, 2.16147198,
+		2.50708113, 2.32800390, 2.16171791, 3.67730949, 3.41464453,
+		3.17074135, 2.94425982, 2.73395555, 2.53867301, 2.73733922,
+		2.54181499, 2.36025678, 3.49166701, 3.24226222, 3.01067206,
+		2.79562406, 2.59593663, 2.66051258, 3.74047597, 3.47329911,
+		3.22520632, 2.99483444, 2.78091769, 2.58228072, 2.39783209,
+		2.22655837, 2.06751849, 2.52983860, 2.56913584, 2.38562614,
+		2.21522427, 2.05699396, 1.91006582, 1.96363255, 1.82337308,
+		2.24313215, 2.08290842, 2.73412925, 2.53883430, 2.35748900,
+		2.18909693, 2.03273286, 1.88753766, 1.75271354, 1.62751971,
+		2.51126830, 3.44189200, 3.25604257, 3.02346810, 4.57750609,
+		4.27054137, 4.76550270,
+	}},
+	{Name: minusdmPluginID, New: minusdmNew, Field: "minus_dm", Want: []float64{
+		2.15000000, 1.99642857, 1.86382653, 1.73069606, 2.05707492,
+		1.91014099, 1.77370235, 1.64700933, 2.43936580, 2.26512539,
+		2.10333072, 1.95309281, 1.81358618, 1.68404431, 2.50375543,
+		2.57491576, 2.98099320, 2.76806512, 2.57034618, 3.79675002,
+		3.52555359, 3.87372834, 3.59703346, 3.34010250, 3.10152375,
+		3.26998634, 3.03641588, 3.26952903, 3.03599125, 3.64913473,
+		3.38848225, 3.41644780, 3.17241582, 2.94581469, 2.73539935,
+		2.54001368, 2.35858413, 2.19011384, 2.03367714, 1.88841448,
+		2.24352773, 2.08327575, 1.93447034, 3.23629389, 3.00513004,
+		2.79047789, 2.59115805, 2.40607533, 2.23421280, 3.23462618,
+		3.00358145, 3.03903992, 2.82196564, 2.62039666, 2.49322547,
+		3.38513794, 3.48334237, 3.73453220, 4.63777990, 4.30650991,
+		4.01890206, 3.73183763, 3.46527780, 3.21775795, 2.98791810,
+		3.52449538, 3.27274571, 3.66897816, 3.40690829, 3.34355770,
+		3.10473215, 2.96296557, 2.75132517, 2.55480194, 2.37231609,
+		2.41286494, 2.24051744, 3.77048048, 3.50116045, 3.25107756,
+		3.01885773, 2.80322504, 2.60299468, 2.41706649, 2.24441888,
+		2.08410325, 1.93523873,
+	}},
+	{Name: plusdiPluginID, New: plusdiNew, Field: "plus_di", Want: []float64{
+		22.14751958, 20.69154775, 19.37726284, 17.56213083, 16.74603329,
+		19.77152382, 19.24358803, 18.16177102, 20.65823705, 22.54123421,
+		23.70015532, 22.61636854, 24.15222074, 21.63988693, 20.13609114,
+		17.86496152, 17.86496152, 17.43465917, 15.86284647, 14.45902752,
+		13.08763503, 11.87471907, 10.79710886, 10.38161270, 9.68178300,
+		9.02649618, 8.24521706, 7.99675269, 7.53408520, 13.28086569,
+		11.73894181, 10.50427499, 9.53603677, 9.23455167, 10.72113595,
+		10.24913351, 10.10539841, 16.50221386, 14.77431103, 13.01020906,
+		11.52786199, 11.09286717, 9.95338122, 10.35544979, 9.71059981,
+		9.61173796, 14.33832629, 13.60434051, 12.28332768, 11.12044381,
+		10.65925755, 11.05127303, 15.21466623, 13.96659083, 12.94624343,
+		12.11363185, 11.01924029, 9.69532405, 8.85729244, 8.17141485,
+		7.86073642, 9.53463735, 9.93558779, 9.73291104, 9.13112696,
+		8.56107933, 7.97820743, 8.33780059, 7.79260767, 9.53412103,
+		8.96766164, 11.63567123, 10.69009618, 10.43459018, 10.07907132,
+		9.72233882, 8.64081496, 8.04952527, 7.85526899, 11.82390266,
+		16.13499285, 15.41822339, 14.45272828, 21.09893160, 20.17664462,
+		21.95686998,
+	}},
+	{Name: minusdiPluginID, New: minusdiNew, Field: "minus_di", Want: []float64{
+		9.12206266, 8.56835261, 8.02410833, 9.30883960, 8.87626561,
+		8.06869105, 7.85324226, 11.82188407, 10.51568606, 9.51654117,
+		8.69078840, 8.29336647, 7.75845648, 11.13002961, 11.47023471,
+		12.68766975, 12.68766975, 12.38206965, 17.92115393, 16.33518035,
+		17.49574933, 15.87430486, 14.43373916, 13.87829757, 14.69542760,
+		13.70080501, 14.51234530, 14.07502501, 17.16485361, 15.47901264,
+		14.85593801, 13.29343484, 12.06810404, 11.68656677, 10.86196682,
+		10.38376423, 10.23814106, 9.12628516, 8.17069617, 9.20565940,
+		8.15679214, 7.84900200, 12.68854504, 11.36851180, 10.66057687,
+		10.55204348, 9.88040757, 9.37462478, 13.19704451, 11.94765749,
+		12.47869799, 11.72191891, 10.65865974, 10.02558631, 13.58822210,
+		14.08956921, 14.79788769, 17.41281602, 15.90771003, 14.74927240,
+		14.18850284, 13.06018776, 12.44399620, 12.19015025, 14.52792622,
+		13.62096151, 15.32505761, 14.46610871, 14.28946912, 13.19623192,
+		12.75662066, 11.70885213, 10.75733002, 10.50021705, 11.10934721,
+		10.71614975, 17.26059560, 16.07945561, 15.69141592, 14.21380579,
+		13.14103290, 12.32586877, 11.55401811, 10.34511795, 9.84657609,
+		8.91653784,
+	}},
+	{Name: dxPluginID, New: dxNew, Field: "dx", Want: []float64{
+		41.65535957, 41.43279706, 41.43279706, 30.71452610, 30.71452610,
+		42.03571283, 42.03571283, 21.14447665, 32.53536924, 40.62881123,
+		46.33815874, 46.33815874, 51.37391522, 32.07166338, 27.41810761,
+		16.94548573, 16.94548573, 16.94548573, 6.09255103, 6.09255103,
+		14.41342871, 14.41342871, 14.41342871, 14.41342871, 20.56693311,
+		20.56693311, 27.53866229, 27.53866229, 38.99264046, 7.64310242,
+		11.72028685, 11.72028685, 11.72028685, 11.72028685, 0.65250518,
+		0.65250518, 0.65250518, 28.78018214, 28.78018214, 17.12536996,
+		17.12536996, 17.12536996, 12.08008450, 4.66333917, 4.66333917,
+		4.66333917, 18.40690249, 18.40690249, 3.58596344, 3.58596344,
+		7.86344516, 2.94489187, 17.60889379, 16.42620636, 2.41941437,
+		7.54082431, 14.63620354, 28.46927879, 28.46927879, 28.69834348,
+		28.69834348, 15.60335342, 11.20846755, 11.20846755, 22.81071530,
+		22.81071530, 31.52712792, 26.87393654, 29.42142401, 16.11110437,
+		17.44112404, 0.31348208, 0.31348208, 0.31348208, 4.86244824,
+		4.86244824, 33.27919391, 33.27919391, 33.27919391, 9.17862315,
+		10.22666117, 11.14599315, 11.14599315, 34.19983686, 34.40692999,
+		42.23807174,
+	}},
+	{Name: adxPluginID, New: adxNew, Field: "adx", Want: []float64{
+		38.60371320, 37.80474137, 36.31479454, 34.93127248, 33.64657343,
+		31.67842897, 29.85086626, 28.74819215, 27.72428047, 26.77350535,
+		25.89064273, 25.51037776, 25.15727457, 25.32737369, 25.48532288,
+		26.45013128, 25.10677207, 24.15059456, 23.26271543, 22.43825625,
+		21.67268701, 20.17124545, 18.77704971, 17.48243939, 18.28942101,
+		19.03876110, 18.90209030, 18.77518170, 18.65733801, 18.18753419,
+		17.22152026, 16.32450732, 15.49156674, 15.69980501, 15.89316911,
+		15.01408299, 14.19778874, 13.74533563, 12.97387536, 13.30494810,
+		13.52789512, 12.73443221, 12.36346022, 12.52579903, 13.66461901,
+		14.72209471, 15.72039819, 16.64739428, 16.57281994, 16.18965191,
+		15.83385303, 16.33220033, 16.79495140, 17.84724972, 18.49201307,
+		19.27268528, 19.04685807, 18.93216278, 17.60225702, 16.36734452,
+		15.22064006, 14.48076922, 13.79374629, 15.18556398, 16.47796612,
+		17.67805382, 17.07095163, 16.58207374, 16.19378227, 15.83322590,
+		17.14512668, 18.37811263, 20.08239543,
+	}},
+	{Name: adxrPluginID, New: adxrNew, Field: "adxr", Want: []float64{
+		31.96554344, 31.64503212, 31.38246291, 30.01902228, 28.89858399,
+		27.47057220, 26.14456125, 25.21043958, 23.94776296, 22.77527753,
+		21.68654106, 21.89989939, 22.09801783, 22.11473200, 22.13025229,
+		22.55373464, 21.64715313, 20.68605741, 19.79361138, 18.96491149,
+		18.68624601, 18.03220728, 16.89556635, 15.84011406, 16.01737832,
+		16.00631823, 16.10351920, 16.15153841, 15.69588511, 15.27549720,
+		14.87365964, 14.99456317, 15.10683072, 15.71010160, 16.27028170,
+		15.79345146, 15.19372032, 14.78959433, 14.65303784, 15.04994975,
+		15.68757242, 15.61322264, 15.81807275, 15.78632855, 16.29839090,
+		16.16217586, 16.04387136, 15.93401717, 15.52679458, 14.99169910,
+		15.50970850, 16.40508322, 17.23650261, 17.45910067, 17.53704340,
+		17.73323377, 17.44004198, 18.03864473, 17.99018483, 18.22486997,
+	}},
+}
+
+func TestTALib(t *testing.T) {
+	indicatortest.TALibCases(t, talibOutputs)
+}
@@ -0,0 +1,263 @@
+package ichimoku
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// Tenkan = (Highest High + Lowest Low) / 2 over the tenkan period
+// Kijun = (Highest High + Lowest Low) / 2 over the kijun period
+// Senkou A = (Tenkan + Kijun) / 2, shifted forward by the displacement
+// Senkou B = (Highest High + Lowest Low) / 2 over the senkou period, shifted forward by the displacement
+// Chikou = Close, shifted back by the displacement
+const ichimokuPluginID = "ICHIMOKU"
+const ichimokuPluginName = "Ichimoku Kinko Hyo"
+const ichimokuPluginDescription = "Ichimoku Kinko Hyo shows the trend, support and resistance with the conversion and base lines and a cloud projected forward."
+const ichimokuPluginHCL = `
+indicator "ichimoku" {
+  tenkan = 9
+  kijun = 26
+  senkou = 52
+  displacement = 26
+}
+`
+
+// ichimoku outputs at the time of each bar the tenkan and kijun lines and
+// the senkou spans of the cloud at that time, computed displacement bars
+// earlier. The chikou span is the close plotted displacement bars back, so
+// Compute sets the close of each bar as the chikou of the bar displacement
+// earlier, the bars of the last displacement staying without one. Compute
+// adds the cloud projected over the next displacement intervals of the input
+// after its last bar, and Projection returns it while streaming.
+//
+// A tenkan crossing over the kijun is a CROSSOVER, and under it a
+// CROSSUNDER, STRONG on the side of the cloud agreeing with the cross, WEAK
+// on the other side and MEDIUM in the cloud. A close breaking out above the
+// cloud is BULLISH, and below it BEARISH, STRONG when both the projected
+// cloud and the chikou span, against the close displacement bars earlier,
+// agree with the breakout, MEDIUM when one of them does and WEAK otherwise.
+type ichimoku struct {
+	plugins.Plugin
+
+	Tenkan       int `hcl:"tenkan,optional"`       // period of the conversion line
+	Kijun        int `hcl:"kijun,optional"`        // period of the base line
+	Senkou       int `hcl:"senkou,optional"`       // period of the second leading span
+	Displacement int `hcl:"displacement,optional"` // bars the spans are shifted by
+
+	state ichimokuState
+}
+
+// ichimokuState is the snapshot of an ichimoku.
+type ichimokuState struct {
+	Count        int
+	Highs, Lows  plugins.Ring // the last bars of the longest period
+	Closes       plugins.Ring // the closes of the last displacement bars and the current one
+	LeadA, LeadB plugins.Ring // the spans computed over the last displacement bars and the current one
+
+	// the last output, for the crossings
+	Tenkan, Kijun, Close, Top, Bottom float64
+}
+
+func ichimokuNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	i := &ichimoku{
+		Plugin: plugins.Plugin{
+			PID:         ichimokuPluginID,
+			Title:       ichimokuPluginName,
+			Summary:     ichimokuPluginDescription,
+			Template:    ichimokuPluginHCL,
+			Params:      params,
+			Fields:      plugins.Bar(params, "high", "low", "close"),
+			Initialized: true,
+		},
+		Tenkan:       max(params.Int("tenkan", 9), 1),
+		Kijun:        max(params.Int("kijun", 26), 1),
+		Senkou:       max(params.Int("senkou", 52), 1),
+		Displacement: max(params.Int("displacement", 26), 0),
+	}
+	i.Reset()
+	return i
+}
+
+func (i *ichimoku) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+// Compute returns the outputs of the bars with their chikou span, followed
+// by the projected cloud.
+func (i *ichimoku) Compute(input *series.Series) (output *series.Series) {
+	output = plugins.Project(i.ID(), i, input)
+
+	rows := make(map[int64]*tick.Tick, output.Len())
+	for _, out := range output.Ticks() {
+		rows[out.UnixNano()] = out
+	}
+	var bars []int64
+	for _, in := range input.Ticks() {
+		if math.IsNaN(in.GetField(i.Fields[0])) || math.IsNaN(in.GetField(i.Fields[1])) || math.IsNaN(in.GetField(i.Fields[2])) {
+			continue
+		}
+		bars = append(bars, in.UnixNano())
+		if len(bars) <= i.Displacement {
+			continue
+		}
+		if out, ok := rows[bars[len(bars)-1-i.Displacement]]; ok {
+			out.SetField("chikou", in.GetField(i.Fields[2]))
+		}
+	}
+	return output
+}
+
+func (i *ichimoku) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the bar and returns the lines and the cloud at its time, once
+// the spans of displacement bars earlier are known. The chikou span of the
+// bar is left out, its close being known displacement bars later.
+func (i *ichimoku) Update(input *tick.Tick) (output *tick.Tick) {
+	high, low, last := input.GetField(i.Fields[0]), input.GetField(i.Fields[1]), input.GetField(i.Fields[2])
+	if math.IsNaN(high) || math.IsNaN(low) || math.IsNaN(last) {
+		return tick.New()
+	}
+
+	s := &i.state
+	s.Count++
+	s.Highs.Push(high)
+	s.Lows.Push(low)
+	s.Closes.Push(last)
+	if !s.Highs.Full() {
+		return tick.New()
+	}
+	tenkan, kijun := s.mid(i.Tenkan), s.mid(i.Kijun)
+	s.LeadA.Push((tenkan + kijun) / 2)
+	s.LeadB.Push(s.mid(i.Senkou))
+	if !s.LeadA.Full() {
+		return tick.New()
+	}
+
+	a, b := s.LeadA.At(i.Displacement), s.LeadB.At(i.Displacement)
+	output = plugins.Output(input, map[string]float64{
+		"tenkan":   tenkan,
+		"kijun":    kijun,
+		"senkou_a": a,
+		"senkou_b": b,
+	})
+
+	top, bottom := max(a, b), min(a, b)
+	if s.Count > i.Warmup() {
+		i.signal(output, tenkan, kijun, last, top, bottom)
+	}
+	s.Tenkan, s.Kijun, s.Close, s.Top, s.Bottom = tenkan, kijun, last, top, bottom
+	return output
+}
+
+// signal sets the crossings of the tenkan and the kijun and the breakouts of
+// the close from the cloud.
+func (i *ichimoku) signal(output *tick.Tick, tenkan, kijun, last, top, bottom float64) {
+	s := &i.state
+	switch {
+	case s.Tenkan <= s.Kijun && tenkan > kijun:
+		output.SetSignal(sig.CROSSOVER, side(kijun, top, bottom, sig.STRONG, sig.WEAK))
+	case s.Tenkan >= s.Kijun && tenkan < kijun:
+		output.SetSignal(sig.CROSSUNDER, side(kijun, top, bottom, sig.WEAK, sig.STRONG))
+	}
+
+	// the cloud projected from this bar, and the chikou span against the
+	// close it is plotted over
+	a, b, earlier := s.LeadA.At(0), s.LeadB.At(0), s.Closes.At(i.Displacement)
+	switch {
+	case s.Close <= s.Top && last > top:
+		output.SetSignal(sig.BULLISH, agree(a > b, last > earlier))
+	case s.Close >= s.Bottom && last < bottom:
+		output.SetSignal(sig.BEARISH, agree(a < b, last < earlier))
+	}
+}
+
+// side returns the strength of a value above the cloud, below it, and MEDIUM in it.
+func side(value, top, bottom float64, above, below sig.Strength) sig.Strength {
+	switch {
+	case value > top:
+		return above
+	case value < bottom:
+		return below
+	default:
+		return sig.MEDIUM
+	}
+}
+
+// agree returns STRONG when the cloud and the chikou agree, MEDIUM when one does and WEAK otherwise.
+func agree(cloud, chikou bool) sig.Strength {
+	switch {
+	case cloud && chikou:
+		return sig.STRONG
+	case cloud || chikou:
+		return sig.MEDIUM
+	default:
+		return sig.WEAK
+	}
+}
+
+// Projection returns the senkou spans of the next displacement intervals,
+// computed over the last displacement bars, nil until warmed up.
+func (i *ichimoku) Projection() []map[string]float64 {
+	s := &i.state
+	if !s.LeadA.Full() {
+		return nil
+	}
+	projection := make([]map[string]float64, i.Displacement)
+	for k := range projection {
+		ago := i.Displacement - 1 - k
+		projection[k] = map[string]float64{"senkou_a": s.LeadA.At(ago), "senkou_b": s.LeadB.At(ago)}
+	}
+	return projection
+}
+
+func (i *ichimoku) Reset() {
+	period := max(i.Tenkan, i.Kijun, i.Senkou)
+	i.state = ichimokuState{
+		Highs:  plugins.NewRing(period),
+		Lows:   plugins.NewRing(period),
+		Closes: plugins.NewRing(i.Displacement + 1),
+		LeadA:  plugins.NewRing(i.Displacement + 1),
+		LeadB:  plugins.NewRing(i.Displacement + 1),
+	}
+}
+
+// Warmup returns the bars of the longest period and of the displacement.
+func (i *ichimoku) Warmup() int {
+	return max(i.Tenkan, i.Kijun, i.Senkou) + i.Displacement
+}
+
+func (i *ichimoku) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(i.state)
+}
+
+func (i *ichimoku) Restore(state []byte) error {
+	var s ichimokuState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.state = s
+	return nil
+}
+
+// mid returns the middle of the highest high and the lowest low of the last n bars.
+func (s *ichimokuState) mid(n int) float64 {
+	high, low := math.Inf(-1), math.Inf(1)
+	for k := range n {
+		high, low = max(high, s.Highs.At(k)), min(low, s.Lows.At(k))
+	}
+	return (high + low) / 2
+}
+
+func init() {
+	indicators.Add(ichimokuPluginID, ichimokuNew, indicators.TREND)
+}
@@ -0,0 +1,192 @@
+package ichimoku
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+)
+
+// fourHours returns the bars every 4 hours, lasting 4 hours unless duration is false.
+func fourHours(b indicatortest.Bars, duration bool) *series.Series {
+	s := b.Series(4 * time.Hour)
+	if duration {
+		for _, t := range s.Ticks() {
+			t.SetDuration(4 * time.Hour)
+		}
+	}
+	return s
+}
+
+// mid is the middle of the range of the n bars up to i, NaN before.
+func mid(b indicatortest.Bars, i, n int) float64 {
+	if i < n-1 {
+		return math.NaN()
+	}
+	high, low := math.Inf(-1), math.Inf(1)
+	for k := i - n + 1; k <= i; k++ {
+		high, low = max(high, b.High[k]), min(low, b.Low[k])
+	}
+	return (high + low) / 2
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestIchimoku(t *testing.T) {
+	b := indicatortest.RandomBars(200, 23)
+	tenkan, kijun, senkou, displacement := 7, 20, 40, 15
+	i := ichimokuNew(opt.With("tenkan", tenkan), opt.With("kijun", kijun), opt.With("senkou", senkou), opt.With("displacement", displacement)).(internal.Indicator)
+	output := i.Compute(fourHours(b, true))
+
+	leadA := func(j int) float64 { return (mid(b, j, tenkan) + mid(b, j, kijun)) / 2 }
+	warmup := senkou + displacement
+	if i.Warmup() != warmup {
+		t.Errorf("Warmup() = %d, want %d", i.Warmup(), warmup)
+	}
+	if output.Len() != len(b.Close)-warmup+1+displacement {
+		t.Fatalf("got %d outputs, want %d", output.Len(), len(b.Close)-warmup+1+displacement)
+	}
+
+	for k := range output.Len() {
+		out, j := output.At(k), k+warmup-1
+		if want := indicatortest.Base.Add(time.Duration(j) * 4 * time.Hour); !out.Time().Equal(want) || out.Duration() != 4*time.Hour {
+			t.Errorf("output %d at %v lasting %v, want %v", k, out.Time(), out.Duration(), want)
+		}
+
+		// the cloud projected after the last bar
+		if j >= len(b.Close) {
+			want := map[string]float64{"senkou_a": leadA(j - displacement), "senkou_b": mid(b, j-displacement, senkou)}
+			if len(out.Fields()) != 2 || !near(out.GetField("senkou_a"), want["senkou_a"]) || !near(out.GetField("senkou_b"), want["senkou_b"]) {
+				t.Errorf("projection %d = %v, want %v", j, out.Fields(), want)
+			}
+			continue
+		}
+
+		for field, want := range map[string]float64{
+			"tenkan":   mid(b, j, tenkan),
+			"kijun":    mid(b, j, kijun),
+			"senkou_a": leadA(j - displacement),
+			"senkou_b": mid(b, j-displacement, senkou),
+		} {
+			if got := out.GetField(field); !near(got, want) {
+				t.Errorf("%s[%d] = %v, want %v", field, j, got, want)
+			}
+		}
+
+		// the close of the bar displacement later, none for the last bars
+		if j+displacement >= len(b.Close) {
+			if out.HasField("chikou") {
+				t.Errorf("chikou[%d] = %v, want none", j, out.GetField("chikou"))
+			}
+		} else if got := out.GetField("chikou"); got != b.Close[j+displacement] {
+			t.Errorf("chikou[%d] = %v, want the close of bar %d %v", j, got, j+displacement, b.Close[j+displacement])
+		}
+	}
+
+	// the spacing of the bars without duration
+	output = i.Compute(fourHours(b, false))
+	if last := output.At(output.Len() - 1); !last.Time().Equal(indicatortest.Base.Add(time.Duration(len(b.Close)-1+displacement) * 4 * time.Hour)) {
+		t.Errorf("last projection at %v", last.Time())
+	}
+}
+
+func TestIchimokuSignals(t *testing.T) {
+	b := indicatortest.RandomBars(400, 23)
+	i := ichimokuNew().(internal.Indicator)
+	output := i.Compute(fourHours(b, true))
+
+	counts := map[sig.Signal]int{}
+	strengths := map[sig.Strength]int{}
+	for k := 1; k < output.Len()-26; k++ {
+		out, prev, j := output.At(k), output.At(k-1), k+i.Warmup()-1
+		for s := range out.Signals() {
+			counts[s]++
+		}
+
+		tenkan, kijun := out.GetField("tenkan"), out.GetField("kijun")
+		top := max(out.GetField("senkou_a"), out.GetField("senkou_b"))
+		bottom := min(out.GetField("senkou_a"), out.GetField("senkou_b"))
+		over := prev.GetField("tenkan") <= prev.GetField("kijun") && tenkan > kijun
+		under := prev.GetField("tenkan") >= prev.GetField("kijun") && tenkan < kijun
+		if out.HasSignal(sig.CROSSOVER) != over || out.HasSignal(sig.CROSSUNDER) != under {
+			t.Errorf("output %d: tenkan %v, kijun %v with signals %v", k, tenkan, kijun, out.SignalNames())
+		}
+		if over && kijun > top && out.GetSignal(sig.CROSSOVER) != sig.STRONG {
+			t.Errorf("output %d: crossover above the cloud with signals %v", k, out.Signals())
+		}
+
+		prevTop := max(prev.GetField("senkou_a"), prev.GetField("senkou_b"))
+		prevBottom := min(prev.GetField("senkou_a"), prev.GetField("senkou_b"))
+		up := b.Close[j-1] <= prevTop && b.Close[j] > top
+		down := b.Close[j-1] >= prevBottom && b.Close[j] < bottom
+		if out.HasSignal(sig.BULLISH) != up || out.HasSignal(sig.BEARISH) != down {
+			t.Errorf("output %d: close %v, cloud %v-%v with signals %v", k, b.Close[j], bottom, top, out.SignalNames())
+		}
+
+		// the breakout is confirmed by the cloud projected from the bar and
+		// by the chikou span against the close of the bar it is plotted over
+		projected := output.At(k + 26)
+		spanA, spanB := projected.GetField("senkou_a"), projected.GetField("senkou_b")
+		var signal sig.Signal
+		var cloud, chikou bool
+		switch {
+		case up:
+			signal, cloud, chikou = sig.BULLISH, spanA > spanB, b.Close[j] > b.Close[j-26]
+		case down:
+			signal, cloud, chikou = sig.BEARISH, spanA < spanB, b.Close[j] < b.Close[j-26]
+		default:
+			continue
+		}
+		want := sig.WEAK
+		if cloud || chikou {
+			want = sig.MEDIUM
+		}
+		if cloud && chikou {
+			want = sig.STRONG
+		}
+		strengths[want]++
+		if got := out.GetSignal(signal); got != want {
+			t.Errorf("output %d: %v %v, want %v with the cloud %v and the chikou %v", k, signal, got, want, cloud, chikou)
+		}
+	}
+	if strengths[sig.STRONG] == 0 || strengths[sig.MEDIUM] == 0 {
+		t.Errorf("breakout strengths %v, want STRONG and MEDIUM ones", strengths)
+	}
+	for _, s := range []sig.Signal{sig.CROSSOVER, sig.CROSSUNDER, sig.BULLISH, sig.BEARISH} {
+		if counts[s] == 0 {
+			t.Errorf("no %v signal over %d bars", s, len(b.Close))
+		}
+	}
+
+	// the first output has no previous one to cross
+	if out := output.At(0); len(out.Signals()) != 0 {
+		t.Errorf("first output has signals %v", out.Signals())
+	}
+}
+
+func TestIchimokuSnapshot(t *testing.T) {
+	input := fourHours(indicatortest.RandomBars(150, 23), true)
+	newIchimoku := func() internal.Indicator {
+		return ichimokuNew(opt.With("senkou", 30), opt.With("displacement", 10)).(internal.Indicator)
+	}
+	live := indicatortest.SnapshotReplay(t, newIchimoku, input, 60)
+
+	// the projection while streaming is the one of the batch
+	batch := newIchimoku().Compute(input)
+	projection := live.(*ichimoku).Projection()
+	if len(projection) != 10 {
+		t.Fatalf("got %d projections, want 10", len(projection))
+	}
+	for k, fields := range projection {
+		if want := batch.At(batch.Len() - len(projection) + k); fields["senkou_a"] != want.GetField("senkou_a") || fields["senkou_b"] != want.GetField("senkou_b") {
+			t.Errorf("projection %d: live %v, batch %v", k, fields, want.Fields())
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Package indicatortest provides the inputs and the reference computations
+// shared by the tests of the indicator packages.
+package indicatortest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/series"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// Base is the time of the first tick of the inputs.
+var Base = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Values returns the values as the value field of one tick a minute.
+func Values(vs ...float64) *series.Series {
+	s := series.New("values")
+	for i, v := range vs {
+		s.Add(tick.New(
+			tick.WithTime(Base.Add(time.Duration(i)*time.Minute)),
+			tick.WithFields(map[string]float64{"value": v}),
+		))
+	}
+	return s
+}
+
+// RandomWalk returns n values of a random walk from 100.
+func RandomWalk(n int, seed int64) []float64 {
+	r := rand.New(rand.NewSource(seed))
+	xs := make([]float64, n)
+	x := 100.0
+	for i := range xs {
+		x += r.NormFloat64()
+		xs[i] = x
+	}
+	return xs
+}
+
+// Bars are OHLCV bars by field.
+type Bars struct {
+	Open, High, Low, Close, Volume []float64
+}
+
+// Add appends a bar.
+func (b *Bars) Add(open, high, low, last, volume float64) {
+	b.Open = append(b.Open, open)
+	b.High = append(b.High, high)
+	b.Low = append(b.Low, low)
+	b.Close = append(b.Close, last)
+	b.Volume = append(b.Volume, volume)
+}
+
+// RandomBars returns n bars of a random walk with a slow cycle. Shadows are
+// in quarters, so equal highs and lows occur, and now and then a bar does
+// not move or repeats the previous one inside its range. Volumes are whole,
+// from 0 to 4999.
+func RandomBars(n int, seed int64) Bars {
+	r := rand.New(rand.NewSource(seed))
+	var b Bars
+	price := 100.0
+	for i := range n {
+		open := price
+		price += r.NormFloat64() + 0.5*math.Sin(float64(i)/12)
+		if r.Intn(10) == 0 {
+			price = open
+		}
+		high := math.Max(open, price) + math.Round(r.Float64()*4)/4
+		low := math.Min(open, price) - math.Round(r.Float64()*4)/4
+		if i > 0 && r.Intn(12) == 0 {
+			high, low, price = b.High[i-1], b.Low[i-1], b.Close[i-1]
+			open = price
+		}
+		b.Add(open, high, low, price, float64(r.Intn(5000)))
+	}
+	return b
+}
+
+// Len returns the number of bars.
+func (b Bars) Len() int {
+	return len(b.Close)
+}
+
+// Series returns the bars at the interval as produced by the OHLCV
+// indicator, with the close as value too, tagged with their day.
+func (b Bars) Series(interval time.Duration) *series.Series {
+	s := series.New("bars")
+	for i := range b.Close {
+		at := Base.Add(time.Duration(i) * interval)
+		s.Add(tick.New(
+			tick.WithTime(at),
+			tick.WithFields(map[string]float64{
+				"open": b.Open[i], "high": b.High[i], "low": b.Low[i], "close": b.Close[i],
+				"volume": b.Volume[i], "value": b.Close[i],
+			}),
+			tick.WithTags(map[string]string{"day": at.Format(time.DateOnly)}),
+		))
+	}
+	return s
+}
+
+// Fields returns the values of the field of every tick of the series.
+func Fields(s *series.Series, field string) []float64 {
+	out := make([]float64, s.Len())
+	for i := range out {
+		out[i] = s.At(i).GetField(field)
+	}
+	return out
+}
+
+// Near fails unless got matches want up to a relative error of 1e-9.
+func Near(t *testing.T, name string, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d outputs, want %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9*math.Max(1, math.Abs(want[i])) {
+			t.Errorf("%s[%d] = %v, want %v", name, i, got[i], want[i])
+		}
+	}
+}
+
+// Tail returns the last n values of xs, aligning references of different warm-ups.
+func Tail(xs []float64, n int) []float64 {
+	return xs[len(xs)-n:]
+}
+
+// Align returns xs preceded by NaN values up to the size.
+func Align(xs []float64, size int) []float64 {
+	out := make([]float64, size-len(xs), size)
+	for i := range out {
+		out[i] = math.NaN()
+	}
+	return append(out, xs...)
+}
@@ -0,0 +1,77 @@
+package indicatortest
+
+import "math"
+
+// The references follow the TA-Lib definitions directly, recomputing each
+// output from its whole window. They return the outputs from the first
+// defined one on.
+
+// SMA returns the simple moving averages of xs.
+func SMA(xs []float64, n int) []float64 {
+	var out []float64
+	for i := n - 1; i < len(xs); i++ {
+		sum := 0.0
+		for _, x := range xs[i-n+1 : i+1] {
+			sum += x
+		}
+		out = append(out, sum/float64(n))
+	}
+	return out
+}
+
+// EMA returns the exponential moving averages of xs, seeded with the simple
+// average of the first n values.
+func EMA(xs []float64, n int) []float64 {
+	return EMAAlpha(xs, n, 2/float64(n+1))
+}
+
+// EMAAlpha is EMA with a custom smoothing factor.
+func EMAAlpha(xs []float64, n int, alpha float64) []float64 {
+	if len(xs) < n {
+		return nil
+	}
+	out := []float64{SMA(xs[:n], n)[0]}
+	for _, x := range xs[n:] {
+		out = append(out, alpha*x+(1-alpha)*out[len(out)-1])
+	}
+	return out
+}
+
+// Wilder returns the Wilder smoothing of xs, seeded with the simple average
+// of the first n values.
+func Wilder(xs []float64, n int) []float64 {
+	return EMAAlpha(xs, n, 1/float64(n))
+}
+
+// Std returns the population standard deviations of the windows of n values.
+func Std(xs []float64, n int) []float64 {
+	var out []float64
+	for i := n - 1; i < len(xs); i++ {
+		w := xs[i-n+1 : i+1]
+		mean := SMA(w, n)[0]
+		v := 0.0
+		for _, x := range w {
+			v += (x - mean) * (x - mean)
+		}
+		out = append(out, math.Sqrt(v/float64(n)))
+	}
+	return out
+}
+
+// TrueRange returns the true ranges of the bars from the second one.
+func TrueRange(b Bars) []float64 {
+	var out []float64
+	for i := 1; i < b.Len(); i++ {
+		out = append(out, max(b.High[i]-b.Low[i], math.Abs(b.High[i]-b.Close[i-1]), math.Abs(b.Low[i]-b.Close[i-1])))
+	}
+	return out
+}
+
+// Changes returns the gains and the losses of xs from one value to the next.
+func Changes(xs []float64) (gains, losses []float64) {
+	for i := 1; i < len(xs); i++ {
+		gains = append(gains, max(xs[i]-xs[i-1], 0))
+		losses = append(losses, max(xs[i-1]-xs[i], 0))
+	}
+	return
+}
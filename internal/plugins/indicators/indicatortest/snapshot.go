@@ -0,0 +1,69 @@
+package indicatortest
+
+import (
+	"maps"
+	"math"
+	"testing"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/series"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// SnapshotReplay streams the input through an indicator from new, replacing
+// it before the tick at by another one restored from its snapshot, and fails
+// unless the outputs are the ones of an indicator streamed without the
+// restart, and those of Compute over the input. Compute may complete its
+// rows with fields known only later, such as a lagging span, and add rows
+// after the last tick of the input, such as a projection. It returns the
+// restored indicator.
+func SnapshotReplay(t *testing.T, new func() internal.Indicator, input *series.Series, at int) internal.Indicator {
+	t.Helper()
+	batch := new().Compute(input)
+
+	live, whole := new(), new()
+	var outputs, wholes []*tick.Tick
+	for i, in := range input.Ticks() {
+		if i == at {
+			state, err := live.Snapshot()
+			if err != nil {
+				t.Fatal(err)
+			}
+			live = new()
+			if err := live.Restore(state); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if out := live.Update(in); !out.IsEmpty() {
+			outputs = append(outputs, out)
+		}
+		if out := whole.Update(in); !out.IsEmpty() {
+			wholes = append(wholes, out)
+		}
+	}
+
+	rows := batch.Len()
+	for rows > 0 && input.Len() > 0 && batch.At(rows-1).UnixNano() > input.At(input.Len()-1).UnixNano() {
+		rows--
+	}
+	if len(outputs) != len(wholes) || len(outputs) != rows {
+		t.Fatalf("live produced %d outputs, %d without the restart, batch %d", len(outputs), len(wholes), rows)
+	}
+	for i, out := range outputs {
+		if whole := wholes[i]; out.UnixNano() != whole.UnixNano() || !maps.EqualFunc(out.Fields(), whole.Fields(), same) || !maps.Equal(out.Signals(), whole.Signals()) {
+			t.Errorf("output %d: live %v %v %v, without the restart %v %v %v", i, out.Time(), out.Fields(), out.Signals(), whole.Time(), whole.Fields(), whole.Signals())
+		}
+		want := batch.At(i)
+		fields := want.Fields()
+		maps.DeleteFunc(fields, func(field string, _ float64) bool { return !out.HasField(field) })
+		if out.UnixNano() != want.UnixNano() || !maps.EqualFunc(out.Fields(), fields, same) || !maps.Equal(out.Signals(), want.Signals()) {
+			t.Errorf("output %d: live %v %v %v, batch %v %v %v", i, out.Time(), out.Fields(), out.Signals(), want.Time(), want.Fields(), want.Signals())
+		}
+	}
+	return live
+}
+
+// same returns true if the values are equal or both NaN.
+func same(a, b float64) bool {
+	return a == b || math.IsNaN(a) && math.IsNaN(b)
+}
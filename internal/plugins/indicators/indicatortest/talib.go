@@ -0,0 +1,130 @@
+package indicatortest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/series"
+)
+
+// TALib are the bars the TA-Lib reference outputs of the indicator packages
+// are computed over, RandomBars(100, 1) with the prices rounded to cents.
+var TALib = Bars{
+	Open: []float64{
+		100.00, 98.77, 98.77, 98.10, 98.96, 98.80, 97.57, 97.97,
+		97.17, 98.41, 98.41, 98.41, 100.23, 102.38, 102.15, 102.57,
+		101.64, 102.51, 101.69, 101.69, 102.19, 102.13, 101.72, 102.35,
+		103.45, 104.40, 104.40, 105.05, 103.21, 104.27, 102.87, 102.87,
+		102.87, 101.96, 101.96, 101.61, 101.61, 101.61, 101.72, 101.72,
+		101.72, 101.27, 101.42, 101.19, 102.34, 101.67, 102.68, 101.68,
+		102.17, 102.02, 102.27, 102.32, 102.99, 102.99, 101.83, 101.83,
+		102.26, 101.14, 102.39, 102.01, 102.01, 102.31, 102.53, 101.15,
+		101.15, 101.66, 101.20, 102.18, 101.14, 100.57, 99.73, 100.26,
+		98.56, 99.15, 98.54, 98.54, 99.15, 99.37, 99.37, 99.37,
+		99.37, 98.74, 99.31, 99.34, 99.64, 99.01, 99.69, 99.69,
+		99.73, 99.97, 99.97, 98.79, 99.53, 99.53, 100.28, 101.64,
+		101.70, 101.70, 102.72, 102.99,
+	},
+	High: []float64{
+		100.75, 100.75, 99.27, 99.21, 99.21, 98.80, 97.97, 98.22,
+		98.66, 98.41, 98.41, 100.98, 103.38, 102.88, 102.82, 102.82,
+		102.76, 103.01, 102.19, 103.19, 102.69, 102.38, 103.35, 104.20,
+		104.90, 104.90, 105.55, 105.55, 104.52, 105.02, 102.87, 103.12,
+		103.12, 103.12, 102.71, 102.71, 102.71, 102.22, 102.47, 102.47,
+		102.47, 101.92, 101.67, 103.09, 103.34, 103.18, 103.18, 102.42,
+		102.92, 102.52, 102.32, 103.99, 103.99, 103.99, 103.99, 102.76,
+		103.01, 103.39, 103.39, 102.01, 103.31, 103.28, 103.28, 103.28,
+		101.66, 101.91, 103.18, 102.93, 101.64, 101.07, 101.26, 101.01,
+		100.15, 99.90, 99.29, 99.90, 100.12, 99.87, 100.12, 100.12,
+		99.62, 99.81, 99.84, 100.39, 99.89, 100.69, 100.69, 100.23,
+		100.22, 100.22, 100.22, 100.03, 99.78, 100.78, 101.89, 101.95,
+		101.95, 103.72, 103.74, 104.54,
+	},
+	Low: []float64{
+		98.27, 98.27, 97.85, 97.85, 98.05, 97.32, 96.82, 96.67,
+		96.17, 98.16, 98.16, 97.41, 99.98, 101.40, 101.40, 101.39,
+		101.39, 100.94, 101.19, 101.19, 102.13, 101.22, 100.97, 102.10,
+		102.95, 103.90, 104.15, 103.21, 102.96, 102.37, 102.87, 102.62,
+		101.21, 101.21, 100.61, 100.61, 100.61, 101.36, 100.97, 100.97,
+		100.52, 101.27, 100.44, 100.94, 100.67, 100.67, 100.93, 101.68,
+		101.27, 101.52, 102.02, 101.57, 101.57, 101.08, 101.08, 101.83,
+		100.39, 100.64, 101.76, 101.76, 101.76, 102.06, 100.90, 100.90,
+		100.65, 100.45, 100.95, 100.89, 99.82, 99.48, 98.98, 97.81,
+		97.81, 97.79, 98.29, 97.79, 98.90, 99.37, 98.62, 98.62,
+		97.99, 98.49, 98.31, 98.59, 98.51, 98.76, 98.76, 99.69,
+		99.48, 99.48, 97.79, 98.54, 99.28, 98.78, 100.28, 100.64,
+		100.64, 101.45, 102.72, 102.49,
+	},
+	Close: []float64{
+		98.77, 98.77, 98.10, 98.96, 98.80, 97.57, 97.97, 97.17,
+		98.41, 98.41, 98.41, 100.23, 102.38, 102.15, 102.57, 101.64,
+		102.51, 101.69, 101.69, 102.19, 102.13, 101.72, 102.35, 103.45,
+		104.40, 104.40, 105.05, 103.21, 104.27, 102.87, 102.87, 102.87,
+		101.96, 101.96, 101.61, 101.61, 101.61, 101.72, 101.72, 101.72,
+		101.27, 101.42, 101.19, 102.34, 101.67, 102.68, 101.68, 102.17,
+		102.02, 102.27, 102.32, 102.99, 102.99, 101.83, 101.83, 102.26,
+		101.14, 102.39, 102.01, 102.01, 102.31, 102.53, 101.15, 101.15,
+		101.66, 101.20, 102.18, 101.14, 100.57, 99.73, 100.26, 98.56,
+		99.15, 98.54, 98.54, 99.15, 99.37, 99.37, 99.37, 99.37,
+		98.74, 99.31, 99.34, 99.64, 99.01, 99.69, 99.69, 99.73,
+		99.97, 99.97, 98.79, 99.53, 99.53, 100.28, 101.64, 101.70,
+		101.70, 102.72, 102.99, 103.79,
+	},
+	Volume: []float64{
+		2081, 694, 1211, 1528, 2790, 429, 1413, 4078, 2189, 4703,
+		3266, 1563, 1577, 1137, 3891, 2940, 2425, 3410, 3591, 4271,
+		2066, 1052, 1528, 3612, 2051, 2305, 3767, 2342, 4535, 4371,
+		1720, 565, 695, 3831, 3447, 3318, 3675, 4386, 1661, 2060,
+		2039, 1509, 740, 421, 3338, 3524, 3622, 3479, 4511, 3408,
+		2375, 1114, 2587, 4983, 36, 2887, 3602, 2120, 556, 1478,
+		3265, 4673, 48, 3531, 4330, 3193, 1494, 2759, 3475, 1581,
+		1695, 973, 1406, 3033, 2523, 2208, 2587, 3849, 3973, 2913,
+		1244, 1925, 3176, 913, 2221, 864, 1813, 3963, 3333, 2037,
+		104, 2764, 894, 1757, 1315, 3401, 2283, 1590, 3268, 2762,
+	},
+}
+
+// Approx fails unless got matches the TA-Lib outputs in want, rounded to 8
+// decimals, up to 1e-6.
+func Approx(t *testing.T, name string, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: got %d outputs, want %d", name, len(got), len(want))
+		return
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-6 {
+			t.Errorf("%s[%d] = %.8f, want %.8f", name, i, got[i], want[i])
+		}
+	}
+}
+
+// TALibCase is an output field of an indicator checked against TA-Lib over
+// the TALib bars, from the first defined value on, rounded to 8 decimals.
+type TALibCase struct {
+	Name    string // the name the failures are reported with
+	New     func(...internal.PluginOptions) internal.Plugin
+	Options []internal.PluginOptions // the parameters, the defaults when nil
+	Field   string
+	Want    []float64
+
+	// Values returns the values checked from the output, the field when nil.
+	Values func(output *series.Series) []float64
+}
+
+// TALibCases fails unless each indicator computes the TA-Lib outputs of its
+// case over the TALib bars.
+func TALibCases(t *testing.T, cases []TALibCase) {
+	t.Helper()
+	input := TALib.Series(time.Minute)
+	for _, c := range cases {
+		output := c.New(c.Options...).Compute(input)
+		got := Fields(output, c.Field)
+		if c.Values != nil {
+			got = c.Values(output)
+		}
+		Approx(t, c.Name+" "+c.Field, got, c.Want)
+	}
+}
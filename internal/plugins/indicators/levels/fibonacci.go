@@ -0,0 +1,185 @@
+package levels
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// R = Swing High - Swing Low of the last leg between swings
+// Up leg, the swing high after the swing low:
+// Retracement = High - r * R, Extension = Low + e * R
+// Down leg, the swing low after the swing high:
+// Retracement = Low + r * R, Extension = High - e * R
+// for the ratios r of 0.236, 0.382, 0.5, 0.618 and 0.786 and e of 1.272, 1.618 and 2.618
+const fibonacciPluginID = "FIBLEVELS"
+const fibonacciPluginName = "Fibonacci Retracements and Extensions"
+const fibonacciPluginDescription = "Fibonacci Retracements and Extensions are the levels at Fibonacci ratios of the last leg between swing highs and lows."
+const fibonacciPluginHCL = `
+indicator "fiblevels" {
+  bars = 2
+}
+`
+
+// retracements and extensions are the ratios of the levels, each output as
+// fib_ followed by its thousandths.
+var (
+	retracements = []float64{0.236, 0.382, 0.5, 0.618, 0.786}
+	extensions   = []float64{1.272, 1.618, 2.618}
+)
+
+// fibonacci outputs the levels of the last leg between the swings of a
+// fractal, the swings and the direction of the leg, 1 up and -1 down, from
+// the bar both a swing high and a swing low are known. The closes breaking
+// or holding the levels of the leg before the bar are signalled, STRONG for
+// the 0.618 and 1.618 levels and MEDIUM for the others.
+type fibonacci struct {
+	plugins.Plugin
+
+	Bars int `hcl:"bars,optional"` // bars on each side of a swing
+
+	state fibonacciState
+}
+
+// fibonacciState is the snapshot of a fibonacci.
+type fibonacciState struct {
+	Count     int
+	Swings    swings
+	High, Low float64 // the last swings, NaN until found
+	HighBar   int     // bars of the swings
+	LowBar    int
+	PrevClose float64
+}
+
+func fibonacciNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	i := &fibonacci{
+		Plugin: plugins.Plugin{
+			PID:         fibonacciPluginID,
+			Title:       fibonacciPluginName,
+			Summary:     fibonacciPluginDescription,
+			Template:    fibonacciPluginHCL,
+			Params:      params,
+			Fields:      plugins.Bar(params, "high", "low", "close"),
+			Initialized: true,
+		},
+		Bars: max(params.Int("bars", 2), 1),
+	}
+	i.Reset()
+	return i
+}
+
+func (i *fibonacci) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+func (i *fibonacci) Compute(input *series.Series) (output *series.Series) {
+	return plugins.Compute(i.ID(), i, input)
+}
+
+func (i *fibonacci) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the bar and returns the levels of the last leg, once both a
+// swing high and a swing low were confirmed.
+func (i *fibonacci) Update(input *tick.Tick) (output *tick.Tick) {
+	values, ok := read(input, i.Fields)
+	if !ok {
+		return tick.New()
+	}
+	high, low, last := values[0], values[1], values[2]
+
+	s := &i.state
+	s.Count++
+	_, before := i.levels()
+	swingHigh, swingLow := s.Swings.add(high, low)
+	if !math.IsNaN(swingHigh) {
+		s.High, s.HighBar = swingHigh, s.Count-i.Bars
+	}
+	if !math.IsNaN(swingLow) {
+		s.Low, s.LowBar = swingLow, s.Count-i.Bars
+	}
+	prevClose := s.PrevClose
+	s.PrevClose = last
+	if math.IsNaN(s.High) || math.IsNaN(s.Low) {
+		return tick.New()
+	}
+
+	fields, _ := i.levels()
+	output = plugins.Output(input, fields)
+	signal(output, before, prevClose, high, low, last)
+	return output
+}
+
+// levels returns the fields of the last leg and its levels, nil without one.
+func (i *fibonacci) levels() (fields map[string]float64, levels []level) {
+	s := &i.state
+	if math.IsNaN(s.High) || math.IsNaN(s.Low) {
+		return nil, nil
+	}
+
+	// the swing high and the swing low of one bar make an up leg
+	up, r := s.HighBar >= s.LowBar, s.High-s.Low
+	fields = map[string]float64{"swing_high": s.High, "swing_low": s.Low, "direction": 1}
+	if !up {
+		fields["direction"] = -1
+	}
+	add := func(ratio, price float64) {
+		fields[fmt.Sprintf("fib_%d", int(math.Round(ratio*1000)))] = price
+		strength := sig.MEDIUM
+		if ratio == 0.618 || ratio == 1.618 {
+			strength = sig.STRONG
+		}
+		levels = append(levels, level{Price: price, Strength: strength})
+	}
+	for _, ratio := range retracements {
+		if up {
+			add(ratio, s.High-ratio*r)
+		} else {
+			add(ratio, s.Low+ratio*r)
+		}
+	}
+	for _, ratio := range extensions {
+		if up {
+			add(ratio, s.Low+ratio*r)
+		} else {
+			add(ratio, s.High-ratio*r)
+		}
+	}
+	return fields, levels
+}
+
+func (i *fibonacci) Reset() {
+	i.state = fibonacciState{Swings: newSwings(i.Bars), High: math.NaN(), Low: math.NaN()}
+}
+
+// Warmup returns the bars before the first output at the least, the ones
+// confirming the first swing.
+func (i *fibonacci) Warmup() int {
+	return 2*i.Bars + 1
+}
+
+func (i *fibonacci) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(i.state)
+}
+
+func (i *fibonacci) Restore(state []byte) error {
+	var s fibonacciState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.state = s
+	return nil
+}
+
+func init() {
+	indicators.Add(fibonacciPluginID, fibonacciNew, indicators.OTHER)
+}
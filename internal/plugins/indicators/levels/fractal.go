@@ -0,0 +1,148 @@
+package levels
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// Swing High = High[n] when High[n] > High[k] for every other k of the last 2n+1 bars
+// Swing Low = Low[n] when Low[n] < Low[k] for every other k of the last 2n+1 bars
+const fractalPluginID = "FRACTAL"
+const fractalPluginName = "Fractal Swing Highs and Lows"
+const fractalPluginDescription = "Fractal Swing Highs and Lows are the highs and lows beyond the ones of the bars around them, confirmed bars later."
+const fractalPluginHCL = `
+indicator "fractal" {
+  bars = 2
+}
+`
+
+// fractal outputs the last swing high and low, confirmed bars after them,
+// and whether the bar confirmed a new one, from the bar both are known. A new
+// swing high is a MEDIUM BEARISH signal and a new swing low a MEDIUM BULLISH
+// one, the closes breaking or holding the swings before the bar are STRONG.
+type fractal struct {
+	plugins.Plugin
+
+	Bars int `hcl:"bars,optional"` // bars on each side of a swing
+
+	state fractalState
+}
+
+// fractalState is the snapshot of a fractal.
+type fractalState struct {
+	Swings    swings
+	High, Low float64 // the last swings, NaN until found
+	PrevClose float64
+}
+
+func fractalNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	i := &fractal{
+		Plugin: plugins.Plugin{
+			PID:         fractalPluginID,
+			Title:       fractalPluginName,
+			Summary:     fractalPluginDescription,
+			Template:    fractalPluginHCL,
+			Params:      params,
+			Fields:      plugins.Bar(params, "high", "low", "close"),
+			Initialized: true,
+		},
+		Bars: max(params.Int("bars", 2), 1),
+	}
+	i.Reset()
+	return i
+}
+
+func (i *fractal) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+func (i *fractal) Compute(input *series.Series) (output *series.Series) {
+	return plugins.Compute(i.ID(), i, input)
+}
+
+func (i *fractal) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the bar and returns the last swings once both a swing high and
+// a swing low were confirmed.
+func (i *fractal) Update(input *tick.Tick) (output *tick.Tick) {
+	values, ok := read(input, i.Fields)
+	if !ok {
+		return tick.New()
+	}
+	high, low, last := values[0], values[1], values[2]
+
+	s := &i.state
+	swingHigh, swingLow := s.Swings.add(high, low)
+	levels := []level{{Price: s.High, Strength: sig.STRONG}, {Price: s.Low, Strength: sig.STRONG}}
+	if !math.IsNaN(swingHigh) {
+		s.High = swingHigh
+	}
+	if !math.IsNaN(swingLow) {
+		s.Low = swingLow
+	}
+	prevClose := s.PrevClose
+	s.PrevClose = last
+	if math.IsNaN(s.High) || math.IsNaN(s.Low) {
+		return tick.New()
+	}
+
+	output = plugins.Output(input, map[string]float64{
+		"swing_high": s.High,
+		"swing_low":  s.Low,
+		"new_high":   flag(!math.IsNaN(swingHigh)),
+		"new_low":    flag(!math.IsNaN(swingLow)),
+	})
+	if !math.IsNaN(swingHigh) {
+		output.SetSignal(sig.BEARISH, sig.MEDIUM)
+	}
+	if !math.IsNaN(swingLow) {
+		output.SetSignal(sig.BULLISH, sig.MEDIUM)
+	}
+	signal(output, levels, prevClose, high, low, last)
+	return output
+}
+
+// flag returns 1 when ok, else 0.
+func flag(ok bool) float64 {
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+func (i *fractal) Reset() {
+	i.state = fractalState{Swings: newSwings(i.Bars), High: math.NaN(), Low: math.NaN()}
+}
+
+// Warmup returns the bars before the first output at the least, the ones
+// confirming the first swing.
+func (i *fractal) Warmup() int {
+	return 2*i.Bars + 1
+}
+
+func (i *fractal) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(i.state)
+}
+
+func (i *fractal) Restore(state []byte) error {
+	var s fractalState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.state = s
+	return nil
+}
+
+func init() {
+	indicators.Add(fractalPluginID, fractalNew, indicators.PATTERN)
+}
@@ -0,0 +1,94 @@
+package levels
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal/plugins"
+	sig "github.com/rangertaha/gotal/internal/signals"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// level is a price level signalled when the price touches or breaks it.
+type level struct {
+	Price    float64
+	Strength sig.Strength
+}
+
+// signal sets the breaks of the levels by the close, a CROSSOVER above a
+// level and a CROSSUNDER below it, and the touches of the levels the close
+// held, BULLISH for a support touched from above and BEARISH for a
+// resistance touched from below, each with the strength of the strongest
+// level.
+func signal(output *tick.Tick, levels []level, prevClose, high, low, last float64) {
+	for _, l := range levels {
+		if math.IsNaN(l.Price) {
+			continue
+		}
+		switch {
+		case prevClose <= l.Price && last > l.Price:
+			set(output, sig.CROSSOVER, l.Strength)
+		case prevClose >= l.Price && last < l.Price:
+			set(output, sig.CROSSUNDER, l.Strength)
+		case prevClose > l.Price && last > l.Price && low <= l.Price:
+			set(output, sig.BULLISH, l.Strength)
+		case prevClose < l.Price && last < l.Price && high >= l.Price:
+			set(output, sig.BEARISH, l.Strength)
+		}
+	}
+}
+
+// set sets the signal unless the output has it stronger.
+func set(output *tick.Tick, signal sig.Signal, strength sig.Strength) {
+	if !output.HasSignal(signal) || output.GetSignal(signal) < strength {
+		output.SetSignal(signal, strength)
+	}
+}
+
+// read returns the values of the fields of the tick, false when one is missing.
+func read(input *tick.Tick, fields []string) ([]float64, bool) {
+	values := make([]float64, len(fields))
+	for j, field := range fields {
+		if values[j] = input.GetField(field); math.IsNaN(values[j]) {
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+// swings detects the swing highs and lows, the fractals of the bars with a
+// high above, or a low below, the ones of the Bars bars before and after.
+type swings struct {
+	Bars        int
+	Highs, Lows plugins.Ring // the last 2*Bars+1 bars
+}
+
+func newSwings(bars int) swings {
+	return swings{Bars: bars, Highs: plugins.NewRing(2*bars + 1), Lows: plugins.NewRing(2*bars + 1)}
+}
+
+// add adds a bar and returns the swing high and the swing low it confirms,
+// Bars bars ago, NaN when it confirms none.
+func (s *swings) add(high, low float64) (swingHigh, swingLow float64) {
+	s.Highs.Push(high)
+	s.Lows.Push(low)
+	swingHigh, swingLow = math.NaN(), math.NaN()
+	if !s.Highs.Full() {
+		return
+	}
+
+	isHigh, isLow := true, true
+	h, l := s.Highs.At(s.Bars), s.Lows.At(s.Bars)
+	for k := range s.Highs.Len {
+		if k != s.Bars {
+			isHigh = isHigh && h > s.Highs.At(k)
+			isLow = isLow && l < s.Lows.At(k)
+		}
+	}
+	if isHigh {
+		swingHigh = h
+	}
+	if isLow {
+		swingLow = l
+	}
+	return
+}
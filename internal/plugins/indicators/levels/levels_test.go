@@ -0,0 +1,316 @@
+package levels
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestPivot(t *testing.T) {
+	b := indicatortest.RandomBars(24*4, 24)
+	input := b.Series(time.Hour)
+
+	for _, test := range []struct {
+		id     string
+		new    func(opts ...internal.PluginOptions) internal.Plugin
+		levels func(o, h, l, c float64) map[string]float64
+	}{
+		{"PIVOT", pivotNew, func(o, h, l, c float64) map[string]float64 {
+			pp := (h + l + c) / 3
+			return map[string]float64{"pp": pp, "r1": 2*pp - l, "s1": 2*pp - h, "r2": pp + h - l, "s2": pp - h + l, "r3": h + 2*(pp-l), "s3": l - 2*(h-pp)}
+		}},
+		{"PIVOT_FIB", fibPivotNew, func(o, h, l, c float64) map[string]float64 {
+			pp := (h + l + c) / 3
+			return map[string]float64{"pp": pp, "r1": pp + 0.382*(h-l), "s1": pp - 0.382*(h-l), "r2": pp + 0.618*(h-l), "s2": pp - 0.618*(h-l), "r3": pp + h - l, "s3": pp - h + l}
+		}},
+		{"PIVOT_CAMARILLA", camarillaPivotNew, func(o, h, l, c float64) map[string]float64 {
+			r := h - l
+			return map[string]float64{"pp": (h + l + c) / 3,
+				"r1": c + r*1.1/12, "s1": c - r*1.1/12, "r2": c + r*1.1/6, "s2": c - r*1.1/6,
+				"r3": c + r*1.1/4, "s3": c - r*1.1/4, "r4": c + r*1.1/2, "s4": c - r*1.1/2}
+		}},
+		{"PIVOT_WOODIE", woodiePivotNew, func(o, h, l, c float64) map[string]float64 {
+			pp := (h + l + 2*c) / 4
+			return map[string]float64{"pp": pp, "r1": 2*pp - l, "s1": 2*pp - h, "r2": pp + h - l, "s2": pp - h + l, "r3": h + 2*(pp-l), "s3": l - 2*(h-pp)}
+		}},
+		{"PIVOT_DEMARK", demarkPivotNew, func(o, h, l, c float64) map[string]float64 {
+			x := h + l + 2*c
+			if c < o {
+				x = h + 2*l + c
+			} else if c > o {
+				x = 2*h + l + c
+			}
+			return map[string]float64{"pp": x / 4, "r1": x/2 - l, "s1": x/2 - h}
+		}},
+	} {
+		// daily sessions from midnight, and from 06:00 after a first short one
+		for _, reset := range []int{0, 6} {
+			first := reset
+			if reset == 0 {
+				first = 24
+			}
+			output := test.new(opt.With("reset", time.Duration(reset)*time.Hour)).Compute(input)
+			if output.Len() != len(b.Close)-first {
+				t.Fatalf("%s: got %d outputs, want %d", test.id, output.Len(), len(b.Close)-first)
+			}
+			for k := range output.Len() {
+				j := k + first
+				start := reset + (j-reset)/24*24
+				prev := max(start-24, 0)
+				o, h, l, c := b.Open[prev], math.Inf(-1), math.Inf(1), b.Close[start-1]
+				for m := prev; m < start; m++ {
+					h, l = max(h, b.High[m]), min(l, b.Low[m])
+				}
+				want := test.levels(o, h, l, c)
+				out := output.At(k)
+				if len(out.Fields()) != len(want) {
+					t.Fatalf("%s[%d] = %v, want %v", test.id, j, out.Fields(), want)
+				}
+				for field, w := range want {
+					if got := out.GetField(field); !near(got, w) {
+						t.Errorf("%s %s[%d] = %v, want %v", test.id, field, j, got, w)
+					}
+				}
+			}
+		}
+	}
+
+	// sessions of a tag
+	s := series.New("bars")
+	for k, session := range []string{"a", "a", "b", "b", "b", "c"} {
+		s.Add(tick.New(
+			tick.WithTime(indicatortest.Base.Add(time.Duration(k)*time.Minute)),
+			tick.WithFields(map[string]float64{"open": 100, "high": 101 + float64(k), "low": 99, "close": 100}),
+			tick.WithTags(map[string]string{"session": session}),
+		))
+	}
+	output := pivotNew(opt.With("session", "session")).Compute(s)
+	if output.Len() != 4 || output.At(0).GetField("pp") != (102+99+100)/3.0 || output.At(3).GetField("pp") != (105+99+100)/3.0 {
+		t.Errorf("tagged sessions: got %d outputs", output.Len())
+	}
+
+	if err := pivotNew(opt.With("timezone", "Nowhere/Nothing")).Init(); err == nil {
+		t.Error("Init() with an unknown time zone succeeded")
+	}
+}
+
+func TestPivotSignals(t *testing.T) {
+	// the first day ranges 90 to 110 and closes at 100: PP 100, R1 110, S1 90, R2 120
+	var b indicatortest.Bars
+	for k := range 24 {
+		b.Add(100, 110, 90, 100+float64(k%2), 0)
+	}
+	b.Close[23] = 100
+	b.Add(100, 101.5, 99.8, 101, 0)  // crosses over the pivot
+	b.Add(101, 101.2, 99.5, 102, 0)  // holds it
+	b.Add(102, 111.5, 101.5, 111, 0) // crosses over R1
+	b.Add(111, 121, 110, 119, 0)     // holds R1, touches R2 from below
+	b.Add(119, 119.5, 88, 89, 0)     // crosses under R1, the pivot and S1
+	output := pivotNew().Compute(b.Series(time.Hour))
+
+	for k, want := range []map[sig.Signal]sig.Strength{
+		{sig.CROSSOVER: sig.MEDIUM},
+		{sig.BULLISH: sig.MEDIUM},
+		{sig.CROSSOVER: sig.WEAK},
+		{sig.BULLISH: sig.WEAK, sig.BEARISH: sig.MEDIUM},
+		{sig.CROSSUNDER: sig.MEDIUM},
+	} {
+		if got := output.At(k).Signals(); len(got) != len(want) {
+			t.Errorf("bar %d: signals %v, want %v", k, got, want)
+		} else {
+			for s, strength := range want {
+				if got[s] != strength {
+					t.Errorf("bar %d: signals %v, want %v", k, got, want)
+				}
+			}
+		}
+	}
+}
+
+// refSwings returns the swing highs and lows of the bars, NaN elsewhere.
+func refSwings(b indicatortest.Bars, n int) (highs, lows []float64) {
+	for i := range b.High {
+		highs, lows = append(highs, math.NaN()), append(lows, math.NaN())
+		if i < n || i+n >= len(b.High) {
+			continue
+		}
+		isHigh, isLow := true, true
+		for k := i - n; k <= i+n; k++ {
+			if k != i {
+				isHigh = isHigh && b.High[i] > b.High[k]
+				isLow = isLow && b.Low[i] < b.Low[k]
+			}
+		}
+		if isHigh {
+			highs[i] = b.High[i]
+		}
+		if isLow {
+			lows[i] = b.Low[i]
+		}
+	}
+	return
+}
+
+func TestFractal(t *testing.T) {
+	b := indicatortest.RandomBars(300, 24)
+	for _, n := range []int{1, 2, 4} {
+		highs, lows := refSwings(b, n)
+		output := fractalNew(opt.With("bars", n)).Compute(b.Series(time.Hour))
+
+		high, low, k, swings := math.NaN(), math.NaN(), 0, 0
+		for j := range b.Close {
+			// the swing of the bar j-n is confirmed at j
+			newHigh, newLow := j >= n && !math.IsNaN(highs[j-n]), j >= n && !math.IsNaN(lows[j-n])
+			if newHigh {
+				high = highs[j-n]
+			}
+			if newLow {
+				low = lows[j-n]
+			}
+			if math.IsNaN(high) || math.IsNaN(low) {
+				continue
+			}
+			if k >= output.Len() {
+				t.Fatalf("bars %d: got %d outputs", n, output.Len())
+			}
+
+			out := output.At(k)
+			k++
+			if out.GetField("swing_high") != high || out.GetField("swing_low") != low ||
+				out.GetField("new_high") != flag(newHigh) || out.GetField("new_low") != flag(newLow) {
+				t.Errorf("bars %d, bar %d: %v, want swings %v %v", n, j, out.Fields(), high, low)
+			}
+			if newHigh && (!out.HasSignal(sig.BEARISH) || out.GetSignal(sig.BEARISH) < sig.MEDIUM) ||
+				newLow && (!out.HasSignal(sig.BULLISH) || out.GetSignal(sig.BULLISH) < sig.MEDIUM) {
+				t.Errorf("bars %d, bar %d: signals %v", n, j, out.SignalNames())
+			}
+			if newHigh || newLow {
+				swings++
+			}
+		}
+		if k != output.Len() || swings < 20 {
+			t.Errorf("bars %d: got %d outputs and %d swings, want %d outputs", n, output.Len(), swings, k)
+		}
+	}
+}
+
+func TestFibonacci(t *testing.T) {
+	b := indicatortest.RandomBars(300, 24)
+	fractals := fractalNew().Compute(b.Series(time.Hour))
+	output := fibonacciNew().Compute(b.Series(time.Hour))
+	if output.Len() != fractals.Len() {
+		t.Fatalf("got %d outputs, want %d", output.Len(), fractals.Len())
+	}
+
+	highs, lows := refSwings(b, 2)
+	highBar, lowBar, ups, downs := -1, -1, 0, 0
+	offset := len(b.Close) - output.Len()
+	for j := range b.Close {
+		if j >= 2 && !math.IsNaN(highs[j-2]) {
+			highBar = j - 2
+		}
+		if j >= 2 && !math.IsNaN(lows[j-2]) {
+			lowBar = j - 2
+		}
+		if j < offset {
+			continue
+		}
+
+		out, swing := output.At(j-offset), fractals.At(j-offset)
+		h, l := swing.GetField("swing_high"), swing.GetField("swing_low")
+		r := h - l
+		want := map[string]float64{"swing_high": h, "swing_low": l, "direction": 1,
+			"fib_236": h - 0.236*r, "fib_382": h - 0.382*r, "fib_500": h - 0.5*r, "fib_618": h - 0.618*r, "fib_786": h - 0.786*r,
+			"fib_1272": l + 1.272*r, "fib_1618": l + 1.618*r, "fib_2618": l + 2.618*r}
+		if highBar < lowBar {
+			want = map[string]float64{"swing_high": h, "swing_low": l, "direction": -1,
+				"fib_236": l + 0.236*r, "fib_382": l + 0.382*r, "fib_500": l + 0.5*r, "fib_618": l + 0.618*r, "fib_786": l + 0.786*r,
+				"fib_1272": h - 1.272*r, "fib_1618": h - 1.618*r, "fib_2618": h - 2.618*r}
+			downs++
+		} else {
+			ups++
+		}
+		if len(out.Fields()) != len(want) {
+			t.Fatalf("bar %d: %v, want %v", j, out.Fields(), want)
+		}
+		for field, w := range want {
+			if got := out.GetField(field); !near(got, w) {
+				t.Errorf("%s[%d] = %v, want %v", field, j, got, w)
+			}
+		}
+	}
+	if ups < 20 || downs < 20 {
+		t.Errorf("got %d up legs and %d down legs", ups, downs)
+	}
+}
+
+func TestSupport(t *testing.T) {
+	// a price bouncing between 90 and 110 every 20 bars
+	r := rand.New(rand.NewSource(4))
+	var b indicatortest.Bars
+	for k := range 300 {
+		phase := float64(k%20) / 10
+		price := 90 + 20*phase
+		if phase > 1 {
+			price = 90 + 20*(2-phase)
+		}
+		price += r.Float64() * 0.1
+		b.Add(price, price+0.2, price-0.2, price, 0)
+	}
+	output := supportNew().Compute(b.Series(time.Hour))
+	if output.Len() != len(b.Close)-99 {
+		t.Fatalf("got %d outputs, want %d", output.Len(), len(b.Close)-99)
+	}
+
+	bounces := 0
+	for k := range output.Len() {
+		out := output.At(k)
+		if got := out.GetField("levels"); got != 2 {
+			t.Errorf("output %d: %v levels, want 2", k, got)
+		}
+		if s := out.GetField("support"); math.Abs(s-89.85) > 0.1 || out.GetField("support_touches") < 4 {
+			t.Errorf("output %d: %v", k, out.Fields())
+		}
+		if res := out.GetField("resistance"); b.Close[k+99] < 110 && !(math.Abs(res-110.25) < 0.1 && out.GetField("resistance_touches") >= 4) {
+			t.Errorf("output %d: %v", k, out.Fields())
+		}
+		if out.HasSignal(sig.BULLISH) {
+			bounces++
+		}
+	}
+	if bounces < 5 {
+		t.Errorf("got %d bounces off the support", bounces)
+	}
+
+	if err := supportNew(opt.With("tolerance", -1.0)).Init(); err == nil {
+		t.Error("Init() with a negative tolerance succeeded")
+	}
+}
+
+func TestLevelsSnapshot(t *testing.T) {
+	input := indicatortest.RandomBars(24*5, 24).Series(time.Hour)
+	for id, new := range map[string]func(opts ...internal.PluginOptions) internal.Plugin{
+		"PIVOT_DEMARK": demarkPivotNew,
+		"FRACTAL":      fractalNew,
+		"FIBLEVELS":    fibonacciNew,
+		"SRLEVELS":     supportNew,
+	} {
+		t.Run(id, func(t *testing.T) {
+			indicatortest.SnapshotReplay(t, func() internal.Indicator {
+				return new(opt.WithPeriod(30)).(internal.Indicator)
+			}, input, 50)
+		})
+	}
+}
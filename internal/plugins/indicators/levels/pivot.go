@@ -0,0 +1,280 @@
+package levels
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// H, L and C are the high, low and close of the previous session, R = H - L
+//
+// Classic: PP = (H + L + C) / 3, R1 = 2PP - L, S1 = 2PP - H, R2 = PP + R,
+// S2 = PP - R, R3 = H + 2(PP - L), S3 = L - 2(H - PP)
+// Fibonacci: PP = (H + L + C) / 3, R1..R3 = PP + 0.382R, 0.618R, R and S1..S3 = PP - 0.382R, 0.618R, R
+// Camarilla: PP = (H + L + C) / 3, R1..R4 = C + 1.1R/12, 1.1R/6, 1.1R/4, 1.1R/2 and S1..S4 = C - 1.1R/12, 1.1R/6, 1.1R/4, 1.1R/2
+// Woodie: PP = (H + L + 2C) / 4, with the levels of the classic pivot
+// DeMark: X = H + 2L + C when C < O, 2H + L + C when C > O and H + L + 2C
+// otherwise, PP = X / 4, R1 = X/2 - L, S1 = X/2 - H
+const pivotPluginID = "PIVOT"
+const pivotPluginName = "Pivot Points"
+const pivotPluginDescription = "Pivot Points are the classic support and resistance levels of the session, from the high, low and close of the previous one."
+const pivotPluginHCL = `
+indicator "pivot" {
+  reset = "0h"
+  timezone = "UTC"
+}
+`
+
+const fibPivotPluginID = "PIVOT_FIB"
+const fibPivotPluginName = "Fibonacci Pivot Points"
+const fibPivotPluginDescription = "Fibonacci Pivot Points are the support and resistance levels of the session at Fibonacci ratios of the range of the previous one."
+const fibPivotPluginHCL = `
+indicator "pivot_fib" {
+  reset = "0h"
+  timezone = "UTC"
+}
+`
+
+const camarillaPivotPluginID = "PIVOT_CAMARILLA"
+const camarillaPivotPluginName = "Camarilla Pivot Points"
+const camarillaPivotPluginDescription = "Camarilla Pivot Points are the support and resistance levels of the session around the close of the previous one."
+const camarillaPivotPluginHCL = `
+indicator "pivot_camarilla" {
+  reset = "0h"
+  timezone = "UTC"
+}
+`
+
+const woodiePivotPluginID = "PIVOT_WOODIE"
+const woodiePivotPluginName = "Woodie Pivot Points"
+const woodiePivotPluginDescription = "Woodie Pivot Points are the classic pivot points weighting the close of the previous session twice."
+const woodiePivotPluginHCL = `
+indicator "pivot_woodie" {
+  reset = "0h"
+  timezone = "UTC"
+}
+`
+
+const demarkPivotPluginID = "PIVOT_DEMARK"
+const demarkPivotPluginName = "DeMark Pivot Points"
+const demarkPivotPluginDescription = "DeMark Pivot Points are the support and resistance levels of the session weighting the previous one by its direction."
+const demarkPivotPluginHCL = `
+indicator "pivot_demark" {
+  reset = "0h"
+  timezone = "UTC"
+}
+`
+
+// session is the OHLC of a session.
+type session struct {
+	Open, High, Low, Close float64
+}
+
+// pivot is the plugin of every pivot point method. The output is the levels
+// of the session, named pp, r1, s1 and so on, computed from the previous
+// session, from the first bar of the second session. The closes breaking or
+// holding a level are signalled, STRONG for the third levels and beyond,
+// MEDIUM for the second ones and the pivot, WEAK for the first ones.
+type pivot struct {
+	plugins.Plugin
+
+	sessions plugins.Sessions
+	method   func(s session) map[string]float64
+
+	state pivotState
+}
+
+// pivotState is the snapshot of a pivot.
+type pivotState struct {
+	Count     int
+	Session   string
+	Current   session            // the session so far
+	Levels    map[string]float64 // levels of the current session, nil in the first one
+	PrevClose float64
+}
+
+func newPivot(id, name, description, hcl string, params internal.Options, method func(s session) map[string]float64) *pivot {
+	i := &pivot{
+		Plugin: plugins.Plugin{
+			PID:         id,
+			Title:       name,
+			Summary:     description,
+			Template:    hcl,
+			Params:      params,
+			Fields:      plugins.Bar(params, "open", "high", "low", "close"),
+			Initialized: true,
+		},
+		sessions: plugins.NewSessions(params),
+		method:   method,
+	}
+	i.Reset()
+	return i
+}
+
+func pivotNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newPivot(pivotPluginID, pivotPluginName, pivotPluginDescription, pivotPluginHCL, opt.New(opts...), classicPivot)
+}
+
+func fibPivotNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newPivot(fibPivotPluginID, fibPivotPluginName, fibPivotPluginDescription, fibPivotPluginHCL, opt.New(opts...), fibonacciPivot)
+}
+
+func camarillaPivotNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newPivot(camarillaPivotPluginID, camarillaPivotPluginName, camarillaPivotPluginDescription, camarillaPivotPluginHCL, opt.New(opts...), camarillaPivot)
+}
+
+func woodiePivotNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newPivot(woodiePivotPluginID, woodiePivotPluginName, woodiePivotPluginDescription, woodiePivotPluginHCL, opt.New(opts...), woodiePivot)
+}
+
+func demarkPivotNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newPivot(demarkPivotPluginID, demarkPivotPluginName, demarkPivotPluginDescription, demarkPivotPluginHCL, opt.New(opts...), demarkPivot)
+}
+
+func classicPivot(s session) map[string]float64 {
+	return classicPivotFrom((s.High+s.Low+s.Close)/3, s)
+}
+
+// classicPivotFrom returns the classic levels around the pivot.
+func classicPivotFrom(pp float64, s session) map[string]float64 {
+	r := s.High - s.Low
+	return map[string]float64{
+		"pp": pp,
+		"r1": 2*pp - s.Low, "s1": 2*pp - s.High,
+		"r2": pp + r, "s2": pp - r,
+		"r3": s.High + 2*(pp-s.Low), "s3": s.Low - 2*(s.High-pp),
+	}
+}
+
+func fibonacciPivot(s session) map[string]float64 {
+	pp, r := (s.High+s.Low+s.Close)/3, s.High-s.Low
+	return map[string]float64{
+		"pp": pp,
+		"r1": pp + 0.382*r, "s1": pp - 0.382*r,
+		"r2": pp + 0.618*r, "s2": pp - 0.618*r,
+		"r3": pp + r, "s3": pp - r,
+	}
+}
+
+func camarillaPivot(s session) map[string]float64 {
+	r := 1.1 * (s.High - s.Low)
+	return map[string]float64{
+		"pp": (s.High + s.Low + s.Close) / 3,
+		"r1": s.Close + r/12, "s1": s.Close - r/12,
+		"r2": s.Close + r/6, "s2": s.Close - r/6,
+		"r3": s.Close + r/4, "s3": s.Close - r/4,
+		"r4": s.Close + r/2, "s4": s.Close - r/2,
+	}
+}
+
+func woodiePivot(s session) map[string]float64 {
+	return classicPivotFrom((s.High+s.Low+2*s.Close)/4, s)
+}
+
+func demarkPivot(s session) map[string]float64 {
+	x := s.High + s.Low + 2*s.Close
+	switch {
+	case s.Close < s.Open:
+		x = s.High + 2*s.Low + s.Close
+	case s.Close > s.Open:
+		x = 2*s.High + s.Low + s.Close
+	}
+	return map[string]float64{"pp": x / 4, "r1": x/2 - s.Low, "s1": x/2 - s.High}
+}
+
+func (i *pivot) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+func (i *pivot) Compute(input *series.Series) (output *series.Series) {
+	return plugins.Compute(i.ID(), i, input)
+}
+
+func (i *pivot) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the bar and returns the levels of its session, once a session
+// ended.
+func (i *pivot) Update(input *tick.Tick) (output *tick.Tick) {
+	values, ok := read(input, i.Fields)
+	if !ok {
+		return tick.New()
+	}
+	open, high, low, last := values[0], values[1], values[2], values[3]
+
+	s := &i.state
+	if key := i.sessions.Key(input); s.Count == 0 || key != s.Session {
+		if s.Count > 0 {
+			s.Levels = i.method(s.Current)
+		}
+		s.Session, s.Current = key, session{Open: open, High: high, Low: low, Close: last}
+	} else {
+		s.Current.High, s.Current.Low, s.Current.Close = max(s.Current.High, high), min(s.Current.Low, low), last
+	}
+	s.Count++
+	prevClose := s.PrevClose
+	s.PrevClose = last
+	if s.Levels == nil {
+		return tick.New()
+	}
+
+	fields := make(map[string]float64, len(s.Levels))
+	levels := make([]level, 0, len(s.Levels))
+	for name, price := range s.Levels {
+		fields[name] = price
+		levels = append(levels, level{Price: price, Strength: strength(name)})
+	}
+	output = plugins.Output(input, fields)
+	signal(output, levels, prevClose, high, low, last)
+	return output
+}
+
+// strength returns the strength of the level of the name.
+func strength(name string) sig.Strength {
+	switch {
+	case strings.HasSuffix(name, "1"):
+		return sig.WEAK
+	case name == "pp" || strings.HasSuffix(name, "2"):
+		return sig.MEDIUM
+	default:
+		return sig.STRONG
+	}
+}
+
+func (i *pivot) Reset() {
+	i.state = pivotState{}
+}
+
+// Warmup returns the bars before the first output at the least, the first
+// bar of the second session.
+func (i *pivot) Warmup() int {
+	return 2
+}
+
+func (i *pivot) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(i.state)
+}
+
+func (i *pivot) Restore(state []byte) error {
+	var s pivotState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.state = s
+	return nil
+}
+
+func init() {
+	indicators.Add(pivotPluginID, pivotNew, indicators.OTHER)
+	indicators.Add(fibPivotPluginID, fibPivotNew, indicators.OTHER)
+	indicators.Add(camarillaPivotPluginID, camarillaPivotNew, indicators.OTHER)
+	indicators.Add(woodiePivotPluginID, woodiePivotNew, indicators.OTHER)
+	indicators.Add(demarkPivotPluginID, demarkPivotNew, indicators.OTHER)
+}
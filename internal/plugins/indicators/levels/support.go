@@ -0,0 +1,215 @@
+package levels
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// The swing highs and lows of the last period bars, sorted by price, are
+// clustered while within tolerance of the mean of their cluster. Each
+// cluster of at least touches swings is a level at its mean.
+// Support = the highest level at or below the close
+// Resistance = the lowest level above the close
+const supportPluginID = "SRLEVELS"
+const supportPluginName = "Support and Resistance Levels"
+const supportPluginDescription = "Support and Resistance Levels are the prices the swing highs and lows of the period cluster at, nearest to the close."
+const supportPluginHCL = `
+indicator "srlevels" {
+  period = 100
+  bars = 2
+  tolerance = 0.005
+  touches = 2
+}
+`
+
+// support outputs the nearest support and resistance levels with their
+// touches, and the number of levels, from the bar the period is filled. The
+// support or the resistance is left out when the close has none. The closes
+// breaking or holding the levels found before the bar are signalled, STRONG
+// for the levels of more touches than the minimum and MEDIUM for the others.
+type support struct {
+	plugins.Plugin
+
+	Period    int     `hcl:"period,optional"`    // bars the swings are kept for
+	Bars      int     `hcl:"bars,optional"`      // bars on each side of a swing
+	Tolerance float64 `hcl:"tolerance,optional"` // relative distance of the swings of a level
+	Touches   int     `hcl:"touches,optional"`   // swings a level needs
+
+	state supportState
+}
+
+// supportState is the snapshot of a support.
+type supportState struct {
+	Count     int
+	Swings    swings
+	Points    []point // swings of the period, the oldest first
+	Levels    []cluster
+	PrevClose float64
+}
+
+// point is a swing high or low.
+type point struct {
+	Price float64
+	Bar   int
+}
+
+// cluster is a level and the swings at it.
+type cluster struct {
+	Price   float64
+	Touches int
+}
+
+func supportNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	i := &support{
+		Plugin: plugins.Plugin{
+			PID:         supportPluginID,
+			Title:       supportPluginName,
+			Summary:     supportPluginDescription,
+			Template:    supportPluginHCL,
+			Params:      params,
+			Fields:      plugins.Bar(params, "high", "low", "close"),
+			Initialized: true,
+		},
+		Period:    max(params.Period(100), 1),
+		Bars:      max(params.Int("bars", 2), 1),
+		Tolerance: params.Float("tolerance", 0.005),
+		Touches:   max(params.Int("touches", 2), 1),
+	}
+	if i.Tolerance < 0 || math.IsNaN(i.Tolerance) {
+		params.AddError(fmt.Errorf("invalid level tolerance: %v", i.Tolerance))
+		i.Tolerance = 0.005
+	}
+	i.Reset()
+	return i
+}
+
+func (i *support) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+func (i *support) Compute(input *series.Series) (output *series.Series) {
+	return plugins.Compute(i.ID(), i, input)
+}
+
+func (i *support) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the bar and returns the levels nearest to its close once the
+// period is filled.
+func (i *support) Update(input *tick.Tick) (output *tick.Tick) {
+	values, ok := read(input, i.Fields)
+	if !ok {
+		return tick.New()
+	}
+	high, low, last := values[0], values[1], values[2]
+
+	s := &i.state
+	s.Count++
+	before := make([]level, len(s.Levels))
+	for k, c := range s.Levels {
+		before[k] = level{Price: c.Price, Strength: i.strength(c)}
+	}
+
+	changed := false
+	swingHigh, swingLow := s.Swings.add(high, low)
+	for _, price := range []float64{swingHigh, swingLow} {
+		if !math.IsNaN(price) {
+			s.Points = append(s.Points, point{Price: price, Bar: s.Count - i.Bars})
+			changed = true
+		}
+	}
+	for len(s.Points) > 0 && s.Points[0].Bar <= s.Count-i.Period {
+		s.Points = s.Points[1:]
+		changed = true
+	}
+	if changed {
+		s.Levels = i.cluster()
+	}
+	prevClose := s.PrevClose
+	s.PrevClose = last
+	if s.Count < i.Warmup() {
+		return tick.New()
+	}
+
+	fields := map[string]float64{"levels": float64(len(s.Levels))}
+	for _, c := range s.Levels {
+		if c.Price <= last {
+			fields["support"], fields["support_touches"] = c.Price, float64(c.Touches)
+		} else if _, ok := fields["resistance"]; !ok {
+			fields["resistance"], fields["resistance_touches"] = c.Price, float64(c.Touches)
+		}
+	}
+	output = plugins.Output(input, fields)
+	signal(output, before, prevClose, high, low, last)
+	return output
+}
+
+// cluster returns the levels of the swings, the lowest first.
+func (i *support) cluster() (levels []cluster) {
+	prices := make([]float64, len(i.state.Points))
+	for k, p := range i.state.Points {
+		prices[k] = p.Price
+	}
+	slices.Sort(prices)
+
+	var sum float64
+	var n int
+	flush := func() {
+		if n >= i.Touches {
+			levels = append(levels, cluster{Price: sum / float64(n), Touches: n})
+		}
+		sum, n = 0, 0
+	}
+	for _, price := range prices {
+		if n > 0 && price-sum/float64(n) > i.Tolerance*math.Abs(sum/float64(n)) {
+			flush()
+		}
+		sum += price
+		n++
+	}
+	flush()
+	return levels
+}
+
+func (i *support) strength(c cluster) sig.Strength {
+	if c.Touches > i.Touches {
+		return sig.STRONG
+	}
+	return sig.MEDIUM
+}
+
+func (i *support) Reset() {
+	i.state = supportState{Swings: newSwings(i.Bars)}
+}
+
+func (i *support) Warmup() int {
+	return i.Period
+}
+
+func (i *support) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(i.state)
+}
+
+func (i *support) Restore(state []byte) error {
+	var s supportState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.state = s
+	return nil
+}
+
+func init() {
+	indicators.Add(supportPluginID, supportNew, indicators.OTHER)
+}
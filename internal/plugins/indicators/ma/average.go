@@ -0,0 +1,189 @@
+package ma
+
+import (
+	"encoding/gob"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/series"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// Moving average types, as selected with opt.WithMAType.
+const (
+	SMA   = "SMA"
+	EMA   = "EMA"
+	WMA   = "WMA"
+	DEMA  = "DEMA"
+	TEMA  = "TEMA"
+	TRIMA = "TRIMA"
+	KAMA  = "KAMA"
+	MAMA  = "MAMA"
+	T3    = "T3"
+)
+
+// Average is an incremental moving average. The types follow the TA-Lib
+// definitions, including how each one is seeded, and are shared by every
+// indicator that takes a moving average type. Averages are snapshotted
+// through this interface, so the package registers them with gob.
+type Average interface {
+	// Add adds a value and returns the average, NaN until Warmup values were added
+	Add(value float64) float64
+	Warmup() int
+}
+
+// NewAverage returns a moving average of the type over the period. MAMA uses
+// its default limits and ignores the period.
+func NewAverage(maType string, period int) (Average, error) {
+	switch strings.ToUpper(maType) {
+	case SMA, "":
+		return newSMA(period), nil
+	case EMA:
+		return newEMA(period, 2/(float64(period)+1)), nil
+	case WMA:
+		return newWMA(period), nil
+	case DEMA:
+		return newDEMA(period), nil
+	case TEMA:
+		return newTEMA(period), nil
+	case TRIMA:
+		return newTRIMA(period), nil
+	case KAMA:
+		return newKAMA(period), nil
+	case MAMA:
+		return newMAMA(0.5, 0.05), nil
+	case T3:
+		return newT3(period, 0.7), nil
+	}
+	return nil, fmt.Errorf("unknown moving average type %q", maType)
+}
+
+// NewEMA returns an exponential moving average with a custom smoothing factor,
+// seeded with the simple average of the first period values.
+func NewEMA(period int, alpha float64) Average {
+	return newEMA(period, alpha)
+}
+
+// Select returns the moving average of the type, an SMA after recording the
+// error on the options when the type is unknown.
+func Select(params internal.Options, maType string, period int) Average {
+	a, err := NewAverage(maType, period)
+	if err != nil {
+		params.AddError(err)
+		a, _ = NewAverage(SMA, period)
+	}
+	return a
+}
+
+func init() {
+	gob.Register(&smaKernel{})
+	gob.Register(&emaKernel{})
+	gob.Register(&wmaKernel{})
+	gob.Register(&demaKernel{})
+	gob.Register(&temaKernel{})
+	gob.Register(&trimaKernel{})
+	gob.Register(&kamaKernel{})
+	gob.Register(&mamaKernel{})
+	gob.Register(&t3Kernel{})
+}
+
+// average is the plugin of every moving average, an indicator over one input
+// field driven by an Average.
+type average struct {
+	plugins.Plugin
+
+	Period int    `hcl:"period,optional"` // period of the average
+	MAType string `hcl:"matype,optional"` // type of the average
+	Output string `hcl:"output,optional"` // output field name
+
+	new    func() Average
+	kernel Average
+}
+
+// averageState is the snapshot of an average.
+type averageState struct {
+	Kernel Average
+}
+
+func newAverage(id, name, description, hcl string, params internal.Options, new func(period int) (Average, error)) *average {
+	i := &average{
+		Plugin: plugins.Plugin{
+			PID:         id,
+			Title:       name,
+			Summary:     description,
+			Template:    hcl,
+			Params:      params,
+			Fields:      []string{params.Field("value")},
+			Initialized: true,
+		},
+		Period: plugins.Period(params, "period", 30),
+		MAType: id,
+		Output: params.Output(strings.ToLower(id)),
+	}
+
+	i.new = func() Average {
+		kernel, err := new(i.Period)
+		if err != nil {
+			params.AddError(err)
+			kernel = newSMA(i.Period)
+		}
+		return kernel
+	}
+	i.kernel = i.new()
+	return i
+}
+
+func (i *average) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+func (i *average) Compute(input *series.Series) (output *series.Series) {
+	return plugins.Compute(i.ID(), i, input)
+}
+
+func (i *average) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the input value and returns the average once warmed up.
+func (i *average) Update(input *tick.Tick) (output *tick.Tick) {
+	value := input.GetField(i.Fields[0])
+	if math.IsNaN(value) {
+		return tick.New()
+	}
+
+	value = i.kernel.Add(value)
+	if math.IsNaN(value) {
+		return tick.New()
+	}
+
+	fields := map[string]float64{i.Output: value}
+	if mama, ok := i.kernel.(*mamaKernel); ok {
+		fields["fama"] = mama.Fama
+	}
+	return plugins.Output(input, fields)
+}
+
+func (i *average) Reset() {
+	i.kernel = i.new()
+}
+
+func (i *average) Warmup() int {
+	return i.kernel.Warmup()
+}
+
+func (i *average) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(averageState{Kernel: i.kernel})
+}
+
+func (i *average) Restore(state []byte) error {
+	var s averageState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.kernel = s.Kernel
+	return nil
+}
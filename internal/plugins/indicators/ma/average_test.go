@@ -0,0 +1,216 @@
+package ma
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+)
+
+// The references below follow the TA-Lib definitions directly, as full
+// recomputations over the input instead of incremental updates.
+
+// weighted returns the weighted averages of the windows of len(weights)
+// values, the last weight applying to the newest value.
+func weighted(xs, weights []float64) []float64 {
+	var out []float64
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	for i := len(weights) - 1; i < len(xs); i++ {
+		sum := 0.0
+		for j, w := range weights {
+			sum += w * xs[i-len(weights)+1+j]
+		}
+		out = append(out, sum/total)
+	}
+	return out
+}
+
+func refWMA(xs []float64, n int) []float64 {
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = float64(i + 1)
+	}
+	return weighted(xs, weights)
+}
+
+func refTRIMA(xs []float64, n int) []float64 {
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = float64(min(i+1, n-i))
+		if n%2 == 0 && i >= n/2 {
+			weights[i] = float64(n - i)
+		}
+	}
+	return weighted(xs, weights)
+}
+
+func refDEMA(xs []float64, n int) []float64 {
+	e1 := indicatortest.EMA(xs, n)
+	e2 := indicatortest.EMA(e1, n)
+	e1 = e1[len(e1)-len(e2):]
+	out := make([]float64, len(e2))
+	for i := range out {
+		out[i] = 2*e1[i] - e2[i]
+	}
+	return out
+}
+
+func refTEMA(xs []float64, n int) []float64 {
+	e1 := indicatortest.EMA(xs, n)
+	e2 := indicatortest.EMA(e1, n)
+	e3 := indicatortest.EMA(e2, n)
+	e1, e2 = e1[len(e1)-len(e3):], e2[len(e2)-len(e3):]
+	out := make([]float64, len(e3))
+	for i := range out {
+		out[i] = 3*e1[i] - 3*e2[i] + e3[i]
+	}
+	return out
+}
+
+func refT3(xs []float64, n int, v float64) []float64 {
+	e := make([][]float64, 7)
+	e[0] = xs
+	for i := 1; i <= 6; i++ {
+		e[i] = indicatortest.EMA(e[i-1], n)
+	}
+	size := len(e[6])
+	at := func(i, j int) float64 { return e[i][len(e[i])-size+j] }
+	out := make([]float64, size)
+	for j := range out {
+		out[j] = -v*v*v*at(6, j) + (3*v*v+3*v*v*v)*at(5, j) +
+			(-6*v*v-3*v-3*v*v*v)*at(4, j) + (1+3*v+v*v*v+3*v*v)*at(3, j)
+	}
+	return out
+}
+
+func refKAMA(xs []float64, n int) []float64 {
+	fast, slow := 2.0/3, 2.0/31
+	var out []float64
+	prev := xs[n-1]
+	for t := n; t < len(xs); t++ {
+		noise := 0.0
+		for j := t - n + 1; j <= t; j++ {
+			noise += math.Abs(xs[j] - xs[j-1])
+		}
+		er := 1.0
+		if noise != 0 {
+			er = math.Min(math.Abs(xs[t]-xs[t-n])/noise, 1)
+		}
+		sc := math.Pow(er*(fast-slow)+slow, 2)
+		prev += sc * (xs[t] - prev)
+		out = append(out, prev)
+	}
+	return out
+}
+
+func TestAverages(t *testing.T) {
+	xs := indicatortest.RandomWalk(200, 7)
+	input := indicatortest.Values(xs...)
+
+	for _, n := range []int{1, 2, 5, 10, 14} {
+		for _, test := range []struct {
+			id   string
+			new  func(...internal.PluginOptions) internal.Plugin
+			want []float64
+		}{
+			{SMA, smaNew, indicatortest.SMA(xs, n)},
+			{EMA, emaNew, indicatortest.EMA(xs, n)},
+			{WMA, wmaNew, refWMA(xs, n)},
+			{DEMA, demaNew, refDEMA(xs, n)},
+			{TEMA, temaNew, refTEMA(xs, n)},
+			{TRIMA, trimaNew, refTRIMA(xs, n)},
+			{KAMA, kamaNew, refKAMA(xs, n)},
+			{T3, t3New, refT3(xs, n, 0.7)},
+		} {
+			i := test.new(opt.WithPeriod(n)).(internal.Indicator)
+			output := i.Compute(input)
+			name := fmt.Sprintf("%s(%d)", test.id, n)
+			indicatortest.Near(t, name, indicatortest.Fields(output, strings.ToLower(test.id)), test.want)
+			if warmup := len(xs) - len(test.want) + 1; i.Warmup() != warmup {
+				t.Errorf("%s: Warmup() = %d, want %d", name, i.Warmup(), warmup)
+			}
+		}
+	}
+}
+
+func TestMAMA(t *testing.T) {
+	xs := make([]float64, 60)
+	for i := range xs {
+		xs[i] = 42
+	}
+	i := mamaNew().(internal.Indicator)
+	output := i.Compute(indicatortest.Values(xs...))
+
+	if output.Len() != len(xs)-32 || i.Warmup() != 33 {
+		t.Fatalf("got %d outputs with Warmup() %d, want %d and 33", output.Len(), i.Warmup(), len(xs)-32)
+	}
+	// the averages start at zero and converge at the fast limit on a flat
+	// input, the FAMA at half of it
+	fama := 0.0
+	for j := range output.Len() {
+		out := output.At(j)
+		if math.Abs(out.GetField("mama")-42) > 1e-3 {
+			t.Errorf("output %d: mama %v, want 42", j, out.GetField("mama"))
+		}
+		if out.GetField("fama") <= fama || out.GetField("fama") > 42 {
+			t.Errorf("output %d: fama %v after %v, want it rising to 42", j, out.GetField("fama"), fama)
+		}
+		fama = out.GetField("fama")
+	}
+
+	// the first output is at tick 33 on any input
+	output = mamaNew().(internal.Indicator).Compute(indicatortest.Values(indicatortest.RandomWalk(40, 7)...))
+	if output.Len() != 8 {
+		t.Errorf("got %d outputs over 40 ticks, want 8", output.Len())
+	}
+}
+
+func TestMAType(t *testing.T) {
+	input := indicatortest.Values(indicatortest.RandomWalk(100, 7)...)
+	for _, maType := range []string{SMA, EMA, WMA, DEMA, TEMA, TRIMA, KAMA, MAMA, T3} {
+		ma := maNew(opt.WithPeriod(5), opt.WithMAType(strings.ToLower(maType))).(internal.Indicator)
+		want := indicator(maType, opt.WithPeriod(5)).Compute(input)
+		indicatortest.Near(t, maType, indicatortest.Fields(ma.Compute(input), "ma"), indicatortest.Fields(want, strings.ToLower(maType)))
+	}
+
+	ma := maNew(opt.WithMAType("unknown"))
+	if err := ma.Init(); err == nil {
+		t.Error("Init() of an unknown type succeeded")
+	}
+	if err := maNew(opt.WithPeriod(0), opt.WithMAType("ema")).Init(); err == nil {
+		t.Error("Init() with a period of 0 succeeded")
+	}
+	for maType, new := range constructors {
+		if err := new(opt.WithPeriod(-1)).Init(); err == nil {
+			t.Errorf("%s: Init() with a period of -1 succeeded", maType)
+		}
+	}
+}
+
+// constructors are the averages by MA type.
+var constructors = map[string]func(...internal.PluginOptions) internal.Plugin{
+	SMA: smaNew, EMA: emaNew, WMA: wmaNew, DEMA: demaNew, TEMA: temaNew,
+	TRIMA: trimaNew, KAMA: kamaNew, MAMA: mamaNew, T3: t3New,
+}
+
+func indicator(maType string, opts ...internal.PluginOptions) internal.Indicator {
+	return constructors[maType](opts...).(internal.Indicator)
+}
+
+func TestAverageSnapshot(t *testing.T) {
+	input := indicatortest.Values(indicatortest.RandomWalk(80, 7)...)
+	for _, maType := range []string{SMA, EMA, WMA, DEMA, TEMA, TRIMA, KAMA, MAMA, T3} {
+		t.Run(maType, func(t *testing.T) {
+			indicatortest.SnapshotReplay(t, func() internal.Indicator {
+				return indicator(maType, opt.WithPeriod(4))
+			}, input, 40)
+		})
+	}
+}
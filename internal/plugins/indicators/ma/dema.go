@@ -0,0 +1,54 @@
+package ma
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// DEMA = 2 * EMA - EMA(EMA)
+const demaPluginID = "DEMA"
+const demaPluginName = "Double Exponential Moving Average"
+const demaPluginDescription = "Double Exponential Moving Average reduces the lag of an EMA by subtracting the EMA of the EMA."
+const demaPluginHCL = `
+indicator "dema" {
+  period = 30
+}
+`
+
+type demaKernel struct {
+	E1, E2 *emaKernel
+}
+
+func newDEMA(period int) *demaKernel {
+	alpha := 2 / (float64(period) + 1)
+	return &demaKernel{E1: newEMA(period, alpha), E2: newEMA(period, alpha)}
+}
+
+func (k *demaKernel) Add(value float64) float64 {
+	e1 := k.E1.Add(value)
+	if math.IsNaN(e1) {
+		return e1
+	}
+	e2 := k.E2.Add(e1)
+	if math.IsNaN(e2) {
+		return e2
+	}
+	return 2*e1 - e2
+}
+
+func (k *demaKernel) Warmup() int {
+	return 2*k.E1.Period - 1
+}
+
+func demaNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newAverage(demaPluginID, demaPluginName, demaPluginDescription, demaPluginHCL, opt.New(opts...), func(period int) (Average, error) {
+		return newDEMA(period), nil
+	})
+}
+
+func init() {
+	indicators.Add(demaPluginID, demaNew, indicators.TREND)
+}
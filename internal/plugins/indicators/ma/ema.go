@@ -5,10 +5,7 @@ import (
 
 	"github.com/rangertaha/gotal/internal"
 	"github.com/rangertaha/gotal/internal/opt"
-	"github.com/rangertaha/gotal/internal/plugins"
 	"github.com/rangertaha/gotal/internal/plugins/indicators"
-	"github.com/rangertaha/gotal/internal/series"
-	"github.com/rangertaha/gotal/internal/tick"
 )
 
 // EMAt​=α⋅Pricet​+(1−α)⋅EMAt−1
@@ -22,63 +19,47 @@ const emaPluginName = "Exponential Moving Average"
 const emaPluginDescription = "Exponential Moving Average is a technical indicator that smooths out price data by giving more weight to recent prices."
 const emaPluginHCL = `
 indicator "ema" {
-  period = 14
-  alpha = 0.1
+  period = 30
+  alpha = 0.0645
 }
 `
 
-type ema struct {
-	plugins.Plugin
-
-	// EMA parameters
-	Period int     `hcl:"period"`         // period to compute the EMA
-	Alpha  float64 `hcl:"alpha,optional"` // alpha to compute the EMA
+// emaKernel is seeded with the simple average of the first Period values.
+type emaKernel struct {
+	Period int
+	Alpha  float64
+	Count  int
+	Sum    float64
+	Value  float64
 }
 
-func emaNew(opts ...internal.PluginOptions) internal.Plugin {
-	params := opt.New(opts...)
-
-	e := &ema{
-		Plugin: plugins.Plugin{
-			PID:         emaPluginID,
-			Title:       emaPluginName,
-			Summary:     emaPluginDescription,
-			Template:    emaPluginHCL,
-			Params:      params,
-			Series:      params.Series("series", nil),
-			Fields:      []string{params.String("input", "value")}, // input field names to compute the EMA
-			Initialized: false,
-		},
-		Period: params.Int("period", math.NaN()),
-		Alpha:  params.Float("alpha", 2/(float64(params.Int("period", math.NaN()))+1)),
-	}
-
-	// e.Init(params...)
-
-
-	return e
+func newEMA(period int, alpha float64) *emaKernel {
+	return &emaKernel{Period: period, Alpha: alpha}
 }
 
-func (i *ema) Init(opts ...internal.PluginOptions) error {
-	// i.Params = opt.New(opts...)
-
-	// i.Initialized = false
-	// i.Period = i.Params.Int("period", math.NaN())
-	// i.Alpha = i.Params.Float("alpha", 2/(float64(i.Period)+1))
-	// i.Series = i.Params.Series("series", nil)
-	// i.Fields = []string{i.Params.String("input", "value")} // input field names to compute the EMA
-
-	return nil
+func (k *emaKernel) Add(value float64) float64 {
+	k.Count++
+	switch {
+	case k.Count < k.Period:
+		k.Sum += value
+		return math.NaN()
+	case k.Count == k.Period:
+		k.Value = (k.Sum + value) / float64(k.Period)
+	default:
+		k.Value = (value-k.Value)*k.Alpha + k.Value
+	}
+	return k.Value
 }
 
-func (i *ema) Compute(input *series.Series) (output *series.Series) {
-	output = series.New(i.ID())
-	return
+func (k *emaKernel) Warmup() int {
+	return k.Period
 }
 
-func (i *ema) Process(input *tick.Tick) (output *tick.Tick) {
-	i.Series.Push(input)
-	return i.Series.Pop()
+func emaNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	return newAverage(emaPluginID, emaPluginName, emaPluginDescription, emaPluginHCL, params, func(period int) (Average, error) {
+		return newEMA(period, params.Float("alpha", 2/(float64(period)+1))), nil
+	})
 }
 
 func init() {
@@ -0,0 +1,34 @@
+package ma
+
+import (
+	"testing"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+)
+
+func TestEMA(t *testing.T) {
+	ema := emaNew(opt.WithPeriod(3)).(internal.Indicator)
+	output := ema.Compute(indicatortest.Values(1, 2, 3, 4, 5, 6))
+
+	want := []float64{2, 3, 4, 5}
+	if output.Len() != len(want) {
+		t.Fatalf("got %d outputs, want %d", output.Len(), len(want))
+	}
+	for i, w := range want {
+		if got := output.At(i).GetField("ema"); got != w {
+			t.Errorf("ema[%d] = %v, want %v", i, got, w)
+		}
+	}
+	if ema.Warmup() != 3 {
+		t.Errorf("Warmup() = %d, want 3", ema.Warmup())
+	}
+}
+
+func TestEMABatchMatchesLive(t *testing.T) {
+	input := indicatortest.Values(3.1, 2.7, 9.4, 1.2, 5.5, 6.25, 7.125, 0.3, 8.8, 4.4)
+	indicatortest.SnapshotReplay(t, func() internal.Indicator {
+		return emaNew(opt.WithPeriod(4)).(internal.Indicator)
+	}, input, 5)
+}
@@ -0,0 +1,83 @@
+package ma
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// ER = |P - P[n]| / sum(|P - P[1]|, n)
+// SC = (ER * (2/(2+1) - 2/(30+1)) + 2/(30+1))^2
+// KAMA = KAMA[1] + SC * (P - KAMA[1]), seeded with P[1]
+const kamaPluginID = "KAMA"
+const kamaPluginName = "Kaufman Adaptive Moving Average"
+const kamaPluginDescription = "Kaufman Adaptive Moving Average follows prices closely when they trend and smooths them when they are noisy."
+const kamaPluginHCL = `
+indicator "kama" {
+  period = 30
+}
+`
+
+const (
+	kamaFastest = 2.0 / (2 + 1)
+	kamaSlowest = 2.0 / (30 + 1)
+)
+
+// kamaKernel keeps the last Period+2 values and the sum of the absolute
+// changes over the last Period steps.
+type kamaKernel struct {
+	Period int
+	Window plugins.Ring
+	Count  int
+	Noise  float64
+	Value  float64
+}
+
+func newKAMA(period int) *kamaKernel {
+	return &kamaKernel{Period: period, Window: plugins.NewRing(period + 2)}
+}
+
+func (k *kamaKernel) Add(value float64) float64 {
+	k.Window.Push(value)
+	k.Count++
+
+	w, n := &k.Window, k.Period
+	if k.Count > 1 {
+		k.Noise += math.Abs(w.At(0) - w.At(1))
+	}
+	switch {
+	case k.Count <= n:
+		return math.NaN()
+	case k.Count == n+1:
+		k.Value = w.At(1)
+	default:
+		k.Noise -= math.Abs(w.At(n+1) - w.At(n))
+	}
+
+	change := w.At(0) - w.At(n)
+	er := 1.0
+	if k.Noise > change && !(k.Noise > -1e-8 && k.Noise < 1e-8) {
+		er = math.Abs(change / k.Noise)
+	}
+	sc := er*(kamaFastest-kamaSlowest) + kamaSlowest
+	sc *= sc
+	k.Value = (value-k.Value)*sc + k.Value
+	return k.Value
+}
+
+func (k *kamaKernel) Warmup() int {
+	return k.Period + 1
+}
+
+func kamaNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newAverage(kamaPluginID, kamaPluginName, kamaPluginDescription, kamaPluginHCL, opt.New(opts...), func(period int) (Average, error) {
+		return newKAMA(period), nil
+	})
+}
+
+func init() {
+	indicators.Add(kamaPluginID, kamaNew, indicators.TREND)
+}
@@ -0,0 +1,35 @@
+package ma
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// MA is the moving average of the type selected with opt.WithMAType, SMA by default.
+const maPluginID = "MA"
+const maPluginName = "Moving Average"
+const maPluginDescription = "Moving Average of a selectable type: SMA, EMA, WMA, DEMA, TEMA, TRIMA, KAMA, MAMA or T3."
+const maPluginHCL = `
+indicator "ma" {
+  period = 30
+  matype = "SMA"
+}
+`
+
+func maNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	maType := strings.ToUpper(params.MAType(SMA))
+
+	i := newAverage(maPluginID, maPluginName, maPluginDescription, maPluginHCL, params, func(period int) (Average, error) {
+		return NewAverage(maType, period)
+	})
+	i.MAType = maType
+	return i
+}
+
+func init() {
+	indicators.Add(maPluginID, maNew, indicators.TREND)
+}
@@ -0,0 +1,82 @@
+package ma
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/cycle"
+)
+
+// MAMA = a * P + (1 - a) * MAMA[1], FAMA = a/2 * MAMA + (1 - a/2) * FAMA[1]
+// where a is the fast limit over the phase change of the Hilbert transform,
+// clamped to the slow limit.
+const mamaPluginID = "MAMA"
+const mamaPluginName = "MESA Adaptive Moving Average"
+const mamaPluginDescription = "MESA Adaptive Moving Average adapts to the dominant cycle measured by a Hilbert transform, with the following FAMA."
+const mamaPluginHCL = `
+indicator "mama" {
+  fast = 0.5
+  slow = 0.05
+}
+`
+
+const rad2Deg = 180.0 / math.Pi
+
+// mamaKernel adapts the smoothing to the rate of change of the phase measured
+// by the dominant cycle.
+type mamaKernel struct {
+	FastLimit, SlowLimit float64
+	Cycle                *cycle.DominantCycle
+	PrevPhase            float64
+
+	Mama, Fama float64
+}
+
+func newMAMA(fastLimit, slowLimit float64) *mamaKernel {
+	// the transform starts after 12 prices as in TA-Lib
+	return &mamaKernel{FastLimit: fastLimit, SlowLimit: slowLimit, Cycle: cycle.NewDominantCycle(12)}
+}
+
+func (k *mamaKernel) Add(value float64) float64 {
+	c := k.Cycle
+	if !c.Add(value) {
+		return math.NaN()
+	}
+
+	phase := 0.0
+	if c.InPhase != 0 {
+		phase = math.Atan(c.Quadrature/c.InPhase) * rad2Deg
+	}
+	delta := math.Max(k.PrevPhase-phase, 1)
+	k.PrevPhase = phase
+
+	alpha := k.FastLimit
+	if delta > 1 {
+		alpha = math.Max(k.FastLimit/delta, k.SlowLimit)
+	}
+	k.Mama = alpha*value + (1-alpha)*k.Mama
+	alpha *= 0.5
+	k.Fama = alpha*k.Mama + (1-alpha)*k.Fama
+
+	if c.Count < k.Warmup() {
+		return math.NaN()
+	}
+	return k.Mama
+}
+
+func (k *mamaKernel) Warmup() int {
+	return 33
+}
+
+func mamaNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	return newAverage(mamaPluginID, mamaPluginName, mamaPluginDescription, mamaPluginHCL, params, func(int) (Average, error) {
+		return newMAMA(params.Float("fast", 0.5), params.Float("slow", 0.05)), nil
+	})
+}
+
+func init() {
+	indicators.Add(mamaPluginID, mamaNew, indicators.TREND)
+}
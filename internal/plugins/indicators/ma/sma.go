@@ -0,0 +1,56 @@
+package ma
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// SMA = (P1 + P2 + ... + Pn) / n
+const smaPluginID = "SMA"
+const smaPluginName = "Simple Moving Average"
+const smaPluginDescription = "Simple Moving Average is the unweighted mean of the last n values."
+const smaPluginHCL = `
+indicator "sma" {
+  period = 30
+}
+`
+
+type smaKernel struct {
+	Period int
+	Window plugins.Ring
+	Sum    float64
+}
+
+func newSMA(period int) *smaKernel {
+	return &smaKernel{Period: period, Window: plugins.NewRing(period)}
+}
+
+func (k *smaKernel) Add(value float64) float64 {
+	if k.Window.Len == k.Period {
+		k.Sum -= k.Window.At(k.Period - 1)
+	}
+	k.Window.Push(value)
+	k.Sum += value
+	if k.Window.Len < k.Period {
+		return math.NaN()
+	}
+	return k.Sum / float64(k.Period)
+}
+
+func (k *smaKernel) Warmup() int {
+	return k.Period
+}
+
+func smaNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newAverage(smaPluginID, smaPluginName, smaPluginDescription, smaPluginHCL, opt.New(opts...), func(period int) (Average, error) {
+		return newSMA(period), nil
+	})
+}
+
+func init() {
+	indicators.Add(smaPluginID, smaNew, indicators.TREND)
+}
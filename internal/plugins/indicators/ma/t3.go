@@ -0,0 +1,68 @@
+package ma
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// T3 = c1*e6 + c2*e5 + c3*e4 + c4*e3, where e1..e6 are chained EMAs and
+// c1 = -v^3, c2 = 3v^2 + 3v^3, c3 = -6v^2 - 3v - 3v^3, c4 = 1 + 3v + v^3 + 3v^2
+const t3PluginID = "T3"
+const t3PluginName = "Triple Exponential Moving Average (T3)"
+const t3PluginDescription = "Tillson T3 is a smooth, low lag average of six chained EMAs weighted by the volume factor."
+const t3PluginHCL = `
+indicator "t3" {
+  period = 5
+  vfactor = 0.7
+}
+`
+
+type t3Kernel struct {
+	E              [6]*emaKernel
+	C1, C2, C3, C4 float64
+}
+
+func newT3(period int, vfactor float64) *t3Kernel {
+	v, alpha := vfactor, 2/(float64(period)+1)
+	k := &t3Kernel{
+		C1: -v * v * v,
+		C2: 3*v*v + 3*v*v*v,
+		C3: -6*v*v - 3*v - 3*v*v*v,
+		C4: 1 + 3*v + v*v*v + 3*v*v,
+	}
+	for i := range k.E {
+		k.E[i] = newEMA(period, alpha)
+	}
+	return k
+}
+
+func (k *t3Kernel) Add(value float64) float64 {
+	var e [6]float64
+	for i, ema := range k.E {
+		if e[i] = ema.Add(value); math.IsNaN(e[i]) {
+			return e[i]
+		}
+		value = e[i]
+	}
+	return k.C1*e[5] + k.C2*e[4] + k.C3*e[3] + k.C4*e[2]
+}
+
+func (k *t3Kernel) Warmup() int {
+	return 6*k.E[0].Period - 5
+}
+
+func t3New(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	params.Set("period", plugins.Period(params, "period", 5))
+	return newAverage(t3PluginID, t3PluginName, t3PluginDescription, t3PluginHCL, params, func(period int) (Average, error) {
+		return newT3(period, params.Float("vfactor", 0.7)), nil
+	})
+}
+
+func init() {
+	indicators.Add(t3PluginID, t3New, indicators.TREND)
+}
@@ -0,0 +1,222 @@
+package ma
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+)
+
+// talibAverages are the TA-Lib outputs of the averages over the closes, with
+// the default period when it is 0.
+var talibAverages = []struct {
+	id     string
+	period int
+	field  string
+	want   []float64
+}{
+	{SMA, 10, "sma", []float64{
+		98.29300000, 98.25700000, 98.40300000, 98.83100000, 99.15000000,
+		99.52700000, 99.93400000, 100.38800000, 100.84000000, 101.16800000,
+		101.54600000, 101.91800000, 102.06700000, 102.06400000, 102.19400000,
+		102.37700000, 102.65300000, 102.90700000, 103.05900000, 103.31700000,
+		103.38500000, 103.45900000, 103.57400000, 103.53500000, 103.38600000,
+		103.10700000, 102.82800000, 102.48400000, 102.33500000, 102.08000000,
+		101.96500000, 101.80500000, 101.66000000, 101.58300000, 101.62100000,
+		101.62700000, 101.73400000, 101.74100000, 101.78600000, 101.81600000,
+		101.87100000, 101.97600000, 102.13300000, 102.31300000, 102.26200000,
+		102.27800000, 102.23600000, 102.18200000, 102.20400000, 102.20300000,
+		102.17700000, 102.17600000, 102.13000000, 101.94600000, 101.87800000,
+		101.86100000, 101.75500000, 101.85900000, 101.73400000, 101.59000000,
+		101.36200000, 101.15700000, 100.76000000, 100.56000000, 100.29900000,
+		99.98700000, 99.78200000, 99.50100000, 99.32400000, 99.20400000,
+		99.16800000, 99.01600000, 99.09100000, 99.11000000, 99.22000000,
+		99.26700000, 99.32100000, 99.35300000, 99.38900000, 99.44900000,
+		99.50900000, 99.51400000, 99.53600000, 99.55500000, 99.61900000,
+		99.88200000, 100.08300000, 100.28400000, 100.58300000, 100.88500000,
+		101.26700000,
+	}},
+	{EMA, 10, "ema", []float64{
+		98.29300000, 98.31427273, 98.66258678, 99.33848009, 99.84966553,
+		100.34427180, 100.57985874, 100.93079352, 101.06883106, 101.18177087,
+		101.36508525, 101.50416066, 101.54340418, 101.69005796, 102.01004743,
+		102.44458426, 102.80011439, 103.20918450, 103.20933277, 103.40218136,
+		103.30542111, 103.22625364, 103.16148025, 102.94302930, 102.76429670,
+		102.55442457, 102.38271101, 102.24221810, 102.14726935, 102.06958402,
+		102.00602329, 101.87220087, 101.78998253, 101.68089480, 101.80073211,
+		101.77696263, 101.94115125, 101.89366920, 101.94391116, 101.95774550,
+		102.01451904, 102.07006104, 102.23732267, 102.37417309, 102.27523253,
+		102.19428116, 102.20623004, 102.01237003, 102.08103003, 102.06811548,
+		102.05754903, 102.10344920, 102.18100389, 101.99354864, 101.84017616,
+		101.80741686, 101.69697743, 101.78479972, 101.66756340, 101.46800642,
+		101.15200525, 100.98982248, 100.54803657, 100.29384811, 99.97496663,
+		99.71406361, 99.61150659, 99.56759630, 99.53166970, 99.50227521,
+		99.47822517, 99.34400241, 99.33782016, 99.33821649, 99.39308622,
+		99.32343418, 99.39008251, 99.44461296, 99.49650152, 99.58259215,
+		99.65302994, 99.49611541, 99.50227624, 99.50731692, 99.64780476,
+		100.01002207, 100.31729079, 100.56869246, 100.95983929, 101.32895942,
+		101.77642134,
+	}},
+	{WMA, 10, "wma", []float64{
+		98.16018182, 98.18145455, 98.54018182, 99.26327273, 99.86672727,
+		100.48854545, 100.87272727, 101.34109091, 101.57781818, 101.73236364,
+		101.91818182, 102.02436364, 101.98836364, 102.03981818, 102.29181818,
+		102.69290909, 103.06072727, 103.49654545, 103.55163636, 103.77181818,
+		103.69054545, 103.59690909, 103.48981818, 103.19636364, 102.91000000,
+		102.58709091, 102.31490909, 102.09345455, 101.95454545, 101.84272727,
+		101.77727273, 101.65090909, 101.58090909, 101.49545455, 101.63309091,
+		101.64200000, 101.83345455, 101.82363636, 101.90163636, 101.94418182,
+		102.02672727, 102.10836364, 102.29272727, 102.44854545, 102.36072727,
+		102.28218182, 102.27890909, 102.07963636, 102.11745455, 102.08218182,
+		102.04709091, 102.07127273, 102.13563636, 101.95745455, 101.81272727,
+		101.77309091, 101.65290909, 101.73018182, 101.59945455, 101.38781818,
+		101.04963636, 100.84927273, 100.37709091, 100.08436364, 99.71709091,
+		99.39727273, 99.24509091, 99.17018182, 99.14636364, 99.15472727,
+		99.18490909, 99.10709091, 99.16054545, 99.20581818, 99.30218182,
+		99.26400000, 99.34090909, 99.40800000, 99.47654545, 99.58218182,
+		99.67690909, 99.54618182, 99.54909091, 99.54800000, 99.67981818,
+		100.04727273, 100.37781818, 100.67181818, 101.11472727, 101.55236364,
+		102.08054545,
+	}},
+	{DEMA, 10, "dema", []float64{
+		102.50718862, 102.59950246, 102.62792735, 102.49495798, 102.58859145,
+		103.00702074, 103.61581983, 104.04928634, 104.56592800, 104.31951695,
+		104.46829908, 104.09853177, 103.81038897, 103.58641275, 103.11196874,
+		102.75628411, 102.37615526, 102.09636139, 101.89298330, 101.78384646,
+		101.70867728, 101.65873172, 101.47856216, 101.40064494, 101.27309226,
+		101.56512420, 101.56474477, 101.90185459, 101.82266844, 101.92692670,
+		101.95516812, 102.05886136, 102.15178456, 102.44103779, 102.65281763,
+		102.42226306, 102.24834593, 102.26024121, 101.89794825, 102.04358857,
+		102.02691510, 102.01519435, 102.10635007, 102.24683117, 101.89403484,
+		101.63326920, 101.61132628, 101.44618015, 101.65145654, 101.46254382,
+		101.13698923, 100.62262660, 100.42399949, 99.72362930, 99.41136068,
+		98.99202844, 98.69637534, 98.69494226, 98.78175343, 98.85931286,
+		98.92811503, 98.98878045, 98.83372902, 98.91526553, 98.99281426,
+		99.15537781, 99.07195745, 99.23885927, 99.36550068, 99.47422756,
+		99.63480579, 99.75338111, 99.44983629, 99.46945219, 99.48458508,
+		99.74415057, 100.38521009, 100.87566447, 101.23123594, 101.82194953,
+		102.33632972, 102.96673862,
+	}},
+	{TEMA, 10, "tema", []float64{
+		104.28452701, 104.40361657, 103.82224030, 103.41335250, 103.13130787,
+		102.53016134, 102.13548095, 101.72892444, 101.47837956, 101.33591029,
+		101.31645100, 101.32832149, 101.35867122, 101.19513772, 101.17227132,
+		101.07113343, 101.54077166, 101.56395729, 102.04269127, 101.91195874,
+		102.04417754, 102.06288824, 102.18538485, 102.28588840, 102.65057043,
+		102.88555931, 102.50500387, 102.23998006, 102.25335255, 101.75450331,
+		101.98920842, 101.97934678, 101.97533039, 102.11076136, 102.30192565,
+		101.80383308, 101.47160063, 101.48790177, 101.30043643, 101.62831049,
+		101.38496181, 100.97042409, 100.32405028, 100.14989169, 99.28779032,
+		99.00724502, 98.57920137, 98.33017586, 98.47806227, 98.71126009,
+		98.89448870, 99.03723798, 99.14737551, 98.94644697, 99.07925922,
+		99.19011559, 99.40491929, 99.26486276, 99.47871648, 99.62074737,
+		99.72956983, 99.90466660, 100.01356157, 99.54274098, 99.55647381,
+		99.56404185, 99.90658781, 100.74625691, 101.32094561, 101.68078670,
+		102.35304569, 102.88971209, 103.56918990,
+	}},
+	{TRIMA, 10, "trima", []float64{
+		98.23066667, 98.12966667, 98.14400000, 98.35866667, 98.72900000,
+		99.25633333, 99.90433333, 100.60033333, 101.19800000, 101.60666667,
+		101.87800000, 102.02700000, 102.04200000, 102.00300000, 102.04566667,
+		102.17733333, 102.39733333, 102.71200000, 103.08166667, 103.45666667,
+		103.72200000, 103.86266667, 103.85500000, 103.69466667, 103.42866667,
+		103.09333333, 102.76700000, 102.44966667, 102.19700000, 101.97800000,
+		101.83966667, 101.73200000, 101.66000000, 101.61233333, 101.59333333,
+		101.58066667, 101.61133333, 101.66200000, 101.75166667, 101.84833333,
+		101.94433333, 102.03000000, 102.11233333, 102.21333333, 102.27533333,
+		102.33333333, 102.36933333, 102.35566667, 102.27833333, 102.17966667,
+		102.09333333, 102.02333333, 102.00166667, 102.00033333, 101.99033333,
+		101.96266667, 101.89200000, 101.80800000, 101.67733333, 101.56866667,
+		101.42433333, 101.26033333, 101.01266667, 100.71033333, 100.34066667,
+		99.95066667, 99.58800000, 99.28366667, 99.10733333, 99.02500000,
+		99.03800000, 99.07700000, 99.15100000, 99.19700000, 99.24466667,
+		99.25266667, 99.26466667, 99.30300000, 99.35633333, 99.42166667,
+		99.51000000, 99.58033333, 99.61366667, 99.62766667, 99.63900000,
+		99.69500000, 99.81600000, 100.03933333, 100.37433333, 100.80633333,
+		101.29966667,
+	}},
+	{KAMA, 10, "kama", []float64{
+		98.41000000, 98.47361567, 98.98584857, 99.27774109, 99.65464792,
+		99.92827277, 100.31624747, 100.52037335, 100.65345403, 100.85103315,
+		101.00922831, 101.03907626, 101.04517823, 101.14223076, 101.33029040,
+		101.76367369, 102.19728062, 102.24347842, 102.40268329, 102.40887076,
+		102.41550415, 102.42646188, 102.42232223, 102.40451375, 102.31532414,
+		102.23614667, 102.11653392, 102.08113717, 101.94283393, 101.87044256,
+		101.65867094, 101.59714562, 101.52397156, 101.54441869, 101.54517504,
+		101.60806904, 101.60845607, 101.61616030, 101.62008642, 101.63010993,
+		101.65430821, 101.72573590, 101.81368799, 101.81392628, 101.81404152,
+		101.82097968, 101.80767345, 101.81245272, 101.81330413, 101.81509642,
+		101.81722942, 101.82775322, 101.78803722, 101.77460047, 101.77381424,
+		101.75561533, 101.76918159, 101.74213283, 101.68367378, 101.52782763,
+		101.44632032, 101.06607601, 100.96355162, 100.79305832, 100.56065431,
+		100.48618315, 100.37424289, 100.31022169, 100.27024547, 100.25825632,
+		100.14390519, 100.10908754, 100.10022195, 100.04538701, 100.01915586,
+		100.00955599, 100.00395729, 99.99860421, 99.99767420, 99.99677442,
+		99.99039279, 99.98587917, 99.98180421, 99.98896652, 100.22974316,
+		100.40118555, 100.55263654, 100.90122918, 101.23966730, 101.72607612,
+	}},
+	{T3, 5, "t3", []float64{
+		102.75731574, 103.21979109, 103.74529428, 104.02930182, 104.20276279,
+		104.14999947, 103.94236824, 103.67442927, 103.30766499, 102.91141965,
+		102.51065669, 102.15416446, 101.87179953, 101.68358078, 101.57735716,
+		101.53141348, 101.47206011, 101.41676734, 101.34813618, 101.41156974,
+		101.49803777, 101.68790752, 101.81902538, 101.92844785, 102.00308678,
+		102.07565338, 102.15099497, 102.29876659, 102.48689105, 102.53706883,
+		102.46494874, 102.37951617, 102.18386405, 102.06987700, 102.00820693,
+		101.97873041, 102.00313957, 102.08741504, 102.03890002, 101.87995885,
+		101.73571360, 101.58551518, 101.55854639, 101.51164275, 101.36486727,
+		101.06137714, 100.74809946, 100.29201969, 99.84362208, 99.40596819,
+		99.02368575, 98.79732656, 98.73602216, 98.78508072, 98.88803829,
+		99.00473085, 99.03857085, 99.06943479, 99.11512066, 99.20413025,
+		99.24205756, 99.30743586, 99.39737150, 99.49664483, 99.61608143,
+		99.73675839, 99.70355575, 99.63835094, 99.58321611, 99.63486410,
+		99.92172419, 100.35009148, 100.79996167, 101.31112429, 101.84282234,
+		102.41449114,
+	}},
+	{MAMA, 0, "mama", []float64{
+		103.93039458, 102.94519729, 102.85119088, 102.78913134, 102.73017477,
+		102.22508739, 102.19983302, 102.17584137, 102.13054930, 102.09502183,
+		102.04016272, 102.05759856, 101.86379928, 102.27189964, 102.24230466,
+		102.23868942, 102.12934471, 102.13637748, 102.14555860, 102.18778067,
+		102.58889034, 102.55094582, 102.19047291, 102.19394926, 102.14125180,
+		102.15368921, 102.08184461, 102.07825238, 102.08983976, 102.11184777,
+		102.06375538, 101.60687769, 101.60953381, 101.40476690, 101.44352856,
+		101.29176428, 101.25567606, 101.17939226, 101.09684238, 99.82842119,
+		99.79450013, 99.16725007, 99.13588756, 99.13659318, 99.18891721,
+		99.19797135, 99.20657278, 99.21474414, 99.19100693, 99.25050347,
+		99.25497829, 99.27422938, 99.26101791, 99.47550895, 99.48623351,
+		99.60811675, 99.62621092, 99.64340037, 99.21670019, 99.23236518,
+		99.24724692, 99.76362346, 99.85744229, 100.77872114, 100.82478509,
+		101.77239254, 101.83428843, 101.93207401,
+	}},
+	{MAMA, 0, "fama", []float64{
+		96.73339328, 98.28634428, 98.44704144, 98.55559369, 98.65995822,
+		99.55124051, 99.61745532, 99.68141497, 99.74264333, 99.80145279,
+		99.86930400, 99.93292977, 100.41564715, 100.87971027, 100.91377513,
+		100.94689799, 101.24250967, 101.26485636, 101.28687392, 101.30939659,
+		101.62927003, 101.65231192, 101.78685217, 101.79702960, 101.80563515,
+		101.81433650, 101.88121353, 101.88613950, 101.89123201, 101.89674740,
+		101.90092260, 101.82741137, 101.82196443, 101.71766505, 101.71081164,
+		101.60604980, 101.59729045, 101.58684300, 101.56484505, 101.13073908,
+		101.09733311, 100.61481235, 100.57783923, 100.54180808, 100.39016578,
+		100.36036092, 100.33151622, 100.30359691, 100.27578216, 100.01946249,
+		100.00035038, 99.98219736, 99.96416787, 99.84200314, 99.83310890,
+		99.77686087, 99.77309462, 99.76985226, 99.63156424, 99.62158427,
+		99.61222583, 99.65007524, 99.65525941, 99.93612485, 99.95834135,
+		100.41185415, 100.44800818, 100.48510983,
+	}},
+}
+
+func TestTALib(t *testing.T) {
+	var cases []indicatortest.TALibCase
+	for _, test := range talibAverages {
+		c := indicatortest.TALibCase{Name: fmt.Sprintf("%s(%d)", test.id, test.period), New: constructors[test.id], Field: test.field, Want: test.want}
+		if test.period > 0 {
+			c.Options = []internal.PluginOptions{opt.WithPeriod(test.period)}
+		}
+		cases = append(cases, c)
+	}
+	indicatortest.TALibCases(t, cases)
+}
@@ -0,0 +1,58 @@
+package ma
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// TEMA = 3 * EMA - 3 * EMA(EMA) + EMA(EMA(EMA))
+const temaPluginID = "TEMA"
+const temaPluginName = "Triple Exponential Moving Average"
+const temaPluginDescription = "Triple Exponential Moving Average reduces the lag of an EMA further with the EMA of the EMA of the EMA."
+const temaPluginHCL = `
+indicator "tema" {
+  period = 30
+}
+`
+
+type temaKernel struct {
+	E1, E2, E3 *emaKernel
+}
+
+func newTEMA(period int) *temaKernel {
+	alpha := 2 / (float64(period) + 1)
+	return &temaKernel{E1: newEMA(period, alpha), E2: newEMA(period, alpha), E3: newEMA(period, alpha)}
+}
+
+func (k *temaKernel) Add(value float64) float64 {
+	e1 := k.E1.Add(value)
+	if math.IsNaN(e1) {
+		return e1
+	}
+	e2 := k.E2.Add(e1)
+	if math.IsNaN(e2) {
+		return e2
+	}
+	e3 := k.E3.Add(e2)
+	if math.IsNaN(e3) {
+		return e3
+	}
+	return 3*e1 - 3*e2 + e3
+}
+
+func (k *temaKernel) Warmup() int {
+	return 3*k.E1.Period - 2
+}
+
+func temaNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newAverage(temaPluginID, temaPluginName, temaPluginDescription, temaPluginHCL, opt.New(opts...), func(period int) (Average, error) {
+		return newTEMA(period), nil
+	})
+}
+
+func init() {
+	indicators.Add(temaPluginID, temaNew, indicators.TREND)
+}
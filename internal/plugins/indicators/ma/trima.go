@@ -0,0 +1,51 @@
+package ma
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// TRIMA = SMA(SMA(P, n/2+1), n+1-(n/2+1)), weights 1, 2, ..., 2, 1
+const trimaPluginID = "TRIMA"
+const trimaPluginName = "Triangular Moving Average"
+const trimaPluginDescription = "Triangular Moving Average weights the middle of the window the most, as an SMA of an SMA."
+const trimaPluginHCL = `
+indicator "trima" {
+  period = 30
+}
+`
+
+type trimaKernel struct {
+	Period int
+	S1, S2 *smaKernel
+}
+
+func newTRIMA(period int) *trimaKernel {
+	first := period/2 + 1
+	return &trimaKernel{Period: period, S1: newSMA(first), S2: newSMA(period + 1 - first)}
+}
+
+func (k *trimaKernel) Add(value float64) float64 {
+	s1 := k.S1.Add(value)
+	if math.IsNaN(s1) {
+		return s1
+	}
+	return k.S2.Add(s1)
+}
+
+func (k *trimaKernel) Warmup() int {
+	return k.Period
+}
+
+func trimaNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newAverage(trimaPluginID, trimaPluginName, trimaPluginDescription, trimaPluginHCL, opt.New(opts...), func(period int) (Average, error) {
+		return newTRIMA(period), nil
+	})
+}
+
+func init() {
+	indicators.Add(trimaPluginID, trimaNew, indicators.TREND)
+}
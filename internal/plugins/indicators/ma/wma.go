@@ -0,0 +1,60 @@
+package ma
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// WMA = (n*Pn + (n-1)*Pn-1 + ... + 1*P1) / (n*(n+1)/2)
+const wmaPluginID = "WMA"
+const wmaPluginName = "Weighted Moving Average"
+const wmaPluginDescription = "Weighted Moving Average weights the last n values linearly, the newest the most."
+const wmaPluginHCL = `
+indicator "wma" {
+  period = 30
+}
+`
+
+// wmaKernel keeps the weighted sum and the plain sum of the window.
+type wmaKernel struct {
+	Period      int
+	Window      plugins.Ring
+	WeightedSum float64
+	Sum         float64
+}
+
+func newWMA(period int) *wmaKernel {
+	return &wmaKernel{Period: period, Window: plugins.NewRing(period)}
+}
+
+func (k *wmaKernel) Add(value float64) float64 {
+	// every weight drops by one, the oldest value drops out with weight zero
+	k.WeightedSum += float64(k.Period)*value - k.Sum
+	k.Sum += value
+	if k.Window.Len == k.Period {
+		k.Sum -= k.Window.At(k.Period - 1)
+	}
+	k.Window.Push(value)
+	if k.Window.Len < k.Period {
+		return math.NaN()
+	}
+	return k.WeightedSum / float64(k.Period*(k.Period+1)/2)
+}
+
+func (k *wmaKernel) Warmup() int {
+	return k.Period
+}
+
+func wmaNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newAverage(wmaPluginID, wmaPluginName, wmaPluginDescription, wmaPluginHCL, opt.New(opts...), func(period int) (Average, error) {
+		return newWMA(period), nil
+	})
+}
+
+func init() {
+	indicators.Add(wmaPluginID, wmaNew, indicators.TREND)
+}
@@ -1,142 +1,194 @@
 package macd
 
-// import (
-// 	"fmt"
-// 	"math"
-
-// 	"github.com/rangertaha/gotal/internal"
-// 	"github.com/rangertaha/gotal/internal/pkg/opt"
-// 	"github.com/rangertaha/gotal/internal/pkg/series"
-// 	"github.com/rangertaha/gotal/internal/pkg/sig"
-// 	"github.com/rangertaha/gotal/internal/pkg/tick"
-// 	"github.com/rangertaha/gotal/internal/plugins/indicators"
-// )
-
-// type macd struct {
-// 	Name         string `hcl:"name,optional"`   // name of the data series
-// 	Input        string `hcl:"input,optional"`  // field to compute the MACD on
-// 	FastPeriod   int    `hcl:"fast,optional"`   // period for fast EMA
-// 	SlowPeriod   int    `hcl:"slow,optional"`   // period for slow EMA
-// 	SignalPeriod int    `hcl:"signal,optional"` // period for signal line EMA
-
-// 	// series is the series of ticks to compute the MACD on
-// 	series    *series.Series
-// 	fastEma   internal.Indicator
-// 	slowEma   internal.Indicator
-// 	signalEma internal.Indicator
-
-// 	// MACD values
-// 	macdLine   float64 // MACD line (fast EMA - slow EMA)
-// 	signalLine float64 // Signal line (EMA of MACD line)
-// 	histogram  float64 // Histogram (MACD line - Signal line)
-// }
-
-// func New(opts ...internal.OptFunc) *macd {
-// 	cfg := opt.New(opts...)
-// 	ema, err := indicators.Get("ema")
-// 	if err != nil {
-// 		panic(fmt.Sprintf("ema moving average function not found: %v", err))
-// 	}
-// 	fast := cfg.GetInt("fast", 12)
-// 	slow := cfg.GetInt("slow", 26)
-// 	signal := cfg.GetInt("signal", 9)
-
-// 	fastEma := ema(opt.WithPeriod(fast), opt.WithInput("value"), opt.WithOutput("fast"))
-// 	slowEma := ema(opt.WithPeriod(slow), opt.WithInput("value"), opt.WithOutput("slow"))
-// 	signalEma := ema(opt.WithPeriod(signal), opt.WithInput("value"), opt.WithOutput("signal"))
-
-// 	return &macd{
-// 		Name:  cfg.Name("macd"),
-// 		Input: cfg.Field("value"),
-
-// 		series:    series.New(cfg.Name("macd")),
-// 		fastEma:   fastEma,
-// 		slowEma:   slowEma,
-// 		signalEma: signalEma,
-// 	}
-// }
-
-// // Compute computes for a given series of ticks
-// func (i *macd) Compute(input *series.Series) (output *series.Series) {
-// 	output = series.New(i.Name)
-// 	i.series.Reset()
-
-// 	for _, t := range input.Ticks() {
-// 		if t := i.Process(t); !t.IsEmpty() {
-// 			output.Add(t)
-// 		}
-// 	}
-
-// 	return
-// }
-
-// // Process computes for a given tick
-// func (i *macd) Process(input *tick.Tick) (output *tick.Tick) {
-// 	// check if the series has the required field
-// 	if !input.HasField(i.Input) {
-// 		panic(fmt.Sprintf("%s series is missing field %v", i.Name, i.Input))
-// 	}
-
-// 	fastEma := i.fastEma.Process(input)
-// 	slowEma := i.slowEma.Process(input)
-// 	signalEma := i.signalEma.Process(input)
-// 	input.SetField("fast", fastEma.GetField("fast"))
-// 	input.SetField("slow", slowEma.GetField("slow"))
-// 	input.SetField("signal", signalEma.GetField("signal"))
-
-// 	// add the input tick to the series
-// 	i.series.Push(input)
-
-// 	// create a new empty tick
-// 	output = tick.New()
-
-// 	// if the series is not long enough, return false
-// 	if i.series.Len() > i.SlowPeriod {
-// 		// calculate the average while moving the window
-// 		output = i.calculate(i.series.Shift(input))
-// 	}
-
-// 	return
-// }
-
-// func (i *macd) calculate(input *tick.Tick) (output *tick.Tick) {
-
-// 	fastValue := input.GetField("fast")
-// 	slowValue := input.GetField("slow")
-// 	signalValue := input.GetField("signal")
-
-// 	if math.IsNaN(fastValue) || math.IsNaN(slowValue) || math.IsNaN(signalValue) {
-// 		return tick.New() // Return empty tick if no data available
-// 	}
-
-// 	// Calculate MACD line (fast EMA - slow EMA)
-// 	macdValue := fastValue - slowValue
-
-// 	// Calculate histogram (MACD line - Signal line)
-// 	histogramValue := macdValue - signalValue
-
-// 	// Set signals
-// 	if macdValue > signalValue {
-// 		input.SetSignal(sig.BULLISH, sig.MEDIUM)
-// 	} else if macdValue < signalValue {
-// 		input.SetSignal(sig.BEARISH, sig.MEDIUM)
-// 	}
-
-// 	// Add MACD line to signal EMA series for signal line calculation
-// 	output = tick.New(
-// 		tick.WithTime(input.Time()),
-// 		tick.WithDuration(input.Duration()),
-// 		tick.WithFields(map[string]float64{"macd": macdValue, "histogram": histogramValue, "fast": fastValue, "slow": slowValue, "signal": signalValue}),
-// 		tick.WithTags(input.Tags()),
-// 		tick.WithSignals(input.Signals()),
-// 	)
-
-// 	return
-
-// }
-
-// func init() {
-// 	indicators.Add("macd", func(opts ...internal.OptFunc) internal.Indicator {
-// 		return New(opts...)
-// 	}, indicators.TREND)
-// }
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/ma"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// MACD = EMA(P, fast) - EMA(P, slow)
+// Signal = EMA(MACD, signal)
+// Histogram = MACD - Signal
+const macdPluginID = "MACD"
+const macdPluginName = "Moving Average Convergence Divergence"
+const macdPluginDescription = "Moving Average Convergence Divergence is the difference of a fast and a slow EMA, with an EMA of it as the signal line."
+const macdPluginHCL = `
+indicator "macd" {
+  fast = 12
+  slow = 26
+  signal = 9
+}
+`
+
+type macd struct {
+	plugins.Plugin
+
+	FastPeriod   int    `hcl:"fast,optional"`          // period of the fast line
+	SlowPeriod   int    `hcl:"slow,optional"`          // period of the slow line
+	SignalPeriod int    `hcl:"signal,optional"`        // period of the signal line
+	FastMAType   string `hcl:"fast_matype,optional"`   // moving average type of the fast line
+	SlowMAType   string `hcl:"slow_matype,optional"`   // moving average type of the slow line
+	SignalMAType string `hcl:"signal_matype,optional"` // moving average type of the signal line
+
+	new   func() (fast, slow, signal ma.Average)
+	state macdState
+}
+
+// macdState is the state of the three lines, also the snapshot of a MACD.
+type macdState struct {
+	Fast, Slow, Signal ma.Average
+	Count              int     // values added
+	Histogram          float64 // previous histogram, NaN before the first output
+}
+
+// newMACD returns a MACD whose lines come from new once Reset. Like TA-Lib,
+// the periods are swapped when the slow one is the shorter.
+func newMACD(id, name, description, hcl string, params internal.Options, new func(i *macd) (fast, slow, signal ma.Average, err error)) *macd {
+	i := &macd{
+		Plugin: plugins.Plugin{
+			PID:         id,
+			Title:       name,
+			Summary:     description,
+			Template:    hcl,
+			Params:      params,
+			Fields:      []string{params.Field("value")},
+			Initialized: true,
+		},
+		FastPeriod:   params.FastPeriod(12),
+		SlowPeriod:   params.SlowPeriod(26),
+		SignalPeriod: params.SignalPeriod(9),
+		FastMAType:   ma.EMA,
+		SlowMAType:   ma.EMA,
+		SignalMAType: ma.EMA,
+	}
+	if i.SlowPeriod < i.FastPeriod {
+		i.FastPeriod, i.SlowPeriod = i.SlowPeriod, i.FastPeriod
+	}
+
+	i.new = func() (fast, slow, signal ma.Average) {
+		fast, slow, signal, err := new(i)
+		if err != nil {
+			params.AddError(err)
+			fast, _ = ma.NewAverage(ma.EMA, i.FastPeriod)
+			slow, _ = ma.NewAverage(ma.EMA, i.SlowPeriod)
+			signal, _ = ma.NewAverage(ma.EMA, i.SignalPeriod)
+		}
+		return fast, slow, signal
+	}
+	return i
+}
+
+func macdNew(opts ...internal.PluginOptions) internal.Plugin {
+	i := newMACD(macdPluginID, macdPluginName, macdPluginDescription, macdPluginHCL, opt.New(opts...), averages)
+	i.Reset()
+	return i
+}
+
+// averages returns the moving averages of the types of the MACD.
+func averages(i *macd) (fast, slow, signal ma.Average, err error) {
+	if fast, err = ma.NewAverage(i.FastMAType, i.FastPeriod); err != nil {
+		return
+	}
+	if slow, err = ma.NewAverage(i.SlowMAType, i.SlowPeriod); err != nil {
+		return
+	}
+	signal, err = ma.NewAverage(i.SignalMAType, i.SignalPeriod)
+	return
+}
+
+func (i *macd) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+func (i *macd) Compute(input *series.Series) (output *series.Series) {
+	return plugins.Compute(i.ID(), i, input)
+}
+
+func (i *macd) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the input value and returns the macd, signal and histogram
+// fields once the signal line is warmed up, with the trend and crossover
+// signals of the histogram.
+func (i *macd) Update(input *tick.Tick) (output *tick.Tick) {
+	value := input.GetField(i.Fields[0])
+	if math.IsNaN(value) {
+		return tick.New()
+	}
+	s := &i.state
+	s.Count++
+
+	// the shorter line starts late so both are seeded from the same values, as in TA-Lib
+	fast, slow := math.NaN(), math.NaN()
+	if s.Count > s.Slow.Warmup()-s.Fast.Warmup() {
+		fast = s.Fast.Add(value)
+	}
+	if s.Count > s.Fast.Warmup()-s.Slow.Warmup() {
+		slow = s.Slow.Add(value)
+	}
+	if math.IsNaN(fast) || math.IsNaN(slow) {
+		return tick.New()
+	}
+
+	line := fast - slow
+	signal := s.Signal.Add(line)
+	if math.IsNaN(signal) {
+		return tick.New()
+	}
+
+	histogram := line - signal
+	output = plugins.Output(input, map[string]float64{
+		"macd":      line,
+		"signal":    signal,
+		"histogram": histogram,
+	})
+
+	switch {
+	case histogram > 0:
+		output.SetSignal(sig.BULLISH, sig.MEDIUM)
+		if s.Histogram <= 0 {
+			output.SetSignal(sig.CROSSOVER, sig.STRONG)
+		}
+	case histogram < 0:
+		output.SetSignal(sig.BEARISH, sig.MEDIUM)
+		if s.Histogram >= 0 {
+			output.SetSignal(sig.CROSSUNDER, sig.STRONG)
+		}
+	}
+	s.Histogram = histogram
+	return output
+}
+
+func (i *macd) Reset() {
+	fast, slow, signal := i.new()
+	i.state = macdState{Fast: fast, Slow: slow, Signal: signal, Histogram: math.NaN()}
+}
+
+func (i *macd) Warmup() int {
+	return max(i.state.Fast.Warmup(), i.state.Slow.Warmup()) + i.state.Signal.Warmup() - 1
+}
+
+func (i *macd) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(i.state)
+}
+
+func (i *macd) Restore(state []byte) error {
+	var s macdState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.state = s
+	return nil
+}
+
+func init() {
+	indicators.Add(macdPluginID, macdNew, indicators.TREND)
+}
@@ -0,0 +1,157 @@
+package macd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+)
+
+// refEMA is the TA-Lib EMA of xs, seeded with the simple average of the
+// first n values and returned aligned with xs, NaN before the seed.
+func refEMA(xs []float64, n int, alpha float64) []float64 {
+	return indicatortest.Align(indicatortest.EMAAlpha(xs, n, alpha), len(xs))
+}
+
+func refSMA(xs []float64, n int) []float64 {
+	return indicatortest.Align(indicatortest.SMA(xs, n), len(xs))
+}
+
+// refMACD follows TA-Lib, the fast line is seeded from the same values as the slow one.
+func refMACD(xs []float64, fast, slow []float64, signal func([]float64) []float64) (macd, sig, hist []float64) {
+	var line []float64
+	for i := range xs {
+		if !math.IsNaN(fast[i]) && !math.IsNaN(slow[i]) {
+			line = append(line, fast[i]-slow[i])
+		}
+	}
+	signals := signal(line)
+	for i := range line {
+		if !math.IsNaN(signals[i]) {
+			macd = append(macd, line[i])
+			sig = append(sig, signals[i])
+			hist = append(hist, line[i]-signals[i])
+		}
+	}
+	return
+}
+
+func check(t *testing.T, name string, output *series.Series, macd, signal, histogram []float64) {
+	t.Helper()
+	if output.Len() != len(macd) {
+		t.Fatalf("%s: got %d outputs, want %d", name, output.Len(), len(macd))
+	}
+	for i := range macd {
+		out := output.At(i)
+		for field, want := range map[string]float64{"macd": macd[i], "signal": signal[i], "histogram": histogram[i]} {
+			if got := out.GetField(field); math.Abs(got-want) > 1e-9 {
+				t.Errorf("%s %s[%d] = %v, want %v", name, field, i, got, want)
+			}
+		}
+	}
+}
+
+func TestMACD(t *testing.T) {
+	xs := indicatortest.RandomWalk(120, 11)
+	input := indicatortest.Values(xs...)
+
+	i := macdNew(opt.WithFastPeriod(5), opt.WithSlowPeriod(12), opt.WithSignalPeriod(4)).(internal.Indicator)
+	fast := indicatortest.Align(indicatortest.EMAAlpha(xs[12-5:], 5, 2.0/6), len(xs))
+	macd, signal, histogram := refMACD(xs, fast, refEMA(xs, 12, 2.0/13), func(line []float64) []float64 {
+		return refEMA(line, 4, 2.0/5)
+	})
+	check(t, "MACD", i.Compute(input), macd, signal, histogram)
+	if i.Warmup() != 15 || len(xs)-len(macd)+1 != 15 {
+		t.Errorf("Warmup() = %d with %d outputs, want 15", i.Warmup(), len(macd))
+	}
+
+	// the periods are swapped when slow is the shorter
+	swapped := macdNew(opt.WithFastPeriod(12), opt.WithSlowPeriod(5), opt.WithSignalPeriod(4)).(internal.Indicator)
+	check(t, "MACD swapped", swapped.Compute(input), macd, signal, histogram)
+}
+
+func TestMACDEXT(t *testing.T) {
+	xs := indicatortest.RandomWalk(120, 11)
+	input := indicatortest.Values(xs...)
+
+	// SMA lines by default
+	i := macdextNew(opt.WithFastPeriod(3), opt.WithSlowPeriod(10), opt.WithSignalPeriod(5)).(internal.Indicator)
+	macd, signal, histogram := refMACD(xs, refSMA(xs, 3), refSMA(xs, 10), func(line []float64) []float64 {
+		return refSMA(line, 5)
+	})
+	check(t, "MACDEXT", i.Compute(input), macd, signal, histogram)
+
+	// EMA lines are the MACD
+	i = macdextNew(opt.WithFastPeriod(5), opt.WithSlowPeriod(12), opt.WithSignalPeriod(4), opt.WithMAType("ema")).(internal.Indicator)
+	want := macdNew(opt.WithFastPeriod(5), opt.WithSlowPeriod(12), opt.WithSignalPeriod(4)).Compute(input)
+	check(t, "MACDEXT EMA", i.Compute(input), indicatortest.Fields(want, "macd"), indicatortest.Fields(want, "signal"), indicatortest.Fields(want, "histogram"))
+
+	// per line types override WithMAType
+	i = macdextNew(opt.WithFastPeriod(3), opt.WithSlowPeriod(10), opt.WithSignalPeriod(5),
+		opt.WithMAType("ema"), opt.WithFastMAType("sma"), opt.WithSlowMAType("sma")).(internal.Indicator)
+	macd, signal, histogram = refMACD(xs, refSMA(xs, 3), refSMA(xs, 10), func(line []float64) []float64 {
+		return refEMA(line, 5, 2.0/6)
+	})
+	check(t, "MACDEXT SMA/SMA/EMA", i.Compute(input), macd, signal, histogram)
+
+	if err := macdextNew(opt.WithSignalMAType("unknown")).Init(); err == nil {
+		t.Error("Init() with an unknown type succeeded")
+	}
+}
+
+func TestMACDFIX(t *testing.T) {
+	xs := indicatortest.RandomWalk(120, 11)
+	i := macdfixNew(opt.WithFastPeriod(3), opt.WithSignalPeriod(9)).(internal.Indicator)
+
+	fast := indicatortest.Align(indicatortest.EMAAlpha(xs[14:], 12, 0.15), len(xs))
+	macd, signal, histogram := refMACD(xs, fast, refEMA(xs, 26, 0.075), func(line []float64) []float64 {
+		return refEMA(line, 9, 0.2)
+	})
+	check(t, "MACDFIX", i.Compute(indicatortest.Values(xs...)), macd, signal, histogram)
+	if i.Warmup() != 34 {
+		t.Errorf("Warmup() = %d, want 34", i.Warmup())
+	}
+}
+
+func TestMACDSignals(t *testing.T) {
+	xs := make([]float64, 160)
+	for i := range xs {
+		xs[i] = 10 * math.Sin(float64(i)/6)
+	}
+	output := macdNew(opt.WithFastPeriod(3), opt.WithSlowPeriod(6), opt.WithSignalPeriod(3)).Compute(indicatortest.Values(xs...))
+
+	crossings := 0
+	for i := range output.Len() {
+		out := output.At(i)
+		histogram := out.GetField("histogram")
+		if out.HasSignal(sig.BULLISH) != (histogram > 0) || out.HasSignal(sig.BEARISH) != (histogram < 0) {
+			t.Errorf("output %d: histogram %v with signals %v", i, histogram, out.SignalNames())
+		}
+
+		over, under := false, false
+		if i > 0 {
+			prev := output.At(i - 1).GetField("histogram")
+			over, under = prev <= 0 && histogram > 0, prev >= 0 && histogram < 0
+		}
+		if out.HasSignal(sig.CROSSOVER) != over || out.HasSignal(sig.CROSSUNDER) != under {
+			t.Errorf("output %d: histogram %v with signals %v", i, histogram, out.SignalNames())
+		}
+		if over || under {
+			crossings++
+		}
+	}
+	if crossings < 4 {
+		t.Errorf("got %d crossings over a sine wave, want at least 4", crossings)
+	}
+}
+
+func TestMACDSnapshot(t *testing.T) {
+	input := indicatortest.Values(indicatortest.RandomWalk(80, 11)...)
+	indicatortest.SnapshotReplay(t, func() internal.Indicator {
+		return macdextNew(opt.WithFastPeriod(4), opt.WithSlowPeriod(9), opt.WithMAType("tema")).(internal.Indicator)
+	}, input, 30)
+}
@@ -0,0 +1,43 @@
+package macd
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/ma"
+)
+
+// MACDEXT is the MACD with a moving average type per line, selected with
+// opt.WithMAType for all lines or opt.WithFastMAType, opt.WithSlowMAType and
+// opt.WithSignalMAType for one. Like TA-Lib the lines default to SMA.
+const macdextPluginID = "MACDEXT"
+const macdextPluginName = "MACD with controllable MA type"
+const macdextPluginDescription = "MACD with controllable MA type is the MACD with a selectable moving average type for the fast, slow and signal lines."
+const macdextPluginHCL = `
+indicator "macdext" {
+  fast = 12
+  fast_matype = "SMA"
+  slow = 26
+  slow_matype = "SMA"
+  signal = 9
+  signal_matype = "SMA"
+}
+`
+
+func macdextNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	maType := params.MAType(ma.SMA)
+
+	i := newMACD(macdextPluginID, macdextPluginName, macdextPluginDescription, macdextPluginHCL, params, averages)
+	i.FastMAType = strings.ToUpper(params.String("fastMAType", maType))
+	i.SlowMAType = strings.ToUpper(params.String("slowMAType", maType))
+	i.SignalMAType = strings.ToUpper(params.String("signalMAType", maType))
+	i.Reset()
+	return i
+}
+
+func init() {
+	indicators.Add(macdextPluginID, macdextNew, indicators.TREND)
+}
@@ -0,0 +1,36 @@
+package macd
+
+import (
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/ma"
+)
+
+// MACDFIX is the MACD 12/26 with the fixed smoothing factors 0.15 and 0.075
+// instead of 2/(n+1), only the signal period is configurable.
+const macdfixPluginID = "MACDFIX"
+const macdfixPluginName = "Moving Average Convergence Divergence Fix 12/26"
+const macdfixPluginDescription = "Moving Average Convergence Divergence Fix 12/26 is the classic MACD with fixed smoothing factors of 0.15 and 0.075."
+const macdfixPluginHCL = `
+indicator "macdfix" {
+  signal = 9
+}
+`
+
+func macdfixNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	params.Set("fastPeriod", 12)
+	params.Set("slowPeriod", 26)
+
+	i := newMACD(macdfixPluginID, macdfixPluginName, macdfixPluginDescription, macdfixPluginHCL, params, func(i *macd) (fast, slow, signal ma.Average, err error) {
+		signal, err = ma.NewAverage(ma.EMA, i.SignalPeriod)
+		return ma.NewEMA(12, 0.15), ma.NewEMA(26, 0.075), signal, err
+	})
+	i.Reset()
+	return i
+}
+
+func init() {
+	indicators.Add(macdfixPluginID, macdfixNew, indicators.TREND)
+}
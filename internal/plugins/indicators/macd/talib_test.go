@@ -0,0 +1,161 @@
+package macd
+
+import (
+	"testing"
+
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+)
+
+// talibOutputs are the TA-Lib outputs of the indicators with the default
+// parameters. The MACD and MACDFIX lines are the TA-Lib EMAs combined as
+// TA_MACD does, the fast one seeded with the values up to the first slow output.
+var talibOutputs = []indicatortest.TALibCase{
+	{Name: macdPluginID, New: macdNew, Field: "macd", Want: []float64{
+		1.02708660, 0.86028180, 0.71979049, 0.60151622, 0.51077123,
+		0.43385398, 0.36864697, 0.27746031, 0.21482165, 0.14495008,
+		0.18029344, 0.15248219, 0.20952486, 0.17205658, 0.17982865,
+		0.17190274, 0.18367699, 0.19479726, 0.25473709, 0.29879552,
+		0.23737354, 0.18654579, 0.17889960, 0.08152552, 0.10402132,
+		0.09014742, 0.07825024, 0.09196896, 0.11921903, 0.02912470,
+		-0.04179392, -0.05619690, -0.10353603, -0.06126855, -0.11041775,
+		-0.19313672, -0.32275259, -0.37834619, -0.55320333, -0.63682982,
+		-0.74375280, -0.81904856, -0.82004610, -0.79393254, -0.76442553,
+		-0.73259610, -0.69930984, -0.71551793, -0.67459244, -0.63244748,
+		-0.56828893, -0.56180244, -0.49607311, -0.43892251, -0.38595356,
+		-0.32091004, -0.26629292, -0.31459810, -0.28982754, -0.26711753,
+		-0.18645165, -0.01263706, 0.12847281, 0.23756489, 0.40169612,
+		0.54724970, 0.71886860,
+	}},
+	{Name: macdPluginID, New: macdNew, Field: "signal", Want: []float64{
+		1.58882923, 1.44311975, 1.29845390, 1.15906636, 1.02940733,
+		0.91029666, 0.80196672, 0.69706544, 0.60061668, 0.50948336,
+		0.44364538, 0.38541274, 0.35023516, 0.31459945, 0.28764529,
+		0.26449678, 0.24833282, 0.23762571, 0.24104798, 0.25259749,
+		0.24955270, 0.23695132, 0.22534098, 0.19657788, 0.17806657,
+		0.16048274, 0.14403624, 0.13362279, 0.13074203, 0.11041857,
+		0.07997607, 0.05274147, 0.02148597, 0.00493507, -0.01813549,
+		-0.05313574, -0.10705911, -0.16131653, -0.23969389, -0.31912107,
+		-0.40404742, -0.48704765, -0.55364734, -0.60170438, -0.63424861,
+		-0.65391811, -0.66299645, -0.67350075, -0.67371909, -0.66546477,
+		-0.64602960, -0.62918417, -0.60256196, -0.56983407, -0.53305797,
+		-0.49062838, -0.44576129, -0.41952865, -0.39358843, -0.36829425,
+		-0.33192573, -0.26806800, -0.18875984, -0.10349489, -0.00245669,
+		0.10748459, 0.22976139,
+	}},
+	{Name: macdPluginID, New: macdNew, Field: "histogram", Want: []float64{
+		-0.56174264, -0.58283795, -0.57866340, -0.55755014, -0.51863610,
+		-0.47644269, -0.43331976, -0.41960513, -0.38579504, -0.36453328,
+		-0.26335194, -0.23293055, -0.14071031, -0.14254287, -0.10781664,
+		-0.09259404, -0.06465583, -0.04282845, 0.01368911, 0.04619803,
+		-0.01217916, -0.05040553, -0.04644138, -0.11505236, -0.07404525,
+		-0.07033532, -0.06578601, -0.04165382, -0.01152301, -0.08129386,
+		-0.12176999, -0.10893838, -0.12502200, -0.06620362, -0.09228225,
+		-0.14000098, -0.21569348, -0.21702966, -0.31350944, -0.31770875,
+		-0.33970538, -0.33200091, -0.26639876, -0.19222816, -0.13017692,
+		-0.07867800, -0.03631339, -0.04201718, -0.00087335, 0.03301728,
+		0.07774067, 0.06738173, 0.10648885, 0.13091155, 0.14710441,
+		0.16971834, 0.17946837, 0.10493055, 0.10376089, 0.10117672,
+		0.14547408, 0.25543094, 0.31723264, 0.34105978, 0.40415281,
+		0.43976511, 0.48910721,
+	}},
+	{Name: macdextPluginID, New: macdextNew, Field: "macd", Want: []float64{
+		1.15923077, 0.97448718, 0.69807692, 0.34250000, 0.06185897,
+		-0.19025641, -0.29788462, -0.49788462, -0.61025641, -0.69948718,
+		-0.76865385, -0.79205128, -0.75089744, -0.72775641, -0.69839744,
+		-0.65153846, -0.56032051, -0.43032051, -0.27025641, -0.04769231,
+		0.03955128, 0.18673077, 0.20352564, 0.22589744, 0.22019231,
+		0.24576923, 0.23051282, 0.22775641, 0.21403846, 0.13423077,
+		0.00282051, -0.10570513, -0.13820513, -0.14403846, -0.22660256,
+		-0.25025641, -0.37153846, -0.46314103, -0.59217949, -0.75820513,
+		-0.95108974, -1.03474359, -1.08141026, -1.15878205, -1.17205128,
+		-1.26698718, -1.31987179, -1.35352564, -1.27506410, -1.28250000,
+		-1.08673077, -0.98301282, -0.79794872, -0.60134615, -0.44532051,
+		-0.34993590, -0.25455128, -0.19250000, -0.11493590, 0.05282051,
+		0.16673077, 0.31724359, 0.41314103, 0.58192308, 0.67442308,
+		0.80173077, 0.93814103,
+	}},
+	{Name: macdextPluginID, New: macdextNew, Field: "signal", Want: []float64{
+		1.63214387, 1.52775641, 1.39653134, 1.23023504, 1.04282764,
+		0.83319088, 0.61806268, 0.39706553, 0.18220798, -0.02431624,
+		-0.21799858, -0.38356838, -0.50505698, -0.59279202, -0.64925214,
+		-0.68854701, -0.69548433, -0.67549145, -0.62779915, -0.54769231,
+		-0.45529202, -0.35111111, -0.24763533, -0.14493590, -0.04807692,
+		0.04148860, 0.11491453, 0.17024929, 0.19933048, 0.20985043,
+		0.18941595, 0.15505698, 0.11460114, 0.07413105, 0.02164530,
+		-0.03177350, -0.09836182, -0.17360399, -0.25431624, -0.33887464,
+		-0.43280627, -0.53242165, -0.63657407, -0.74014957, -0.84257123,
+		-0.94206553, -1.03725783, -1.12185185, -1.17928063, -1.21610399,
+		-1.22188034, -1.21094729, -1.17085470, -1.10744302, -1.01614672,
+		-0.90837607, -0.78626781, -0.66598291, -0.53625356, -0.40963675,
+		-0.28188746, -0.15797721, -0.04525641, 0.06888177, 0.18269943,
+		0.30006410, 0.42569088,
+	}},
+	{Name: macdextPluginID, New: macdextNew, Field: "histogram", Want: []float64{
+		-0.47291311, -0.55326923, -0.69845442, -0.88773504, -0.98096866,
+		-1.02344729, -0.91594729, -0.89495014, -0.79246439, -0.67517094,
+		-0.55065527, -0.40848291, -0.24584046, -0.13496439, -0.04914530,
+		0.03700855, 0.13516382, 0.24517094, 0.35754274, 0.50000000,
+		0.49484330, 0.53784188, 0.45116097, 0.37083333, 0.26826923,
+		0.20428063, 0.11559829, 0.05750712, 0.01470798, -0.07561966,
+		-0.18659544, -0.26076211, -0.25280627, -0.21816952, -0.24824786,
+		-0.21848291, -0.27317664, -0.28953704, -0.33786325, -0.41933048,
+		-0.51828348, -0.50232194, -0.44483618, -0.41863248, -0.32948006,
+		-0.32492165, -0.28261396, -0.23167379, -0.09578348, -0.06639601,
+		0.13514957, 0.22793447, 0.37290598, 0.50609687, 0.57082621,
+		0.55844017, 0.53171652, 0.47348291, 0.42131766, 0.46245726,
+		0.44861823, 0.47522080, 0.45839744, 0.51304131, 0.49172365,
+		0.50166667, 0.51245014,
+	}},
+	{Name: macdfixPluginID, New: macdfixNew, Field: "macd", Want: []float64{
+		1.02133922, 0.85918639, 0.72202788, 0.60606418, 0.51631951,
+		0.43994916, 0.37495355, 0.28588252, 0.22388425, 0.15536941,
+		0.18600171, 0.15774384, 0.20950147, 0.17283901, 0.17881872,
+		0.17025855, 0.18036271, 0.19002802, 0.24573956, 0.28677819,
+		0.22881856, 0.18067359, 0.17303702, 0.08108611, 0.10162749,
+		0.08813484, 0.07653472, 0.08905311, 0.11439385, 0.02953108,
+		-0.03752450, -0.05157480, -0.09654163, -0.05731071, -0.10382065,
+		-0.18197111, -0.30436974, -0.35743149, -0.52263020, -0.60238809,
+		-0.70407086, -0.77609815, -0.77824850, -0.75468391, -0.72766607,
+		-0.69823704, -0.66724330, -0.68261799, -0.64427688, -0.60463307,
+		-0.54416100, -0.53774303, -0.47564729, -0.42147348, -0.37113775,
+		-0.30938598, -0.25735306, -0.30204695, -0.27828949, -0.25647944,
+		-0.18019589, -0.01619075, 0.11744045, 0.22118676, 0.37676896,
+		0.51510680, 0.67807999,
+	}},
+	{Name: macdfixPluginID, New: macdfixNew, Field: "signal", Want: []float64{
+		1.57592817, 1.43257982, 1.29046943, 1.15358838, 1.02613460,
+		0.90889752, 0.80210872, 0.69886348, 0.60386764, 0.51416799,
+		0.44853473, 0.39037655, 0.35420154, 0.31792903, 0.29010697,
+		0.26613728, 0.24898237, 0.23719150, 0.23890111, 0.24847653,
+		0.24454493, 0.23177066, 0.22002394, 0.19223637, 0.17411459,
+		0.15691864, 0.14084186, 0.13048411, 0.12726606, 0.10771906,
+		0.07867035, 0.05262132, 0.02278873, 0.00676884, -0.01534906,
+		-0.04867347, -0.09981272, -0.15133648, -0.22559522, -0.30095379,
+		-0.38157721, -0.46048140, -0.52403482, -0.57016464, -0.60166492,
+		-0.62097935, -0.63023214, -0.64070931, -0.64142282, -0.63406487,
+		-0.61608410, -0.60041588, -0.57546217, -0.54466443, -0.50995909,
+		-0.46984447, -0.42734619, -0.40228634, -0.37748697, -0.35328546,
+		-0.31866755, -0.25817219, -0.18304966, -0.10220238, -0.00640811,
+		0.09789487, 0.21393190,
+	}},
+	{Name: macdfixPluginID, New: macdfixNew, Field: "histogram", Want: []float64{
+		-0.55458896, -0.57339343, -0.56844155, -0.54752420, -0.50981510,
+		-0.46894835, -0.42715517, -0.41298096, -0.37998339, -0.35879858,
+		-0.26253303, -0.23263272, -0.14470007, -0.14509002, -0.11128825,
+		-0.09587874, -0.06861966, -0.04716348, 0.00683845, 0.03830166,
+		-0.01572638, -0.05109708, -0.04698691, -0.11115026, -0.07248711,
+		-0.06878381, -0.06430714, -0.04143099, -0.01287220, -0.07818798,
+		-0.11619485, -0.10419612, -0.11933036, -0.06407955, -0.08847160,
+		-0.13329764, -0.20455701, -0.20609502, -0.29703498, -0.30143429,
+		-0.32249366, -0.31561675, -0.25421368, -0.18451928, -0.12600114,
+		-0.07725769, -0.03701116, -0.04190868, -0.00285406, 0.02943180,
+		0.07192309, 0.06267285, 0.09981488, 0.12319095, 0.13882134,
+		0.16045849, 0.16999313, 0.10023939, 0.09919748, 0.09680602,
+		0.13847166, 0.24198144, 0.30049011, 0.32338914, 0.38317707,
+		0.41721193, 0.46414809,
+	}},
+}
+
+func TestTALib(t *testing.T) {
+	indicatortest.TALibCases(t, talibOutputs)
+}
@@ -0,0 +1,103 @@
+package momentum
+
+import (
+	"math"
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/ma"
+)
+
+// APO = MA(Price, fast) - MA(Price, slow)
+// PPO = 100 * (MA(Price, fast) - MA(Price, slow)) / MA(Price, slow)
+const apoPluginID = "APO"
+const apoPluginName = "Absolute Price Oscillator"
+const apoPluginDescription = "Absolute Price Oscillator is the difference of a fast and a slow moving average of the price."
+const apoPluginHCL = `
+indicator "apo" {
+  fast = 12
+  slow = 26
+  matype = "SMA"
+}
+`
+
+const ppoPluginID = "PPO"
+const ppoPluginName = "Percentage Price Oscillator"
+const ppoPluginDescription = "Percentage Price Oscillator is the difference of a fast and a slow moving average of the price in percent of the slow one."
+const ppoPluginHCL = `
+indicator "ppo" {
+  fast = 12
+  slow = 26
+  matype = "SMA"
+}
+`
+
+// poKernel is the price oscillator of the ID. Like the MACD, the shorter
+// average starts late so both are seeded from the same values.
+type poKernel struct {
+	ID         string
+	Count      int
+	Fast, Slow ma.Average
+	Output     string
+}
+
+func (k *poKernel) Add(values []float64) map[string]float64 {
+	k.Count++
+	fast, slow := math.NaN(), math.NaN()
+	if k.Count > k.Slow.Warmup()-k.Fast.Warmup() {
+		fast = k.Fast.Add(values[0])
+	}
+	if k.Count > k.Fast.Warmup()-k.Slow.Warmup() {
+		slow = k.Slow.Add(values[0])
+	}
+	if math.IsNaN(fast) || math.IsNaN(slow) {
+		return nil
+	}
+
+	po := fast - slow
+	if k.ID == ppoPluginID {
+		po = 0
+		if !plugins.IsZero(slow) {
+			po = ((fast - slow) / slow) * 100
+		}
+	}
+	return map[string]float64{k.Output: po}
+}
+
+func (k *poKernel) Warmup() int {
+	return max(k.Fast.Warmup(), k.Slow.Warmup())
+}
+
+func newPO(id, name, description, hcl string, opts []internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	fast, slow := plugins.Period(params, "fastPeriod", 12), plugins.Period(params, "slowPeriod", 26)
+	if slow < fast {
+		fast, slow = slow, fast
+	}
+	maType, output := params.MAType(ma.SMA), params.Output(strings.ToLower(id))
+
+	return plugins.NewKernelIndicator(id, name, description, hcl, params, price(params), func() plugins.Kernel {
+		return &poKernel{
+			ID:     id,
+			Fast:   ma.Select(params, maType, fast),
+			Slow:   ma.Select(params, maType, slow),
+			Output: output,
+		}
+	})
+}
+
+func apoNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newPO(apoPluginID, apoPluginName, apoPluginDescription, apoPluginHCL, opts)
+}
+
+func ppoNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newPO(ppoPluginID, ppoPluginName, ppoPluginDescription, ppoPluginHCL, opts)
+}
+
+func init() {
+	indicators.Add(apoPluginID, apoNew, indicators.MOMENTUM)
+	indicators.Add(ppoPluginID, ppoNew, indicators.MOMENTUM)
+}
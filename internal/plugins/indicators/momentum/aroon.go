@@ -0,0 +1,83 @@
+package momentum
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// AroonUp = 100 * (n - PeriodsSinceHighestHigh) / n
+// AroonDown = 100 * (n - PeriodsSinceLowestLow) / n
+// AROONOSC = AroonUp - AroonDown
+const aroonPluginID = "AROON"
+const aroonPluginName = "Aroon"
+const aroonPluginDescription = "Aroon measures the time since the highest high and the lowest low of the period as the Aroon up and down lines."
+const aroonPluginHCL = `
+indicator "aroon" {
+  period = 14
+}
+`
+
+const aroonoscPluginID = "AROONOSC"
+const aroonoscPluginName = "Aroon Oscillator"
+const aroonoscPluginDescription = "Aroon Oscillator is the difference of the Aroon up and down lines, between -100 and 100."
+const aroonoscPluginHCL = `
+indicator "aroonosc" {
+  period = 14
+}
+`
+
+// aroonKernel keeps the highs and lows of the last Period+1 bars, the newest
+// extreme counts on ties.
+type aroonKernel struct {
+	High, Low plugins.Ring
+	Output    string // output of the oscillator, empty for the up and down lines
+}
+
+func (k *aroonKernel) Add(values []float64) map[string]float64 {
+	k.High.Push(values[0])
+	k.Low.Push(values[1])
+	if !k.High.Full() {
+		return nil
+	}
+
+	n := len(k.High.Values) - 1
+	factor := 100 / float64(n)
+	_, high := k.High.Highest()
+	_, low := k.Low.Lowest()
+	if k.Output != "" {
+		return map[string]float64{k.Output: factor * float64(low-high)}
+	}
+	return map[string]float64{
+		"aroondown": factor * float64(n-low),
+		"aroonup":   factor * float64(n-high),
+	}
+}
+
+func (k *aroonKernel) Warmup() int {
+	return len(k.High.Values)
+}
+
+func aroonNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period := plugins.Period(params, "period", 14)
+	return plugins.NewKernelIndicator(aroonPluginID, aroonPluginName, aroonPluginDescription, aroonPluginHCL, params, plugins.Bar(params, "high", "low"), func() plugins.Kernel {
+		return &aroonKernel{High: plugins.NewRing(period + 1), Low: plugins.NewRing(period + 1)}
+	})
+}
+
+func aroonoscNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, output := plugins.Period(params, "period", 14), params.Output(strings.ToLower(aroonoscPluginID))
+	return plugins.NewKernelIndicator(aroonoscPluginID, aroonoscPluginName, aroonoscPluginDescription, aroonoscPluginHCL, params, plugins.Bar(params, "high", "low"), func() plugins.Kernel {
+		return &aroonKernel{High: plugins.NewRing(period + 1), Low: plugins.NewRing(period + 1), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(aroonPluginID, aroonNew, indicators.MOMENTUM)
+	indicators.Add(aroonoscPluginID, aroonoscNew, indicators.MOMENTUM)
+}
@@ -0,0 +1,46 @@
+package momentum
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// BOP = (Close - Open) / (High - Low)
+const bopPluginID = "BOP"
+const bopPluginName = "Balance Of Power"
+const bopPluginDescription = "Balance Of Power is the move from open to close relative to the range of the bar."
+const bopPluginHCL = `
+indicator "bop" {}
+`
+
+type bopKernel struct {
+	Output string
+}
+
+func (k *bopKernel) Add(values []float64) map[string]float64 {
+	bop := 0.0
+	if diff := values[1] - values[2]; diff > 1e-8 {
+		bop = (values[3] - values[0]) / diff
+	}
+	return map[string]float64{k.Output: bop}
+}
+
+func (k *bopKernel) Warmup() int {
+	return 1
+}
+
+func bopNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	output := params.Output(strings.ToLower(bopPluginID))
+	return plugins.NewKernelIndicator(bopPluginID, bopPluginName, bopPluginDescription, bopPluginHCL, params, plugins.Bar(params, "open", "high", "low", "close"), func() plugins.Kernel {
+		return &bopKernel{Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(bopPluginID, bopNew, indicators.MOMENTUM)
+}
@@ -0,0 +1,65 @@
+package momentum
+
+import (
+	"math"
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// TP = (High + Low + Close) / 3
+// CCI = (TP - SMA(TP)) / (0.015 * MeanDeviation(TP))
+const cciPluginID = "CCI"
+const cciPluginName = "Commodity Channel Index"
+const cciPluginDescription = "Commodity Channel Index measures the deviation of the typical price from its average in units of the mean deviation."
+const cciPluginHCL = `
+indicator "cci" {
+  period = 14
+}
+`
+
+type cciKernel struct {
+	Prices plugins.Ring
+	Output string
+}
+
+func (k *cciKernel) Add(values []float64) map[string]float64 {
+	tp := (values[0] + values[1] + values[2]) / 3
+	k.Prices.Push(tp)
+	if !k.Prices.Full() {
+		return nil
+	}
+
+	n := float64(k.Prices.Len)
+	mean := k.Prices.Sum() / n
+	deviation := 0.0
+	for j := k.Prices.Len - 1; j >= 0; j-- {
+		deviation += math.Abs(k.Prices.At(j) - mean)
+	}
+	deviation /= n
+
+	cci := 0.0
+	if diff := tp - mean; diff != 0 && deviation != 0 {
+		cci = diff / (0.015 * deviation)
+	}
+	return map[string]float64{k.Output: cci}
+}
+
+func (k *cciKernel) Warmup() int {
+	return len(k.Prices.Values)
+}
+
+func cciNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, output := params.Period(14), params.Output(strings.ToLower(cciPluginID))
+	return plugins.NewKernelIndicator(cciPluginID, cciPluginName, cciPluginDescription, cciPluginHCL, params, plugins.Bar(params, "high", "low", "close"), func() plugins.Kernel {
+		return &cciKernel{Prices: plugins.NewRing(period), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(cciPluginID, cciNew, indicators.MOMENTUM)
+}
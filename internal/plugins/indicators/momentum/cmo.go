@@ -0,0 +1,54 @@
+package momentum
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// CMO = 100 * (AvgGain - AvgLoss) / (AvgGain + AvgLoss)
+// where the average gain and loss use Wilder smoothing as in the RSI
+const cmoPluginID = "CMO"
+const cmoPluginName = "Chande Momentum Oscillator"
+const cmoPluginDescription = "Chande Momentum Oscillator is the difference of the average gain and loss relative to their sum, between -100 and 100."
+const cmoPluginHCL = `
+indicator "cmo" {
+  period = 14
+}
+`
+
+type cmoKernel struct {
+	Wilder wilder
+	Output string
+}
+
+func (k *cmoKernel) Add(values []float64) map[string]float64 {
+	w := &k.Wilder
+	if !w.add(values[0]) {
+		return nil
+	}
+	cmo := 0.0
+	if sum := w.Gain + w.Loss; !plugins.IsZero(sum) {
+		cmo = 100 * ((w.Gain - w.Loss) / sum)
+	}
+	return map[string]float64{k.Output: cmo}
+}
+
+func (k *cmoKernel) Warmup() int {
+	return k.Wilder.Period + 1
+}
+
+func cmoNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, output := plugins.Period(params, "period", 14), params.Output(strings.ToLower(cmoPluginID))
+	return plugins.NewKernelIndicator(cmoPluginID, cmoPluginName, cmoPluginDescription, cmoPluginHCL, params, price(params), func() plugins.Kernel {
+		return &cmoKernel{Wilder: wilder{Period: period}, Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(cmoPluginID, cmoNew, indicators.MOMENTUM)
+}
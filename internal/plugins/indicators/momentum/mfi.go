@@ -0,0 +1,74 @@
+package momentum
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// TP = (High + Low + Close) / 3, MoneyFlow = TP * Volume
+// MFI = 100 * PositiveFlow / (PositiveFlow + NegativeFlow)
+// where the flows are summed over the period by the direction of TP
+const mfiPluginID = "MFI"
+const mfiPluginName = "Money Flow Index"
+const mfiPluginDescription = "Money Flow Index is a volume weighted RSI, the share of the money flow on rising typical prices."
+const mfiPluginHCL = `
+indicator "mfi" {
+  period = 14
+}
+`
+
+type mfiKernel struct {
+	Count              int
+	Prev               float64 // previous typical price
+	Positive, Negative plugins.Ring
+	Output             string
+}
+
+func (k *mfiKernel) Add(values []float64) map[string]float64 {
+	tp := (values[0] + values[1] + values[2]) / 3
+	prev := k.Prev
+	k.Prev = tp
+	if k.Count++; k.Count == 1 {
+		return nil
+	}
+
+	flow, positive, negative := tp*values[3], 0.0, 0.0
+	switch {
+	case tp > prev:
+		positive = flow
+	case tp < prev:
+		negative = flow
+	}
+	k.Positive.Push(positive)
+	k.Negative.Push(negative)
+	if !k.Positive.Full() {
+		return nil
+	}
+
+	mfi := 0.0
+	positive, negative = k.Positive.Sum(), k.Negative.Sum()
+	if total := positive + negative; total >= 1 {
+		mfi = 100 * (positive / total)
+	}
+	return map[string]float64{k.Output: mfi}
+}
+
+func (k *mfiKernel) Warmup() int {
+	return len(k.Positive.Values) + 1
+}
+
+func mfiNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, output := params.Period(14), params.Output(strings.ToLower(mfiPluginID))
+	return plugins.NewKernelIndicator(mfiPluginID, mfiPluginName, mfiPluginDescription, mfiPluginHCL, params, plugins.Bar(params, "high", "low", "close", "volume"), func() plugins.Kernel {
+		return &mfiKernel{Positive: plugins.NewRing(period), Negative: plugins.NewRing(period), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(mfiPluginID, mfiNew, indicators.MOMENTUM)
+}
@@ -0,0 +1,24 @@
+package momentum
+
+import (
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// MOM = Price - Price[n]
+const momPluginID = "MOM"
+const momPluginName = "Momentum"
+const momPluginDescription = "Momentum is the change of the price over the period."
+const momPluginHCL = `
+indicator "mom" {
+  period = 10
+}
+`
+
+func momNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newROC(momPluginID, momPluginName, momPluginDescription, momPluginHCL, opts)
+}
+
+func init() {
+	indicators.Add(momPluginID, momNew, indicators.MOMENTUM)
+}
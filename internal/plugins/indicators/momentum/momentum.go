@@ -0,0 +1,61 @@
+package momentum
+
+import (
+	"encoding/gob"
+
+	"github.com/rangertaha/gotal/internal"
+)
+
+func init() {
+	gob.Register(&rsiKernel{})
+	gob.Register(&cmoKernel{})
+	gob.Register(&stochKernel{})
+	gob.Register(&stochRSIKernel{})
+	gob.Register(&cciKernel{})
+	gob.Register(&mfiKernel{})
+	gob.Register(&willrKernel{})
+	gob.Register(&rocKernel{})
+	gob.Register(&poKernel{})
+	gob.Register(&bopKernel{})
+	gob.Register(&aroonKernel{})
+}
+
+// price returns the input field of indicators over one value, "value" by default.
+func price(params internal.Options) []string {
+	return []string{params.Field("value")}
+}
+
+// wilder is the Wilder smoothing of the gains and losses of a value, seeded
+// with their simple averages over the first Period changes.
+type wilder struct {
+	Period     int
+	Count      int
+	Prev       float64
+	Gain, Loss float64
+}
+
+// add adds a value and reports whether the averages are warmed up.
+func (w *wilder) add(value float64) bool {
+	w.Count++
+	prev := w.Prev
+	w.Prev = value
+	if w.Count == 1 {
+		return false
+	}
+
+	gain, loss := max(value-prev, 0), max(prev-value, 0)
+	n := float64(w.Period)
+	switch {
+	case w.Count <= w.Period:
+		w.Gain += gain
+		w.Loss += loss
+		return false
+	case w.Count == w.Period+1:
+		w.Gain = (w.Gain + gain) / n
+		w.Loss = (w.Loss + loss) / n
+	default:
+		w.Gain = (w.Gain*(n-1) + gain) / n
+		w.Loss = (w.Loss*(n-1) + loss) / n
+	}
+	return true
+}
@@ -0,0 +1,341 @@
+package momentum
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+	"github.com/rangertaha/gotal/internal/stream"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// The references below follow the TA-Lib definitions directly, recomputing
+// each output from the whole window.
+
+func window(xs []float64, end, n int) []float64 {
+	return xs[end-n+1 : end+1]
+}
+
+func highest(xs []float64) (value float64, ago int) {
+	value = math.Inf(-1)
+	for i, x := range xs {
+		if x >= value {
+			value, ago = x, len(xs)-1-i
+		}
+	}
+	return
+}
+
+func lowest(xs []float64) (value float64, ago int) {
+	value = math.Inf(1)
+	for i, x := range xs {
+		if x <= value {
+			value, ago = x, len(xs)-1-i
+		}
+	}
+	return
+}
+
+func mean(xs []float64) float64 {
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// refWilder returns the Wilder averages of the gains and losses, from the
+// change at index n on.
+func refWilder(xs []float64, n int) (gains, losses []float64) {
+	gains, losses = indicatortest.Changes(xs)
+	return indicatortest.Wilder(gains, n), indicatortest.Wilder(losses, n)
+}
+
+func refRSI(xs []float64, n int) []float64 {
+	gains, losses := refWilder(xs, n)
+	out := make([]float64, len(gains))
+	for i := range out {
+		if sum := gains[i] + losses[i]; math.Abs(sum) >= 1e-8 {
+			out[i] = 100 * gains[i] / sum
+		}
+	}
+	return out
+}
+
+func refCMO(xs []float64, n int) []float64 {
+	gains, losses := refWilder(xs, n)
+	out := make([]float64, len(gains))
+	for i := range out {
+		if sum := gains[i] + losses[i]; math.Abs(sum) >= 1e-8 {
+			out[i] = 100 * (gains[i] - losses[i]) / sum
+		}
+	}
+	return out
+}
+
+func refFastK(high, low, close []float64, n int) []float64 {
+	var out []float64
+	for i := n - 1; i < len(close); i++ {
+		hh, _ := highest(window(high, i, n))
+		ll, _ := lowest(window(low, i, n))
+		k := 0.0
+		if hh != ll {
+			k = 100 * (close[i] - ll) / (hh - ll)
+		}
+		out = append(out, k)
+	}
+	return out
+}
+
+func refCCI(b indicatortest.Bars, n int) []float64 {
+	tp := make([]float64, len(b.Close))
+	for i := range tp {
+		tp[i] = (b.High[i] + b.Low[i] + b.Close[i]) / 3
+	}
+	var out []float64
+	for i := n - 1; i < len(tp); i++ {
+		w := window(tp, i, n)
+		avg, md := mean(w), 0.0
+		for _, x := range w {
+			md += math.Abs(x - avg)
+		}
+		md /= float64(n)
+		cci := 0.0
+		if md != 0 {
+			cci = (tp[i] - avg) / (0.015 * md)
+		}
+		out = append(out, cci)
+	}
+	return out
+}
+
+func refMFI(b indicatortest.Bars, n int) []float64 {
+	tp := make([]float64, len(b.Close))
+	for i := range tp {
+		tp[i] = (b.High[i] + b.Low[i] + b.Close[i]) / 3
+	}
+	var out []float64
+	for i := n; i < len(tp); i++ {
+		positive, negative := 0.0, 0.0
+		for j := i - n + 1; j <= i; j++ {
+			switch {
+			case tp[j] > tp[j-1]:
+				positive += tp[j] * b.Volume[j]
+			case tp[j] < tp[j-1]:
+				negative += tp[j] * b.Volume[j]
+			}
+		}
+		mfi := 0.0
+		if positive+negative >= 1 {
+			mfi = 100 * positive / (positive + negative)
+		}
+		out = append(out, mfi)
+	}
+	return out
+}
+
+func refWILLR(b indicatortest.Bars, n int) []float64 {
+	var out []float64
+	for i := n - 1; i < len(b.Close); i++ {
+		hh, _ := highest(window(b.High, i, n))
+		ll, _ := lowest(window(b.Low, i, n))
+		r := 0.0
+		if hh != ll {
+			r = -100 * (hh - b.Close[i]) / (hh - ll)
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func refChange(xs []float64, n int, fn func(price, prev float64) float64) []float64 {
+	var out []float64
+	for i := n; i < len(xs); i++ {
+		out = append(out, fn(xs[i], xs[i-n]))
+	}
+	return out
+}
+
+func refAroon(b indicatortest.Bars, n int) (down, up []float64) {
+	for i := n; i < len(b.Close); i++ {
+		_, high := highest(window(b.High, i, n+1))
+		_, low := lowest(window(b.Low, i, n+1))
+		up = append(up, 100*float64(n-high)/float64(n))
+		down = append(down, 100*float64(n-low)/float64(n))
+	}
+	return
+}
+
+func TestMomentum(t *testing.T) {
+	b := indicatortest.RandomBars(150, 3)
+	input := b.Series(time.Minute)
+	ratio := func(fn func(price, prev float64) float64) func(price, prev float64) float64 {
+		return func(price, prev float64) float64 {
+			if prev == 0 {
+				return 0
+			}
+			return fn(price, prev)
+		}
+	}
+
+	for _, n := range []int{1, 2, 5, 14} {
+		fastK := refFastK(b.High, b.Low, b.Close, n)
+		slowK := indicatortest.SMA(fastK, 3)
+		rsi := refRSI(b.Close, n)
+		stochRSI := refFastK(rsi, rsi, rsi, 5)
+		down, up := refAroon(b, n)
+		osc := make([]float64, len(up))
+		for i := range osc {
+			osc[i] = up[i] - down[i]
+		}
+
+		for _, test := range []struct {
+			new   func(...internal.PluginOptions) internal.Plugin
+			opts  []internal.PluginOptions
+			field string
+			want  []float64
+		}{
+			{rsiNew, nil, "rsi", rsi},
+			{cmoNew, nil, "cmo", refCMO(b.Close, n)},
+			{stochfNew, []internal.PluginOptions{opt.With("fastk", n)}, "fastk", fastK[2:]},
+			{stochfNew, []internal.PluginOptions{opt.With("fastk", n)}, "fastd", indicatortest.SMA(fastK, 3)},
+			{stochNew, []internal.PluginOptions{opt.With("fastk", n)}, "slowk", slowK[2:]},
+			{stochNew, []internal.PluginOptions{opt.With("fastk", n)}, "slowd", indicatortest.SMA(slowK, 3)},
+			{stochrsiNew, nil, "fastk", stochRSI[2:]},
+			{stochrsiNew, nil, "fastd", indicatortest.SMA(stochRSI, 3)},
+			{cciNew, nil, "cci", refCCI(b, n)},
+			{mfiNew, nil, "mfi", refMFI(b, n)},
+			{willrNew, nil, "willr", refWILLR(b, n)},
+			{momNew, nil, "mom", refChange(b.Close, n, func(p, q float64) float64 { return p - q })},
+			{rocNew, nil, "roc", refChange(b.Close, n, ratio(func(p, q float64) float64 { return (p/q - 1) * 100 }))},
+			{rocpNew, nil, "rocp", refChange(b.Close, n, ratio(func(p, q float64) float64 { return (p - q) / q }))},
+			{rocrNew, nil, "rocr", refChange(b.Close, n, ratio(func(p, q float64) float64 { return p / q }))},
+			{rocr100New, nil, "rocr100", refChange(b.Close, n, ratio(func(p, q float64) float64 { return p / q * 100 }))},
+			{aroonNew, nil, "aroonup", up},
+			{aroonNew, nil, "aroondown", down},
+			{aroonoscNew, nil, "aroonosc", osc},
+		} {
+			i := test.new(append([]internal.PluginOptions{opt.WithPeriod(n)}, test.opts...)...).(internal.Indicator)
+			name := fmt.Sprintf("%s(%d) %s", i.ID(), n, test.field)
+			indicatortest.Near(t, name, indicatortest.Fields(i.Compute(input), test.field), test.want)
+			if warmup := len(b.Close) - len(test.want) + 1; i.Warmup() != warmup {
+				t.Errorf("%s: Warmup() = %d, want %d", name, i.Warmup(), warmup)
+			}
+		}
+	}
+}
+
+func TestPriceOscillators(t *testing.T) {
+	b := indicatortest.RandomBars(100, 3)
+	input := b.Series(time.Minute)
+	fast, slow := indicatortest.SMA(b.Close, 4), indicatortest.SMA(b.Close, 10)
+	fast = fast[len(fast)-len(slow):]
+
+	apo, ppo := make([]float64, len(slow)), make([]float64, len(slow))
+	for i := range slow {
+		apo[i] = fast[i] - slow[i]
+		ppo[i] = 100 * (fast[i] - slow[i]) / slow[i]
+	}
+
+	// the periods are swapped when slow is the shorter
+	indicatortest.Near(t, "APO", indicatortest.Fields(apoNew(opt.WithFastPeriod(10), opt.WithSlowPeriod(4)).Compute(input), "apo"), apo)
+	indicatortest.Near(t, "PPO", indicatortest.Fields(ppoNew(opt.WithFastPeriod(4), opt.WithSlowPeriod(10)).Compute(input), "ppo"), ppo)
+
+	// an EMA fast line is seeded from the same values as the slow one
+	ema := apoNew(opt.WithFastPeriod(4), opt.WithSlowPeriod(10), opt.WithMAType("ema")).(internal.Indicator)
+	if output := ema.Compute(input); output.Len() != len(slow) || ema.Warmup() != 10 {
+		t.Errorf("EMA APO: got %d outputs with Warmup() %d, want %d and 10", output.Len(), ema.Warmup(), len(slow))
+	}
+	if err := apoNew(opt.WithMAType("unknown")).Init(); err == nil {
+		t.Error("Init() with an unknown type succeeded")
+	}
+	if err := apoNew(opt.WithFastPeriod(0)).Init(); err == nil {
+		t.Error("Init() with a fast period of 0 succeeded")
+	}
+	if err := ppoNew(opt.WithSlowPeriod(0)).Init(); err == nil {
+		t.Error("Init() with a slow period of 0 succeeded")
+	}
+}
+
+func TestPeriods(t *testing.T) {
+	for id, new := range map[string]func(...internal.PluginOptions) internal.Plugin{
+		rsiPluginID: rsiNew, cmoPluginID: cmoNew, aroonPluginID: aroonNew, aroonoscPluginID: aroonoscNew,
+	} {
+		if err := new(opt.WithPeriod(0)).Init(); err == nil {
+			t.Errorf("%s: Init() with a period of 0 succeeded", id)
+		}
+		if err := new(opt.WithPeriod(2)).Init(); err != nil {
+			t.Errorf("%s: Init() = %v", id, err)
+		}
+	}
+}
+
+func TestBOP(t *testing.T) {
+	b := indicatortest.RandomBars(50, 3)
+	b.High[3], b.Low[3] = b.Open[3], b.Open[3]
+
+	want := make([]float64, len(b.Close))
+	for i := range want {
+		if b.High[i] > b.Low[i] {
+			want[i] = (b.Close[i] - b.Open[i]) / (b.High[i] - b.Low[i])
+		}
+	}
+	indicatortest.Near(t, "BOP", indicatortest.Fields(bopNew().Compute(b.Series(time.Minute)), "bop"), want)
+}
+
+func constructors() map[string]func(...internal.PluginOptions) internal.Plugin {
+	return map[string]func(...internal.PluginOptions) internal.Plugin{
+		rsiPluginID: rsiNew, cmoPluginID: cmoNew, stochPluginID: stochNew, stochfPluginID: stochfNew,
+		stochrsiPluginID: stochrsiNew, cciPluginID: cciNew, mfiPluginID: mfiNew, willrPluginID: willrNew,
+		rocPluginID: rocNew, rocpPluginID: rocpNew, rocrPluginID: rocrNew, rocr100PluginID: rocr100New,
+		momPluginID: momNew, apoPluginID: apoNew, ppoPluginID: ppoNew, bopPluginID: bopNew,
+		aroonPluginID: aroonNew, aroonoscPluginID: aroonoscNew,
+	}
+}
+
+func same(a, b *tick.Tick) bool {
+	if a.UnixNano() != b.UnixNano() || len(a.Fields()) != len(b.Fields()) {
+		return false
+	}
+	for field, value := range a.Fields() {
+		if b.GetField(field) != value {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMomentumSnapshot(t *testing.T) {
+	input := indicatortest.RandomBars(80, 3).Series(time.Minute)
+	for id, new := range constructors() {
+		t.Run(id, func(t *testing.T) {
+			indicatortest.SnapshotReplay(t, func() internal.Indicator {
+				return new(opt.WithPeriod(6), opt.WithMAType("ema")).(internal.Indicator)
+			}, input, 40)
+		})
+	}
+}
+
+func TestMomentumStream(t *testing.T) {
+	input := indicatortest.RandomBars(60, 3).Series(time.Minute)
+	batch := rsiNew(opt.WithPeriod(5)).Compute(input)
+
+	live := plugins.Stream(rsiNew(opt.WithPeriod(5)).(internal.Indicator), stream.New("bars", stream.WithTicks(input.Ticks()...)))
+	outputs, err := live.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != batch.Len() {
+		t.Fatalf("stream produced %d outputs, batch %d", len(outputs), batch.Len())
+	}
+	for i, out := range outputs {
+		if !same(out, batch.At(i)) {
+			t.Errorf("output %d: stream %v, batch %v", i, out.Fields(), batch.At(i).Fields())
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package momentum
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// ROC = 100 * (Price / Price[n] - 1)
+// ROCP = (Price - Price[n]) / Price[n]
+// ROCR = Price / Price[n]
+// ROCR100 = 100 * Price / Price[n]
+const rocPluginID = "ROC"
+const rocPluginName = "Rate of Change"
+const rocPluginDescription = "Rate of Change is the percentage change of the price over the period."
+const rocPluginHCL = `
+indicator "roc" {
+  period = 10
+}
+`
+
+const rocpPluginID = "ROCP"
+const rocpPluginName = "Rate of Change Percentage"
+const rocpPluginDescription = "Rate of Change Percentage is the change of the price over the period as a fraction of the earlier price."
+const rocpPluginHCL = `
+indicator "rocp" {
+  period = 10
+}
+`
+
+const rocrPluginID = "ROCR"
+const rocrPluginName = "Rate of Change Ratio"
+const rocrPluginDescription = "Rate of Change Ratio is the ratio of the price to the price a period earlier."
+const rocrPluginHCL = `
+indicator "rocr" {
+  period = 10
+}
+`
+
+const rocr100PluginID = "ROCR100"
+const rocr100PluginName = "Rate of Change Ratio 100 Scale"
+const rocr100PluginDescription = "Rate of Change Ratio 100 Scale is the ratio of the price to the price a period earlier, times 100."
+const rocr100PluginHCL = `
+indicator "rocr100" {
+  period = 10
+}
+`
+
+// rocKernel compares the price to the price Period values earlier, as the
+// indicator of the ID. The ratios are zero when the earlier price is.
+type rocKernel struct {
+	ID     string
+	Prices plugins.Ring
+	Output string
+}
+
+func (k *rocKernel) Add(values []float64) map[string]float64 {
+	k.Prices.Push(values[0])
+	if !k.Prices.Full() {
+		return nil
+	}
+
+	price, prev := values[0], k.Prices.At(k.Prices.Len-1)
+	change := 0.0
+	switch {
+	case k.ID == momPluginID:
+		change = price - prev
+	case prev == 0:
+	case k.ID == rocPluginID:
+		change = (price/prev - 1) * 100
+	case k.ID == rocpPluginID:
+		change = (price - prev) / prev
+	case k.ID == rocrPluginID:
+		change = price / prev
+	case k.ID == rocr100PluginID:
+		change = (price / prev) * 100
+	}
+	return map[string]float64{k.Output: change}
+}
+
+func (k *rocKernel) Warmup() int {
+	return len(k.Prices.Values)
+}
+
+func newROC(id, name, description, hcl string, opts []internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, output := params.Period(10), params.Output(strings.ToLower(id))
+	return plugins.NewKernelIndicator(id, name, description, hcl, params, price(params), func() plugins.Kernel {
+		return &rocKernel{ID: id, Prices: plugins.NewRing(period + 1), Output: output}
+	})
+}
+
+func rocNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newROC(rocPluginID, rocPluginName, rocPluginDescription, rocPluginHCL, opts)
+}
+
+func rocpNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newROC(rocpPluginID, rocpPluginName, rocpPluginDescription, rocpPluginHCL, opts)
+}
+
+func rocrNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newROC(rocrPluginID, rocrPluginName, rocrPluginDescription, rocrPluginHCL, opts)
+}
+
+func rocr100New(opts ...internal.PluginOptions) internal.Plugin {
+	return newROC(rocr100PluginID, rocr100PluginName, rocr100PluginDescription, rocr100PluginHCL, opts)
+}
+
+func init() {
+	indicators.Add(rocPluginID, rocNew, indicators.MOMENTUM)
+	indicators.Add(rocpPluginID, rocpNew, indicators.MOMENTUM)
+	indicators.Add(rocrPluginID, rocrNew, indicators.MOMENTUM)
+	indicators.Add(rocr100PluginID, rocr100New, indicators.MOMENTUM)
+}
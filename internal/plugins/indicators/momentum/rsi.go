@@ -0,0 +1,66 @@
+package momentum
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// RSI = 100 * AvgGain / (AvgGain + AvgLoss)
+// where the average gain and loss use Wilder smoothing over the period
+const rsiPluginID = "RSI"
+const rsiPluginName = "Relative Strength Index"
+const rsiPluginDescription = "Relative Strength Index measures the speed of price changes as the share of the average gain in the average absolute change."
+const rsiPluginHCL = `
+indicator "rsi" {
+  period = 14
+}
+`
+
+type rsiKernel struct {
+	Wilder wilder
+	Output string
+}
+
+func newRSI(period int, output string) *rsiKernel {
+	return &rsiKernel{Wilder: wilder{Period: period}, Output: output}
+}
+
+// rsi adds a value and returns the RSI, ok is false until warmed up.
+func (k *rsiKernel) rsi(value float64) (rsi float64, ok bool) {
+	w := &k.Wilder
+	if !w.add(value) {
+		return 0, false
+	}
+	if sum := w.Gain + w.Loss; !plugins.IsZero(sum) {
+		rsi = 100 * (w.Gain / sum)
+	}
+	return rsi, true
+}
+
+func (k *rsiKernel) Add(values []float64) map[string]float64 {
+	rsi, ok := k.rsi(values[0])
+	if !ok {
+		return nil
+	}
+	return map[string]float64{k.Output: rsi}
+}
+
+func (k *rsiKernel) Warmup() int {
+	return k.Wilder.Period + 1
+}
+
+func rsiNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, output := plugins.Period(params, "period", 14), params.Output(strings.ToLower(rsiPluginID))
+	return plugins.NewKernelIndicator(rsiPluginID, rsiPluginName, rsiPluginDescription, rsiPluginHCL, params, price(params), func() plugins.Kernel {
+		return newRSI(period, output)
+	})
+}
+
+func init() {
+	indicators.Add(rsiPluginID, rsiNew, indicators.MOMENTUM)
+}
@@ -0,0 +1,109 @@
+package momentum
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/ma"
+)
+
+// FastK = 100 * (Close - LowestLow) / (HighestHigh - LowestLow) over the fastk period
+// FastD = MA(FastK, fastd)
+// SlowK = MA(FastK, slowk), SlowD = MA(SlowK, slowd)
+const stochPluginID = "STOCH"
+const stochPluginName = "Stochastic"
+const stochPluginDescription = "Stochastic compares the close to the range of the period, smoothed into the slow %K and %D lines."
+const stochPluginHCL = `
+indicator "stoch" {
+  fastk = 5
+  slowk = 3
+  slowd = 3
+  matype = "SMA"
+}
+`
+
+const stochfPluginID = "STOCHF"
+const stochfPluginName = "Stochastic Fast"
+const stochfPluginDescription = "Stochastic Fast compares the close to the range of the period as the fast %K line, with its average as the %D line."
+const stochfPluginHCL = `
+indicator "stochf" {
+  fastk = 5
+  fastd = 3
+  matype = "SMA"
+}
+`
+
+// stochKernel is the raw %K of the bars smoothed by each average in turn.
+// The outputs are the last two lines.
+type stochKernel struct {
+	High, Low plugins.Ring
+	Averages  []ma.Average
+	Outputs   [2]string
+}
+
+func newStoch(params internal.Options, fastK int, outputs [2]string, periods ...int) *stochKernel {
+	k := &stochKernel{High: plugins.NewRing(fastK), Low: plugins.NewRing(fastK), Outputs: outputs}
+	for _, period := range periods {
+		k.Averages = append(k.Averages, ma.Select(params, params.MAType(ma.SMA), period))
+	}
+	return k
+}
+
+func (k *stochKernel) Add(values []float64) map[string]float64 {
+	k.High.Push(values[0])
+	k.Low.Push(values[1])
+	if !k.High.Full() {
+		return nil
+	}
+
+	highest, _ := k.High.Highest()
+	lowest, _ := k.Low.Lowest()
+	line := 0.0
+	if diff := (highest - lowest) / 100; diff != 0 {
+		line = (values[2] - lowest) / diff
+	}
+
+	lines := []float64{line}
+	for _, a := range k.Averages {
+		if line = a.Add(line); math.IsNaN(line) {
+			return nil
+		}
+		lines = append(lines, line)
+	}
+	return map[string]float64{
+		k.Outputs[0]: lines[len(lines)-2],
+		k.Outputs[1]: lines[len(lines)-1],
+	}
+}
+
+func (k *stochKernel) Warmup() int {
+	warmup := len(k.High.Values)
+	for _, a := range k.Averages {
+		warmup += a.Warmup() - 1
+	}
+	return warmup
+}
+
+func stochNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	fastK, slowK, slowD := params.Int("fastk", 5), params.Int("slowk", 3), params.Int("slowd", 3)
+	return plugins.NewKernelIndicator(stochPluginID, stochPluginName, stochPluginDescription, stochPluginHCL, params, plugins.Bar(params, "high", "low", "close"), func() plugins.Kernel {
+		return newStoch(params, fastK, [2]string{"slowk", "slowd"}, slowK, slowD)
+	})
+}
+
+func stochfNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	fastK, fastD := params.Int("fastk", 5), params.Int("fastd", 3)
+	return plugins.NewKernelIndicator(stochfPluginID, stochfPluginName, stochfPluginDescription, stochfPluginHCL, params, plugins.Bar(params, "high", "low", "close"), func() plugins.Kernel {
+		return newStoch(params, fastK, [2]string{"fastk", "fastd"}, fastD)
+	})
+}
+
+func init() {
+	indicators.Add(stochPluginID, stochNew, indicators.MOMENTUM)
+	indicators.Add(stochfPluginID, stochfNew, indicators.MOMENTUM)
+}
@@ -0,0 +1,53 @@
+package momentum
+
+import (
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// STOCHRSI = STOCHF(RSI, RSI, RSI), the fast stochastic of the RSI over the fastk period
+const stochrsiPluginID = "STOCHRSI"
+const stochrsiPluginName = "Stochastic Relative Strength Index"
+const stochrsiPluginDescription = "Stochastic Relative Strength Index is the fast stochastic of the RSI, showing where the RSI is within its recent range."
+const stochrsiPluginHCL = `
+indicator "stochrsi" {
+  period = 14
+  fastk = 5
+  fastd = 3
+  matype = "SMA"
+}
+`
+
+type stochRSIKernel struct {
+	RSI   *rsiKernel
+	Stoch *stochKernel
+}
+
+func (k *stochRSIKernel) Add(values []float64) map[string]float64 {
+	rsi, ok := k.RSI.rsi(values[0])
+	if !ok {
+		return nil
+	}
+	return k.Stoch.Add([]float64{rsi, rsi, rsi})
+}
+
+func (k *stochRSIKernel) Warmup() int {
+	return k.RSI.Warmup() + k.Stoch.Warmup() - 1
+}
+
+func stochrsiNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, fastK, fastD := params.Period(14), params.Int("fastk", 5), params.Int("fastd", 3)
+	return plugins.NewKernelIndicator(stochrsiPluginID, stochrsiPluginName, stochrsiPluginDescription, stochrsiPluginHCL, params, price(params), func() plugins.Kernel {
+		return &stochRSIKernel{
+			RSI:   newRSI(period, ""),
+			Stoch: newStoch(params, fastK, [2]string{"fastk", "fastd"}, fastD),
+		}
+	})
+}
+
+func init() {
+	indicators.Add(stochrsiPluginID, stochrsiNew, indicators.MOMENTUM)
+}
@@ -0,0 +1,451 @@
+package momentum
+
+import (
+	"testing"
+
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+)
+
+// talibOutputs are the TA-Lib outputs of the indicators with the default parameters.
+var talibOutputs = []indicatortest.TALibCase{
+	{Name: rsiPluginID, New: rsiNew, Field: "rsi", Want: []float64{
+		69.03807615, 62.74166433, 65.87692460, 60.69289707, 60.69289707,
+		62.76499790, 62.34030529, 59.38338626, 62.33935374, 66.87269914,
+		70.20807797, 70.20807797, 72.41219844, 59.08589312, 63.27856085,
+		55.22868914, 55.22868914, 55.22868914, 50.05881105, 50.05881105,
+		48.05237002, 48.05237002, 48.05237002, 48.85702654, 48.85702654,
+		48.85702654, 45.27386609, 46.67768676, 44.78084790, 54.69436992,
+		49.15720947, 56.33384217, 48.96439303, 52.25995328, 51.17063046,
+		52.93159457, 53.29438279, 57.96935692, 57.96935692, 48.26819584,
+		48.26819584, 51.74027717, 43.54275787, 52.57398920, 49.95768705,
+		49.95768705, 52.13846100, 53.73085616, 43.87077324, 43.87077324,
+		47.96361000, 44.79112435, 52.06540122, 45.25136339, 42.00648890,
+		37.71445464, 41.75801763, 34.10902874, 38.33103243, 35.77846672,
+		35.77846672, 40.38280594, 41.99793643, 41.99793643, 41.99793643,
+		41.99793643, 38.02955068, 43.25399617, 43.52386430, 46.27539429,
+		41.68268514, 47.71456380, 47.71456380, 48.08091914, 50.32973647,
+		50.32973647, 40.36120027, 47.39754550, 47.39754550, 53.80404899,
+		62.68006810, 63.01767056, 63.01767056, 68.61526596, 69.91342604,
+		73.42137464,
+	}},
+	{Name: cmoPluginID, New: cmoNew, Field: "cmo", Want: []float64{
+		38.07615230, 25.48332866, 31.75384919, 21.38579414, 21.38579414,
+		25.52999580, 24.68061058, 18.76677252, 24.67870748, 33.74539828,
+		40.41615594, 40.41615594, 44.82439688, 18.17178625, 26.55712169,
+		10.45737828, 10.45737828, 10.45737828, 0.11762209, 0.11762209,
+		-3.89525996, -3.89525996, -3.89525996, -2.28594692, -2.28594692,
+		-2.28594692, -9.45226782, -6.64462647, -10.43830419, 9.38873984,
+		-1.68558107, 12.66768433, -2.07121393, 4.51990657, 2.34126092,
+		5.86318913, 6.58876558, 15.93871384, 15.93871384, -3.46360832,
+		-3.46360832, 3.48055434, -12.91448426, 5.14797840, -0.08462589,
+		-0.08462589, 4.27692200, 7.46171231, -12.25845351, -12.25845351,
+		-4.07278001, -10.41775131, 4.13080245, -9.49727322, -15.98702220,
+		-24.57109073, -16.48396474, -31.78194252, -23.33793515, -28.44306656,
+		-28.44306656, -19.23438813, -16.00412714, -16.00412714, -16.00412714,
+		-16.00412714, -23.94089863, -13.49200767, -12.95227139, -7.44921143,
+		-16.63462971, -4.57087239, -4.57087239, -3.83816172, 0.65947295,
+		0.65947295, -19.27759945, -5.20490901, -5.20490901, 7.60809798,
+		25.36013621, 26.03534113, 26.03534113, 37.23053191, 39.82685209,
+		46.84274929,
+	}},
+	{Name: stochPluginID, New: stochNew, Field: "slowk", Want: []float64{
+		46.76934180, 59.51345085, 82.93838418, 86.51280881, 86.83256608,
+		83.31161460, 83.98650674, 78.89447236, 77.23273229, 60.49930671,
+		48.95851094, 42.67310789, 48.22544283, 47.70370370, 48.51291628,
+		56.47668190, 74.01357758, 83.77829771, 87.87922560, 69.51141206,
+		57.34203775, 32.88880475, 27.40525722, 15.72327044, 17.04386008,
+		18.36444973, 26.40357206, 33.12210476, 39.84063745, 41.30146082,
+		45.64029596, 49.97913109, 46.65362035, 44.41918812, 39.11541143,
+		51.59925739, 50.35257304, 63.78442854, 54.13793103, 58.72659176,
+		49.83339791, 56.82886432, 58.69486515, 62.91936394, 62.74945534,
+		50.74792804, 38.26056196, 30.69873998, 29.05211913, 38.97957236,
+		40.46296296, 51.51851852, 54.33333333, 62.24242424, 47.58914446,
+		29.71362578, 19.46117648, 24.94837853, 41.86747788, 37.33804476,
+		35.94459869, 17.81993692, 19.85145860, 17.29379491, 26.70385772,
+		23.74973851, 26.07153696, 28.48789685, 43.59967008, 58.99877873,
+		67.52382338, 67.81115880, 56.94452840, 54.99808580, 53.52112676,
+		64.70070423, 58.21009390, 56.41106443, 52.82212885, 57.30989644,
+		60.30632436, 61.87669344, 54.71651207, 56.16284485, 59.13305743,
+		75.39800188, 82.92832309, 89.94955090, 92.89487513, 88.17975713,
+		83.39811637, 79.61667174,
+	}},
+	{Name: stochPluginID, New: stochNew, Field: "slowd", Want: []float64{
+		33.46738844, 43.64009140, 63.07372561, 76.32154795, 85.42791969,
+		85.55232983, 84.71022914, 82.06419790, 80.03790380, 72.20883712,
+		62.23018331, 50.71030851, 46.61902056, 46.20075148, 48.14735427,
+		50.89776729, 59.66772525, 71.42285240, 81.89036696, 80.38964512,
+		71.57755847, 53.24741818, 39.21203324, 25.33911080, 20.05746258,
+		17.04386008, 20.60396062, 25.96337552, 33.12210476, 38.08806768,
+		42.26079808, 45.64029596, 47.42434914, 47.01731319, 43.39607330,
+		45.04461898, 47.02241395, 55.24541966, 56.09164420, 58.88298378,
+		54.23264023, 55.12961800, 55.11904246, 59.48103114, 61.45456148,
+		58.80558244, 50.58598178, 39.90240999, 32.67047369, 32.91014382,
+		36.16488482, 43.65368461, 48.77160494, 56.03142536, 54.72163401,
+		46.51506483, 32.25464891, 24.70772693, 28.75901096, 34.71796706,
+		38.38337378, 30.36752679, 24.53866474, 18.32173015, 21.28303708,
+		22.58246371, 25.50837773, 26.10305744, 32.71970130, 43.69544855,
+		56.70742406, 64.77792030, 64.09317019, 59.91792433, 55.15458032,
+		57.73997226, 58.81064163, 59.77395418, 55.81442906, 55.51436324,
+		56.81278322, 59.83097142, 58.96650996, 57.58535012, 56.67080478,
+		63.56463472, 72.48646080, 82.75862529, 88.59091637, 90.34139439,
+		88.15758288, 83.73151508,
+	}},
+	{Name: stochfPluginID, New: stochfNew, Field: "fastk", Want: []float64{
+		46.93877551, 19.68503937, 73.68421053, 85.17110266, 89.95983936,
+		84.40748441, 86.13037448, 79.39698492, 86.43216080, 70.85427136,
+		74.41176471, 36.23188406, 36.23188406, 55.55555556, 52.88888889,
+		34.66666667, 57.98319328, 76.78018576, 87.27735369, 87.27735369,
+		89.08296943, 32.17391304, 50.76923077, 15.72327044, 15.72327044,
+		15.72327044, 19.68503937, 19.68503937, 39.84063745, 39.84063745,
+		39.84063745, 44.22310757, 52.85714286, 52.85714286, 34.24657534,
+		46.15384615, 36.94581281, 71.69811321, 42.41379310, 77.24137931,
+		42.75862069, 56.17977528, 50.56179775, 63.74501992, 61.77777778,
+		63.23529412, 63.23529412, 25.77319588, 25.77319588, 40.54982818,
+		20.83333333, 55.55555556, 45.00000000, 54.00000000, 64.00000000,
+		68.72727273, 10.04016064, 10.37344398, 37.96992481, 26.50176678,
+		61.13074205, 24.38162544, 22.32142857, 6.75675676, 30.47619048,
+		14.64843750, 34.98694517, 21.61383285, 21.61383285, 42.23602484,
+		66.94915254, 67.81115880, 67.81115880, 67.81115880, 35.21126761,
+		61.97183099, 63.38028169, 68.75000000, 42.50000000, 57.98319328,
+		57.98319328, 55.96330275, 66.97247706, 62.69430052, 34.48275862,
+		71.31147541, 71.60493827, 83.27759197, 93.90243902, 92.66862170,
+		92.11356467, 79.75708502, 78.32369942, 80.76923077,
+	}},
+	{Name: stochfPluginID, New: stochfNew, Field: "fastd", Want: []float64{
+		28.99534198, 24.63748154, 46.76934180, 59.51345085, 82.93838418,
+		86.51280881, 86.83256608, 83.31161460, 83.98650674, 78.89447236,
+		77.23273229, 60.49930671, 48.95851094, 42.67310789, 48.22544283,
+		47.70370370, 48.51291628, 56.47668190, 74.01357758, 83.77829771,
+		87.87922560, 69.51141206, 57.34203775, 32.88880475, 27.40525722,
+		15.72327044, 17.04386008, 18.36444973, 26.40357206, 33.12210476,
+		39.84063745, 41.30146082, 45.64029596, 49.97913109, 46.65362035,
+		44.41918812, 39.11541143, 51.59925739, 50.35257304, 63.78442854,
+		54.13793103, 58.72659176, 49.83339791, 56.82886432, 58.69486515,
+		62.91936394, 62.74945534, 50.74792804, 38.26056196, 30.69873998,
+		29.05211913, 38.97957236, 40.46296296, 51.51851852, 54.33333333,
+		62.24242424, 47.58914446, 29.71362578, 19.46117648, 24.94837853,
+		41.86747788, 37.33804476, 35.94459869, 17.81993692, 19.85145860,
+		17.29379491, 26.70385772, 23.74973851, 26.07153696, 28.48789685,
+		43.59967008, 58.99877873, 67.52382338, 67.81115880, 56.94452840,
+		54.99808580, 53.52112676, 64.70070423, 58.21009390, 56.41106443,
+		52.82212885, 57.30989644, 60.30632436, 61.87669344, 54.71651207,
+		56.16284485, 59.13305743, 75.39800188, 82.92832309, 89.94955090,
+		92.89487513, 88.17975713, 83.39811637, 79.61667174,
+	}},
+	{Name: stochrsiPluginID, New: stochrsiNew, Field: "fastk", Want: []float64{
+		31.77853918, 0.00000000, 87.41297927, 100.00000000, 100.00000000,
+		100.00000000, 100.00000000, 0.00000000, 31.46159137, 0.00000000,
+		0.00000000, 0.00000000, 0.00000000, 0.00000000, 0.00000000,
+		0.00000000, 0.00000000, 40.10367156, 100.00000000, 100.00000000,
+		0.00000000, 39.17828114, 0.00000000, 100.00000000, 44.14537593,
+		100.00000000, 36.21178228, 44.71922108, 29.93761663, 53.83308118,
+		100.00000000, 100.00000000, 100.00000000, 0.00000000, 0.00000000,
+		35.79036882, 0.00000000, 100.00000000, 71.03050460, 71.03050460,
+		95.17753246, 100.00000000, 0.00000000, 0.00000000, 41.50915148,
+		9.33411118, 100.00000000, 16.84750243, 0.00000000, 0.00000000,
+		28.17628070, 0.00000000, 53.46027204, 21.82560338, 21.82560338,
+		100.00000000, 100.00000000, 100.00000000, 100.00000000, 100.00000000,
+		0.00000000, 100.00000000, 100.00000000, 100.00000000, 44.30273767,
+		100.00000000, 100.00000000, 100.00000000, 100.00000000, 100.00000000,
+		0.00000000, 70.58554117, 70.58554117, 100.00000000, 100.00000000,
+		100.00000000, 100.00000000, 100.00000000, 100.00000000, 100.00000000,
+	}},
+	{Name: stochrsiPluginID, New: stochrsiNew, Field: "fastd", Want: []float64{
+		23.91646923, 23.91646923, 39.73050615, 62.47099309, 95.80432642,
+		100.00000000, 100.00000000, 66.66666667, 43.82053046, 10.48719712,
+		10.48719712, 0.00000000, 0.00000000, 0.00000000, 0.00000000,
+		0.00000000, 0.00000000, 13.36789052, 46.70122385, 80.03455719,
+		66.66666667, 46.39276038, 13.05942705, 46.39276038, 48.04845864,
+		81.38179198, 60.11905274, 60.31033445, 36.95620667, 42.82997297,
+		61.25689927, 84.61102706, 100.00000000, 66.66666667, 33.33333333,
+		11.93012294, 11.93012294, 45.26345627, 57.01016820, 80.68700307,
+		79.07951389, 88.73601235, 65.05917749, 33.33333333, 13.83638383,
+		16.94775422, 50.28108755, 42.06053787, 38.94916748, 5.61583414,
+		9.39209357, 9.39209357, 27.21218425, 25.09529181, 32.37049293,
+		47.88373559, 73.94186779, 100.00000000, 100.00000000, 100.00000000,
+		66.66666667, 66.66666667, 66.66666667, 100.00000000, 81.43424589,
+		81.43424589, 81.43424589, 100.00000000, 100.00000000, 100.00000000,
+		66.66666667, 56.86184706, 47.05702745, 80.39036078, 90.19518039,
+		100.00000000, 100.00000000, 100.00000000, 100.00000000, 100.00000000,
+	}},
+	{Name: cciPluginID, New: cciNew, Field: "cci", Want: []float64{
+		201.20404765, 155.06509357, 109.65895267, 97.92138353, 71.40282403,
+		55.76868255, 63.89574171, 61.53406805, 34.67017313, 52.68794305,
+		131.82014326, 238.90461538, 207.59608248, 175.39398755, 83.66228070,
+		66.15484769, 27.33252132, -14.01534527, -20.64766122, -85.72519813,
+		-84.51264564, -110.41834632, -108.58156028, -100.83036773, -79.13181458,
+		-72.18457567, -63.51207694, -80.65612707, -67.02369361, -115.18107107,
+		43.21524757, 20.01700286, 121.33333333, 57.02083333, 106.43274854,
+		84.25737784, 79.56577267, 95.22193211, 199.66218697, 161.71391333,
+		50.01852039, 43.23298074, 30.45716016, -192.86817217, -15.46961326,
+		46.03886398, -77.85365854, 58.34983498, 89.18406072, -119.09722222,
+		-103.37552743, -152.51515152, -142.60338607, 17.67610476, -55.85819282,
+		-181.23730379, -202.03325579, -147.35287914, -184.58877357, -148.81059922,
+		-132.72920442, -113.18615752, -85.32142604, -47.16296154, -35.42633765,
+		-39.80177360, -33.60030826, -81.15502617, -25.79528129, -19.68649147,
+		70.10082632, -4.49122807, 131.76328502, 109.30682132, 130.51788405,
+		119.41002950, 105.22816561, -111.88589540, -13.21259361, 20.67142480,
+		95.82637730, 277.30061350, 220.31083275, 161.48148148, 190.13930983,
+		166.10823240, 148.42047350,
+	}},
+	{Name: mfiPluginID, New: mfiNew, Field: "mfi", Want: []float64{
+		73.52271070, 65.99801160, 71.32729877, 62.38354870, 52.47345236,
+		58.61179742, 63.02728414, 68.21610875, 67.68604981, 66.82910814,
+		68.80264377, 69.52510342, 71.35396067, 66.22270988, 55.35506006,
+		53.37617041, 48.36358959, 52.73645416, 57.10937260, 51.09339618,
+		42.40606636, 43.85034876, 40.91453541, 42.33162898, 35.87899265,
+		30.31984013, 17.25209076, 24.01258050, 28.54483962, 37.99022972,
+		34.64746962, 45.27290936, 39.89982166, 47.34247063, 45.59266149,
+		51.28464400, 54.59851869, 49.94525197, 52.69565781, 45.18782566,
+		47.95981486, 41.65903553, 38.47397253, 41.15839238, 45.85433368,
+		38.27768065, 48.35799977, 50.15499085, 57.48023869, 52.14108949,
+		40.51534962, 34.24710185, 37.26081674, 40.01792721, 35.95833695,
+		37.44637889, 45.24947293, 39.83511304, 36.92029874, 35.21732962,
+		25.52656198, 18.77588767, 25.47667536, 33.59741481, 34.02255322,
+		37.51349963, 32.97965556, 40.25710440, 40.72636682, 44.76808382,
+		38.30871819, 41.34487318, 43.38044971, 55.48613365, 65.00530525,
+		62.26501110, 58.19487149, 56.33229112, 68.60521190, 70.99774221,
+		76.43580905, 77.90580600, 89.29852841, 89.64612645, 99.56083256,
+		99.59537530,
+	}},
+	{Name: willrPluginID, New: willrNew, Field: "willr", Want: []float64{
+		-17.05963939, -11.23439667, -24.13314840, -12.06657420, -23.43966713,
+		-23.43966713, -16.50485437, -17.33703190, -23.02357836, -17.25293132,
+		-11.04565538, -6.67556742, -10.16260163, -10.84598698, -50.75921909,
+		-27.76572668, -58.13449024, -58.13449024, -58.51528384, -78.38427948,
+		-78.38427948, -79.75708502, -79.75708502, -79.75708502, -77.53036437,
+		-77.53036437, -77.53036437, -85.08946322, -80.00000000, -83.62445415,
+		-29.10447761, -57.58620690, -22.75862069, -57.24137931, -40.34482759,
+		-45.51724138, -36.89655172, -35.17241379, -28.16901408, -28.16901408,
+		-60.84507042, -60.84507042, -48.73239437, -79.16666667, -44.44444444,
+		-55.00000000, -55.00000000, -46.66666667, -40.55555556, -78.88888889,
+		-78.88888889, -64.72222222, -77.50000000, -50.27777778, -79.16666667,
+		-78.99159664, -93.60613811, -70.97505669, -86.55913978, -75.63636364,
+		-86.41304348, -86.33879781, -75.22768670, -71.22040073, -70.68645640,
+		-70.68645640, -70.68645640, -81.51750973, -60.51948052, -55.33141210,
+		-46.68587896, -62.11180124, -34.48275862, -34.48275862, -33.10344828,
+		-24.82758621, -26.66666667, -65.51724138, -40.00000000, -40.00000000,
+		-16.72240803, -6.09756098, -6.00961538, -6.00961538, -16.86340641,
+		-12.60504202, -11.11111111,
+	}},
+	{Name: momPluginID, New: momNew, Field: "mom", Want: []float64{
+		-0.36000000, 1.46000000, 4.28000000, 3.19000000, 3.77000000,
+		4.07000000, 4.54000000, 4.52000000, 3.28000000, 3.78000000,
+		3.72000000, 1.49000000, -0.03000000, 1.30000000, 1.83000000,
+		2.76000000, 2.54000000, 1.52000000, 2.58000000, 0.68000000,
+		0.74000000, 1.15000000, -0.39000000, -1.49000000, -2.79000000,
+		-2.79000000, -3.44000000, -1.49000000, -2.55000000, -1.15000000,
+		-1.60000000, -1.45000000, -0.77000000, 0.38000000, 0.06000000,
+		1.07000000, 0.07000000, 0.45000000, 0.30000000, 0.55000000,
+		1.05000000, 1.57000000, 1.80000000, -0.51000000, 0.16000000,
+		-0.42000000, -0.54000000, 0.22000000, -0.01000000, -0.26000000,
+		-0.01000000, -0.46000000, -1.84000000, -0.68000000, -0.17000000,
+		-1.06000000, 1.04000000, -1.25000000, -1.44000000, -2.28000000,
+		-2.05000000, -3.97000000, -2.00000000, -2.61000000, -3.12000000,
+		-2.05000000, -2.81000000, -1.77000000, -1.20000000, -0.36000000,
+		-1.52000000, 0.75000000, 0.19000000, 1.10000000, 0.47000000,
+		0.54000000, 0.32000000, 0.36000000, 0.60000000, 0.60000000,
+		0.05000000, 0.22000000, 0.19000000, 0.64000000, 2.63000000,
+		2.01000000, 2.01000000, 2.99000000, 3.02000000, 3.82000000,
+	}},
+	{Name: rocPluginID, New: rocNew, Field: "roc", Want: []float64{
+		-0.36448314, 1.47818163, 4.36289501, 3.22352466, 3.81578947,
+		4.17136415, 4.63407165, 4.65164145, 3.33299461, 3.84107306,
+		3.78010365, 1.48658086, -0.02930260, 1.27263828, 1.78414741,
+		2.71546635, 2.47780704, 1.49473891, 2.53712263, 0.66542715,
+		0.72456673, 1.13055446, -0.38104543, -1.44030933, -2.67241379,
+		-2.67241379, -3.27463113, -1.44365856, -2.44557399, -1.11791582,
+		-1.55536114, -1.40954603, -0.75519812, 0.37269517, 0.05904931,
+		1.05304596, 0.06889086, 0.44239088, 0.29492725, 0.54069996,
+		1.03683223, 1.54801814, 1.77883190, -0.49833887, 0.15737189,
+		-0.40903779, -0.53107789, 0.21532740, -0.00980200, -0.25422900,
+		-0.00977326, -0.44664531, -1.78658122, -0.66777963, -0.16694491,
+		-1.03657344, 1.02827763, -1.22082235, -1.41162631, -2.23507499,
+		-2.00371420, -3.87203745, -1.97726149, -2.58032625, -3.06905371,
+		-2.02569170, -2.75004893, -1.75004944, -1.19319877, -0.36097463,
+		-1.51605825, 0.76095779, 0.19162885, 1.11629795, 0.47696367,
+		0.54462935, 0.32202878, 0.36228238, 0.60380396, 0.60380396,
+		0.05063804, 0.22152855, 0.19126233, 0.64231232, 2.65629734,
+		2.01625038, 2.01625038, 2.99809486, 3.02090627, 3.82114634,
+	}},
+	{Name: rocpPluginID, New: rocpNew, Field: "rocp", Want: []float64{
+		-0.00364483, 0.01478182, 0.04362895, 0.03223525, 0.03815789,
+		0.04171364, 0.04634072, 0.04651641, 0.03332995, 0.03841073,
+		0.03780104, 0.01486581, -0.00029303, 0.01272638, 0.01784147,
+		0.02715466, 0.02477807, 0.01494739, 0.02537123, 0.00665427,
+		0.00724567, 0.01130554, -0.00381045, -0.01440309, -0.02672414,
+		-0.02672414, -0.03274631, -0.01443659, -0.02445574, -0.01117916,
+		-0.01555361, -0.01409546, -0.00755198, 0.00372695, 0.00059049,
+		0.01053046, 0.00068891, 0.00442391, 0.00294927, 0.00540700,
+		0.01036832, 0.01548018, 0.01778832, -0.00498339, 0.00157372,
+		-0.00409038, -0.00531078, 0.00215327, -0.00009802, -0.00254229,
+		-0.00009773, -0.00446645, -0.01786581, -0.00667780, -0.00166945,
+		-0.01036573, 0.01028278, -0.01220822, -0.01411626, -0.02235075,
+		-0.02003714, -0.03872037, -0.01977261, -0.02580326, -0.03069054,
+		-0.02025692, -0.02750049, -0.01750049, -0.01193199, -0.00360975,
+		-0.01516058, 0.00760958, 0.00191629, 0.01116298, 0.00476964,
+		0.00544629, 0.00322029, 0.00362282, 0.00603804, 0.00603804,
+		0.00050638, 0.00221529, 0.00191262, 0.00642312, 0.02656297,
+		0.02016250, 0.02016250, 0.02998095, 0.03020906, 0.03821146,
+	}},
+	{Name: rocrPluginID, New: rocrNew, Field: "rocr", Want: []float64{
+		0.99635517, 1.01478182, 1.04362895, 1.03223525, 1.03815789,
+		1.04171364, 1.04634072, 1.04651641, 1.03332995, 1.03841073,
+		1.03780104, 1.01486581, 0.99970697, 1.01272638, 1.01784147,
+		1.02715466, 1.02477807, 1.01494739, 1.02537123, 1.00665427,
+		1.00724567, 1.01130554, 0.99618955, 0.98559691, 0.97327586,
+		0.97327586, 0.96725369, 0.98556341, 0.97554426, 0.98882084,
+		0.98444639, 0.98590454, 0.99244802, 1.00372695, 1.00059049,
+		1.01053046, 1.00068891, 1.00442391, 1.00294927, 1.00540700,
+		1.01036832, 1.01548018, 1.01778832, 0.99501661, 1.00157372,
+		0.99590962, 0.99468922, 1.00215327, 0.99990198, 0.99745771,
+		0.99990227, 0.99553355, 0.98213419, 0.99332220, 0.99833055,
+		0.98963427, 1.01028278, 0.98779178, 0.98588374, 0.97764925,
+		0.97996286, 0.96127963, 0.98022739, 0.97419674, 0.96930946,
+		0.97974308, 0.97249951, 0.98249951, 0.98806801, 0.99639025,
+		0.98483942, 1.00760958, 1.00191629, 1.01116298, 1.00476964,
+		1.00544629, 1.00322029, 1.00362282, 1.00603804, 1.00603804,
+		1.00050638, 1.00221529, 1.00191262, 1.00642312, 1.02656297,
+		1.02016250, 1.02016250, 1.02998095, 1.03020906, 1.03821146,
+	}},
+	{Name: rocr100PluginID, New: rocr100New, Field: "rocr100", Want: []float64{
+		99.63551686, 101.47818163, 104.36289501, 103.22352466, 103.81578947,
+		104.17136415, 104.63407165, 104.65164145, 103.33299461, 103.84107306,
+		103.78010365, 101.48658086, 99.97069740, 101.27263828, 101.78414741,
+		102.71546635, 102.47780704, 101.49473891, 102.53712263, 100.66542715,
+		100.72456673, 101.13055446, 99.61895457, 98.55969067, 97.32758621,
+		97.32758621, 96.72536887, 98.55634144, 97.55442601, 98.88208418,
+		98.44463886, 98.59045397, 99.24480188, 100.37269517, 100.05904931,
+		101.05304596, 100.06889086, 100.44239088, 100.29492725, 100.54069996,
+		101.03683223, 101.54801814, 101.77883190, 99.50166113, 100.15737189,
+		99.59096221, 99.46892211, 100.21532740, 99.99019800, 99.74577100,
+		99.99022674, 99.55335469, 98.21341878, 99.33222037, 99.83305509,
+		98.96342656, 101.02827763, 98.77917765, 98.58837369, 97.76492501,
+		97.99628580, 96.12796255, 98.02273851, 97.41967375, 96.93094629,
+		97.97430830, 97.24995107, 98.24995056, 98.80680123, 99.63902537,
+		98.48394175, 100.76095779, 100.19162885, 101.11629795, 100.47696367,
+		100.54462935, 100.32202878, 100.36228238, 100.60380396, 100.60380396,
+		100.05063804, 100.22152855, 100.19126233, 100.64231232, 102.65629734,
+		102.01625038, 102.01625038, 102.99809486, 103.02090627, 103.82114634,
+	}},
+	{Name: apoPluginID, New: apoNew, Field: "apo", Want: []float64{
+		1.91397436, 1.87910256, 1.83916667, 1.74852564, 1.69647436,
+		1.63826923, 1.49108974, 1.32346154, 1.15923077, 0.97448718,
+		0.69807692, 0.34250000, 0.06185897, -0.19025641, -0.29788462,
+		-0.49788462, -0.61025641, -0.69948718, -0.76865385, -0.79205128,
+		-0.75089744, -0.72775641, -0.69839744, -0.65153846, -0.56032051,
+		-0.43032051, -0.27025641, -0.04769231, 0.03955128, 0.18673077,
+		0.20352564, 0.22589744, 0.22019231, 0.24576923, 0.23051282,
+		0.22775641, 0.21403846, 0.13423077, 0.00282051, -0.10570513,
+		-0.13820513, -0.14403846, -0.22660256, -0.25025641, -0.37153846,
+		-0.46314103, -0.59217949, -0.75820513, -0.95108974, -1.03474359,
+		-1.08141026, -1.15878205, -1.17205128, -1.26698718, -1.31987179,
+		-1.35352564, -1.27506410, -1.28250000, -1.08673077, -0.98301282,
+		-0.79794872, -0.60134615, -0.44532051, -0.34993590, -0.25455128,
+		-0.19250000, -0.11493590, 0.05282051, 0.16673077, 0.31724359,
+		0.41314103, 0.58192308, 0.67442308, 0.80173077, 0.93814103,
+	}},
+	{Name: ppoPluginID, New: ppoNew, Field: "ppo", Want: []float64{
+		1.90165747, 1.86254028, 1.81987598, 1.72613239, 1.67226511,
+		1.61240257, 1.46460850, 1.29800075, 1.13487889, 0.95286807,
+		0.68176952, 0.33409745, 0.06030768, -0.18553108, -0.29053301,
+		-0.48583406, -0.59553524, -0.68295186, -0.75030035, -0.77314492,
+		-0.73283860, -0.71037410, -0.68160121, -0.63594795, -0.54715512,
+		-0.42053815, -0.26425279, -0.04666900, 0.03872276, 0.18298727,
+		0.19949131, 0.22156415, 0.21600758, 0.24109386, 0.22612342,
+		0.22336050, 0.20983451, 0.13161716, 0.00276619, -0.10367157,
+		-0.13557293, -0.14124667, -0.22223396, -0.24548920, -0.36482016,
+		-0.45500862, -0.58268839, -0.74676809, -0.93803305, -1.02188746,
+		-1.06924147, -1.14702941, -1.16176519, -1.25760352, -1.31132799,
+		-1.34635372, -1.26974096, -1.27802691, -1.08408310, -0.98174788,
+		-0.79763273, -0.60171413, -0.44607369, -0.35068718, -0.25521386,
+		-0.19321490, -0.11543716, 0.05310524, 0.16768529, 0.31892777,
+		0.41501818, 0.58424207, 0.67602474, 0.80244680, 0.93708500,
+	}},
+	{Name: bopPluginID, New: bopNew, Field: "bop", Want: []float64{
+		-0.49596774, 0.00000000, -0.47183099, 0.63235294, -0.13793103,
+		-0.83108108, 0.34782609, -0.51612903, 0.49799197, 0.00000000,
+		0.00000000, 0.50980392, 0.63235294, -0.15540541, 0.29577465,
+		-0.65034965, 0.63503650, -0.39613527, 0.00000000, 0.25000000,
+		-0.10714286, -0.35344828, 0.26470588, 0.52380952, 0.48717949,
+		0.00000000, 0.46428571, -0.78632479, 0.67948718, -0.52830189,
+		0.00000000, 0.00000000, -0.47643979, 0.00000000, -0.16666667,
+		0.00000000, 0.00000000, 0.12790698, 0.00000000, 0.00000000,
+		-0.23076923, 0.23076923, -0.18699187, 0.53488372, -0.25093633,
+		0.40239044, -0.44444444, 0.66216216, -0.09090909, 0.25000000,
+		0.16666667, 0.27685950, 0.00000000, -0.39862543, 0.00000000,
+		0.46236559, -0.42748092, 0.45454545, -0.23312883, 0.00000000,
+		0.19354839, 0.18032787, -0.57983193, 0.00000000, 0.50495050,
+		-0.31506849, 0.43946188, -0.50980392, -0.31318681, -0.52830189,
+		0.23245614, -0.53125000, 0.25213675, -0.28909953, 0.00000000,
+		0.28909953, 0.18032787, 0.00000000, 0.00000000, 0.00000000,
+		-0.38650307, 0.43181818, 0.01960784, 0.16666667, -0.45652174,
+		0.35233161, 0.00000000, 0.07407407, 0.32432432, 0.00000000,
+		-0.48559671, 0.49664430, 0.00000000, 0.37500000, 0.84472050,
+		0.04580153, 0.00000000, 0.44933921, 0.26470588, 0.39024390,
+	}},
+	{Name: aroonPluginID, New: aroonNew, Field: "aroondown", Want: []float64{
+		57.14285714, 50.00000000, 42.85714286, 35.71428571, 28.57142857,
+		21.42857143, 14.28571429, 7.14285714, 0.00000000, 14.28571429,
+		7.14285714, 0.00000000, 0.00000000, 28.57142857, 21.42857143,
+		14.28571429, 7.14285714, 0.00000000, 28.57142857, 21.42857143,
+		100.00000000, 100.00000000, 100.00000000, 92.85714286, 85.71428571,
+		78.57142857, 100.00000000, 92.85714286, 100.00000000, 92.85714286,
+		85.71428571, 78.57142857, 71.42857143, 64.28571429, 57.14285714,
+		50.00000000, 42.85714286, 35.71428571, 28.57142857, 21.42857143,
+		14.28571429, 7.14285714, 100.00000000, 92.85714286, 85.71428571,
+		78.57142857, 71.42857143, 64.28571429, 57.14285714, 50.00000000,
+		42.85714286, 35.71428571, 28.57142857, 21.42857143, 100.00000000,
+		100.00000000, 100.00000000, 100.00000000, 100.00000000, 100.00000000,
+		92.85714286, 100.00000000, 92.85714286, 85.71428571, 78.57142857,
+		71.42857143, 64.28571429, 57.14285714, 50.00000000, 42.85714286,
+		35.71428571, 28.57142857, 21.42857143, 14.28571429, 7.14285714,
+		0.00000000, 100.00000000, 92.85714286, 85.71428571, 78.57142857,
+		71.42857143, 64.28571429, 57.14285714, 50.00000000, 42.85714286,
+		35.71428571,
+	}},
+	{Name: aroonPluginID, New: aroonNew, Field: "aroonup", Want: []float64{
+		85.71428571, 78.57142857, 71.42857143, 64.28571429, 57.14285714,
+		50.00000000, 42.85714286, 35.71428571, 28.57142857, 100.00000000,
+		100.00000000, 100.00000000, 100.00000000, 100.00000000, 92.85714286,
+		85.71428571, 78.57142857, 71.42857143, 64.28571429, 57.14285714,
+		50.00000000, 42.85714286, 35.71428571, 28.57142857, 21.42857143,
+		14.28571429, 7.14285714, 0.00000000, 7.14285714, 0.00000000,
+		100.00000000, 92.85714286, 85.71428571, 78.57142857, 71.42857143,
+		64.28571429, 57.14285714, 100.00000000, 100.00000000, 100.00000000,
+		100.00000000, 92.85714286, 85.71428571, 78.57142857, 71.42857143,
+		64.28571429, 57.14285714, 50.00000000, 42.85714286, 35.71428571,
+		28.57142857, 21.42857143, 14.28571429, 7.14285714, 0.00000000,
+		21.42857143, 14.28571429, 7.14285714, 0.00000000, 7.14285714,
+		0.00000000, 14.28571429, 7.14285714, 0.00000000, 14.28571429,
+		7.14285714, 0.00000000, 0.00000000, 0.00000000, 7.14285714,
+		0.00000000, 0.00000000, 100.00000000, 92.85714286, 85.71428571,
+		78.57142857, 71.42857143, 64.28571429, 57.14285714, 100.00000000,
+		100.00000000, 100.00000000, 100.00000000, 100.00000000, 100.00000000,
+		100.00000000,
+	}},
+	{Name: aroonoscPluginID, New: aroonoscNew, Field: "aroonosc", Want: []float64{
+		28.57142857, 28.57142857, 28.57142857, 28.57142857, 28.57142857,
+		28.57142857, 28.57142857, 28.57142857, 28.57142857, 85.71428571,
+		92.85714286, 100.00000000, 100.00000000, 71.42857143, 71.42857143,
+		71.42857143, 71.42857143, 71.42857143, 35.71428571, 35.71428571,
+		-50.00000000, -57.14285714, -64.28571429, -64.28571429, -64.28571429,
+		-64.28571429, -92.85714286, -92.85714286, -92.85714286, -92.85714286,
+		14.28571429, 14.28571429, 14.28571429, 14.28571429, 14.28571429,
+		14.28571429, 14.28571429, 64.28571429, 71.42857143, 78.57142857,
+		85.71428571, 85.71428571, -14.28571429, -14.28571429, -14.28571429,
+		-14.28571429, -14.28571429, -14.28571429, -14.28571429, -14.28571429,
+		-14.28571429, -14.28571429, -14.28571429, -14.28571429, -100.00000000,
+		-78.57142857, -85.71428571, -92.85714286, -100.00000000, -92.85714286,
+		-92.85714286, -85.71428571, -85.71428571, -85.71428571, -64.28571429,
+		-64.28571429, -64.28571429, -57.14285714, -50.00000000, -35.71428571,
+		-35.71428571, -28.57142857, 78.57142857, 78.57142857, 78.57142857,
+		78.57142857, -28.57142857, -28.57142857, -28.57142857, 21.42857143,
+		28.57142857, 35.71428571, 42.85714286, 50.00000000, 57.14285714,
+		64.28571429,
+	}},
+}
+
+func TestTALib(t *testing.T) {
+	indicatortest.TALibCases(t, talibOutputs)
+}
@@ -0,0 +1,57 @@
+package momentum
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// WILLR = -100 * (HighestHigh - Close) / (HighestHigh - LowestLow)
+const willrPluginID = "WILLR"
+const willrPluginName = "Williams' %R"
+const willrPluginDescription = "Williams' %R is the distance of the close below the highest high of the period, between -100 and 0."
+const willrPluginHCL = `
+indicator "willr" {
+  period = 14
+}
+`
+
+type willrKernel struct {
+	High, Low plugins.Ring
+	Output    string
+}
+
+func (k *willrKernel) Add(values []float64) map[string]float64 {
+	k.High.Push(values[0])
+	k.Low.Push(values[1])
+	if !k.High.Full() {
+		return nil
+	}
+
+	highest, _ := k.High.Highest()
+	lowest, _ := k.Low.Lowest()
+	willr := 0.0
+	if diff := (highest - lowest) * -0.01; diff != 0 {
+		willr = (highest - values[2]) / diff
+	}
+	return map[string]float64{k.Output: willr}
+}
+
+func (k *willrKernel) Warmup() int {
+	return len(k.High.Values)
+}
+
+func willrNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, output := params.Period(14), params.Output(strings.ToLower(willrPluginID))
+	return plugins.NewKernelIndicator(willrPluginID, willrPluginName, willrPluginDescription, willrPluginHCL, params, plugins.Bar(params, "high", "low", "close"), func() plugins.Kernel {
+		return &willrKernel{High: plugins.NewRing(period), Low: plugins.NewRing(period), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(willrPluginID, willrNew, indicators.MOMENTUM)
+}
@@ -0,0 +1,227 @@
+package ohlc
+
+import (
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/series"
+	"github.com/rangertaha/gotal/internal/stream"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// OHLC aggregates price ticks into open, high, low and close bars. Bars last
+// the given duration or, without one, span a number of input ticks (period).
+const ohlcPluginID = "OHLC"
+const ohlcPluginName = "Open High Low Close"
+const ohlcPluginDescription = "Open High Low Close aggregates price ticks into time bars."
+const ohlcPluginHCL = `
+indicator "ohlc" {
+  duration = "1m"
+  field = "price"
+}
+`
+
+const ohlcvPluginID = "OHLCV"
+const ohlcvPluginName = "Open High Low Close Volume"
+const ohlcvPluginDescription = "Open High Low Close Volume aggregates price and volume ticks into time bars."
+const ohlcvPluginHCL = `
+indicator "ohlcv" {
+  duration = "1m"
+  field = "price"
+  volume = "volume"
+}
+`
+
+type ohlc struct {
+	plugins.Plugin
+
+	// OHLC parameters
+	Duration time.Duration `hcl:"duration,optional"` // duration of each bar
+	Period   int           `hcl:"period,optional"`   // number of input ticks per bar, used when no duration is set
+	Volume   string        `hcl:"volume,optional"`   // volume field, empty for OHLC
+
+	// streaming state
+	bucket   *series.Bucket
+	start    time.Time
+	duration time.Duration // duration of the bar in progress
+}
+
+// ohlcState is the snapshot of an ohlc, the bar in progress.
+type ohlcState struct {
+	Bucket   *series.Bucket
+	Start    time.Time
+	Duration time.Duration
+}
+
+func ohlcNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newPlugin(ohlcPluginID, ohlcPluginName, ohlcPluginDescription, ohlcPluginHCL, "", opts...)
+}
+
+func ohlcvNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newPlugin(ohlcvPluginID, ohlcvPluginName, ohlcvPluginDescription, ohlcvPluginHCL, "volume", opts...)
+}
+
+func newPlugin(id, name, description, hcl, volume string, opts ...internal.PluginOptions) *ohlc {
+	params := opt.New(opts...)
+
+	i := &ohlc{
+		Plugin: plugins.Plugin{
+			PID:         id,
+			Title:       name,
+			Summary:     description,
+			Template:    hcl,
+			Params:      params,
+			Fields:      []string{params.Field("price")},
+			Initialized: true,
+		},
+		Duration: params.Duration("duration", time.Duration(0)),
+		Period:   params.Period(0),
+		Volume:   params.String("volume", volume),
+	}
+	i.bucket = series.NewBucket(i.aggregations()...)
+
+	return i
+}
+
+func (i *ohlc) aggregations() []series.Aggregation {
+	if i.Volume == "" {
+		return series.OHLC(i.Fields[0])
+	}
+	return series.OHLCV(i.Fields[0], i.Volume)
+}
+
+// spawn returns the plugin with an empty streaming state.
+func (i *ohlc) spawn() *ohlc {
+	return &ohlc{
+		Plugin:   i.Plugin,
+		Duration: i.Duration,
+		Period:   i.Period,
+		Volume:   i.Volume,
+		bucket:   series.NewBucket(i.aggregations()...),
+	}
+}
+
+func (i *ohlc) Init(opts ...internal.PluginOptions) error {
+	return nil
+}
+
+// Compute returns the bars of the series, the last one possibly partial.
+// Time bars are resampled, period bars group every Period ticks.
+func (i *ohlc) Compute(input *series.Series) (output *series.Series) {
+	if i.Duration > 0 {
+		output = input.Resample(i.Duration, i.aggregations())
+		output.SetName(i.ID())
+		return
+	}
+
+	b := i.spawn()
+	output = input.Spawn()
+	output.SetName(i.ID())
+	for _, t := range input.Ticks() {
+		output.Add(b.add(t)...)
+	}
+	output.Add(b.flush()...)
+	return
+}
+
+// Stream returns the bars of the stream, flushing the bar in progress once
+// the input is closed.
+func (i *ohlc) Stream(input *stream.Stream) *stream.Stream {
+	b := i.spawn()
+	return input.FlatMapFlush(b.add, b.flush)
+}
+
+func (i *ohlc) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the tick to the current bar and returns the bar it completes,
+// otherwise an empty tick. A time bar completes when a tick of the next bar
+// arrives, a period bar with its last tick.
+func (i *ohlc) Update(input *tick.Tick) (output *tick.Tick) {
+	if bars := i.add(input); len(bars) > 0 {
+		return bars[0]
+	}
+	return tick.New()
+}
+
+// Flush returns the bar in progress and starts a new one, an empty tick when
+// no tick was added since the last bar.
+func (i *ohlc) Flush() (output *tick.Tick) {
+	if bars := i.flush(); len(bars) > 0 {
+		return bars[0]
+	}
+	return tick.New()
+}
+
+// Reset drops the bar in progress.
+func (i *ohlc) Reset() {
+	i.bucket = series.NewBucket(i.aggregations()...)
+	i.start, i.duration = time.Time{}, 0
+}
+
+// Warmup returns the ticks of a period bar, and 2 for time bars, the first
+// one completing with a tick of the next.
+func (i *ohlc) Warmup() int {
+	if i.Duration > 0 {
+		return 2
+	}
+	return i.Period
+}
+
+func (i *ohlc) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(ohlcState{Bucket: i.bucket, Start: i.start, Duration: i.duration})
+}
+
+func (i *ohlc) Restore(state []byte) error {
+	var s ohlcState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.bucket, i.start, i.duration = s.Bucket, s.Start, s.Duration
+	return nil
+}
+
+// add adds the tick to the bar in progress and returns the bar it completes.
+func (i *ohlc) add(input *tick.Tick) (bars []*tick.Tick) {
+	switch {
+	case i.Duration > 0:
+		start := input.Time().Truncate(i.Duration)
+		if i.bucket.Len() > 0 && start.After(i.start) {
+			bars = i.flush()
+		}
+		if i.bucket.Len() == 0 {
+			i.start, i.duration = start, i.Duration
+		}
+		i.bucket.Add(input)
+	case i.Period > 0:
+		if i.bucket.Len() == 0 {
+			i.start, i.duration = input.Time(), time.Duration(i.Period)*input.Duration()
+		}
+		if i.bucket.Add(input); i.bucket.Len() == i.Period {
+			bars = i.flush()
+		}
+	}
+	return
+}
+
+// flush returns the bar in progress, if any, and empties the bucket. The bar
+// is stamped at its start, a period bar starting with its first tick rather
+// than on a multiple of its duration.
+func (i *ohlc) flush() []*tick.Tick {
+	if i.bucket.Len() == 0 {
+		return nil
+	}
+	bar := i.bucket.Tick(i.start, i.duration)
+	bar.SetUnixNano(i.start.UnixNano())
+	i.bucket.Reset()
+	return []*tick.Tick{bar}
+}
+
+func init() {
+	indicators.Add(ohlcPluginID, ohlcNew, indicators.OTHER)
+	indicators.Add(ohlcvPluginID, ohlcvNew, indicators.OTHER)
+}
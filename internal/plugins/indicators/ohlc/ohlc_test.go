@@ -0,0 +1,179 @@
+package ohlc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/series"
+	"github.com/rangertaha/gotal/internal/stream"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+var base = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// trades returns the prices every 20 seconds, without a tick duration.
+func trades(prices ...float64) *series.Series {
+	s := series.New("trades")
+	for i, price := range prices {
+		s.Add(tick.New(
+			tick.WithTime(base.Add(time.Duration(i)*20*time.Second)),
+			tick.WithFields(map[string]float64{"price": price, "volume": 1}),
+		))
+	}
+	return s
+}
+
+// check fails unless the bars have the open, high, low and close.
+func check(t *testing.T, name string, bars []*tick.Tick, want [][4]float64) {
+	t.Helper()
+	if len(bars) != len(want) {
+		t.Fatalf("%s: got %d bars, want %d", name, len(bars), len(want))
+	}
+	for k, bar := range bars {
+		got := [4]float64{bar.GetField("open"), bar.GetField("high"), bar.GetField("low"), bar.GetField("close")}
+		if got != want[k] {
+			t.Errorf("%s bar %d = %v, want %v", name, k, got, want[k])
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	// three ticks a minute, the last minute partial
+	input := trades(1, 3, 2, 4, 6, 5, 7)
+	want := [][4]float64{{1, 3, 1, 2}, {4, 6, 4, 5}, {7, 7, 7, 7}}
+	plugin := ohlcNew(opt.WithDuration(time.Minute))
+
+	output := plugin.Compute(input)
+	check(t, "compute", output.Ticks(), want)
+	if got := output.At(1).Time(); !got.Equal(base.Add(time.Minute)) {
+		t.Errorf("bar 1 at %v, want %v", got, base.Add(time.Minute))
+	}
+
+	live, err := plugin.(internal.Streamer).Stream(stream.New("trades", stream.WithTicks(input.Ticks()...))).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(t, "stream", live, want)
+
+	// the bar in progress is only returned by Flush
+	var processed []*tick.Tick
+	for _, tk := range input.Ticks() {
+		if bar := plugin.Process(tk); !bar.IsEmpty() {
+			processed = append(processed, bar)
+		}
+	}
+	check(t, "process", processed, want[:2])
+	flusher := plugin.(interface{ Flush() *tick.Tick })
+	check(t, "flush", []*tick.Tick{flusher.Flush()}, want[2:])
+	if bar := flusher.Flush(); !bar.IsEmpty() {
+		t.Errorf("second flush = %v, want an empty tick", bar.Fields())
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	// the ticks carry no duration, so bars are counted in ticks
+	input := trades(1, 3, 2, 4, 6, 5, 7)
+	want := [][4]float64{{1, 3, 1, 2}, {4, 6, 4, 5}, {7, 7, 7, 7}}
+	plugin := ohlcvNew(opt.WithPeriod(3))
+
+	output := plugin.Compute(input)
+	check(t, "compute", output.Ticks(), want)
+	for k, vol := range []float64{3, 3, 1} {
+		if got := output.At(k).GetField("volume"); got != vol {
+			t.Errorf("bar %d volume = %v, want %v", k, got, vol)
+		}
+	}
+	if got := output.At(1).Time(); !got.Equal(base.Add(time.Minute)) {
+		t.Errorf("bar 1 at %v, want its first tick at %v", got, base.Add(time.Minute))
+	}
+
+	live, err := plugin.(internal.Streamer).Stream(stream.New("trades", stream.WithTicks(input.Ticks()...))).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(t, "stream", live, want)
+
+	// a period bar completes with its last tick
+	var processed []*tick.Tick
+	for k, tk := range input.Ticks() {
+		bar := plugin.Process(tk)
+		if completes := k%3 == 2; bar.IsEmpty() == completes {
+			t.Errorf("tick %d returned bar %v", k, bar.Fields())
+		}
+		if !bar.IsEmpty() {
+			processed = append(processed, bar)
+		}
+	}
+	check(t, "process", processed, want[:2])
+
+	// the bars of ticks lasting 20 seconds last a minute from their first tick
+	for _, tk := range input.Ticks() {
+		tk.SetDuration(20 * time.Second)
+	}
+	output = plugin.Compute(input.Slice(1, input.Len()))
+	for k, start := range []time.Duration{20 * time.Second, 80 * time.Second} {
+		if bar := output.At(k); !bar.Time().Equal(base.Add(start)) || bar.Duration() != time.Minute {
+			t.Errorf("bar %d at %v lasting %v, want %v lasting 1m", k, bar.Time(), bar.Duration(), base.Add(start))
+		}
+	}
+}
+
+func TestField(t *testing.T) {
+	input := series.New("quotes")
+	for i, mid := range []float64{10, 12, 11} {
+		input.Add(tick.New(
+			tick.WithTime(base.Add(time.Duration(i)*time.Second)),
+			tick.WithFields(map[string]float64{"mid": mid}),
+		))
+	}
+	output := ohlcNew(opt.WithDuration(time.Minute), opt.WithField("mid")).Compute(input)
+	check(t, "mid", output.Ticks(), [][4]float64{{10, 12, 10, 11}})
+}
+
+func TestSnapshot(t *testing.T) {
+	input := trades(1, 3, 2, 4, 6, 5, 7, 8, 6, 9, 4)
+	for name, new := range map[string]func() internal.Indicator{
+		"duration": func() internal.Indicator { return ohlcvNew(opt.WithDuration(time.Minute)).(internal.Indicator) },
+		"period":   func() internal.Indicator { return ohlcvNew(opt.WithPeriod(4)).(internal.Indicator) },
+	} {
+		// restarting in the middle of a bar keeps the ticks added to it
+		live, whole := new(), new()
+		var outputs, wholes []*tick.Tick
+		for k, tk := range input.Ticks() {
+			if k == 5 {
+				state, err := live.Snapshot()
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				live = new()
+				if err := live.Restore(state); err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+			}
+			if bar := live.Update(tk); !bar.IsEmpty() {
+				outputs = append(outputs, bar)
+			}
+			if bar := whole.Update(tk); !bar.IsEmpty() {
+				wholes = append(wholes, bar)
+			}
+		}
+		outputs = append(outputs, live.(interface{ Flush() *tick.Tick }).Flush())
+		wholes = append(wholes, whole.(interface{ Flush() *tick.Tick }).Flush())
+
+		want := make([][4]float64, len(wholes))
+		for k, bar := range wholes {
+			want[k] = [4]float64{bar.GetField("open"), bar.GetField("high"), bar.GetField("low"), bar.GetField("close")}
+			if !outputs[k].Time().Equal(bar.Time()) || outputs[k].GetField("volume") != bar.GetField("volume") {
+				t.Errorf("%s bar %d at %v with %v, want %v with %v", name, k, outputs[k].Time(), outputs[k].Fields(), bar.Time(), bar.Fields())
+			}
+		}
+		check(t, name, outputs, want)
+
+		live.Reset()
+		if bar := live.(interface{ Flush() *tick.Tick }).Flush(); !bar.IsEmpty() {
+			t.Errorf("%s: Flush() after Reset() = %v, want an empty tick", name, bar.Fields())
+		}
+	}
+}
@@ -2,8 +2,10 @@ package indicators
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/series"
 )
 
 type GroupType string
@@ -14,6 +16,7 @@ const (
 	VOLATILITY GroupType = "volatility"
 	VOLUME     GroupType = "volume"
 	CYCLE      GroupType = "cycle"
+	PATTERN    GroupType = "pattern"
 	OTHER      GroupType = "other"
 )
 
@@ -47,6 +50,17 @@ func Get(id string) (PluginFunc, error) {
 	return nil, fmt.Errorf("indicator %s not found", id)
 }
 
+// Series returns a function computing the indicator over a series, the id is case-insensitive
+func Series(id string) (internal.IndicatorFunc, error) {
+	plugin, err := Get(strings.ToUpper(id))
+	if err != nil {
+		return nil, err
+	}
+	return func(input *series.Series, opts ...internal.PluginOptions) *series.Series {
+		return plugin(opts...).Compute(input)
+	}, nil
+}
+
 // Group returns all indicators in a group
 func Group(id GroupType) ([]PluginFunc, error) {
 	if group, ok := GROUPS[id]; ok {
@@ -0,0 +1,245 @@
+package sar
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// SAR = PrevSAR + AF * (EP - PrevSAR)
+// where EP is the extreme point of the trend, the highest high of a long
+// trend or the lowest low of a short one, and AF the acceleration factor,
+// increased by the step on each new extreme point up to the maximum. The
+// SAR never crosses into the range of the last two bars. When a low falls
+// to the SAR of a long trend, or a high rises to the SAR of a short one, the
+// trend reverses and the SAR restarts from the extreme point. The direction
+// of the first trend is the one of the directional movement of the first two
+// bars, as in TA-Lib.
+const sarPluginID = "SAR"
+const sarPluginName = "Parabolic SAR"
+const sarPluginDescription = "Parabolic SAR is a trailing stop following the trend, accelerating toward the price with each new extreme point."
+const sarPluginHCL = `
+indicator "sar" {
+  acceleration = 0.02
+  maximum = 0.2
+}
+`
+
+// sar is the plugin of SAR and SAREXT. The output is the SAR and the
+// direction of the trend, 1 when long and -1 when short. Every tick carries
+// the direction as a BULLISH or BEARISH signal, WEAK at the initial
+// acceleration, STRONG at the maximum and MEDIUM between them, and the
+// reversals as a STRONG CROSSOVER when the price crosses over the SAR or
+// CROSSUNDER when it crosses under it.
+type sar struct {
+	plugins.Plugin
+
+	Start  float64      `hcl:"start,optional"`  // first SAR, positive to start long, negative short, 0 as by the first bars
+	Offset float64      `hcl:"offset,optional"` // fraction the SAR is moved away from the price on reversals
+	Long   acceleration // acceleration factor of long trends
+	Short  acceleration // acceleration factor of short trends
+
+	state sarState
+}
+
+// acceleration is the acceleration factor of a trend direction.
+type acceleration struct {
+	Init, Step, Max float64
+}
+
+// newAcceleration returns the acceleration factor, with the initial factor
+// and the step limited to the maximum as in TA-Lib, after recording the
+// error when a factor is negative.
+func newAcceleration(params internal.Options, init, step, maximum float64) acceleration {
+	if init < 0 || step < 0 || maximum < 0 {
+		params.AddError(fmt.Errorf("invalid SAR acceleration: %v by %v up to %v", init, step, maximum))
+		init, step, maximum = 0.02, 0.02, 0.2
+	}
+	return acceleration{Init: min(init, maximum), Step: min(step, maximum), Max: maximum}
+}
+
+// sarState is the state of the trend, also the snapshot of a sar.
+type sarState struct {
+	Count             int
+	Long              bool    // direction of the trend
+	SAR, EP, AF       float64 // SAR of the next bar, extreme point and acceleration factor
+	PrevHigh, PrevLow float64
+}
+
+func newSAR(id, name, description, hcl string, params internal.Options) *sar {
+	return &sar{
+		Plugin: plugins.Plugin{
+			PID:         id,
+			Title:       name,
+			Summary:     description,
+			Template:    hcl,
+			Params:      params,
+			Fields:      plugins.Bar(params, "high", "low"),
+			Initialized: true,
+		},
+	}
+}
+
+func sarNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	i := newSAR(sarPluginID, sarPluginName, sarPluginDescription, sarPluginHCL, params)
+
+	// the acceleration is both the initial factor and the step
+	af := params.Float("acceleration", 0.02)
+	i.Long = newAcceleration(params, af, af, params.Float("maximum", 0.2))
+	i.Short = i.Long
+	return i
+}
+
+func (i *sar) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+func (i *sar) Compute(input *series.Series) (output *series.Series) {
+	return plugins.Compute(i.ID(), i, input)
+}
+
+func (i *sar) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the bar and returns the SAR of the bar and the direction of
+// the trend, from the second bar.
+func (i *sar) Update(input *tick.Tick) (output *tick.Tick) {
+	high, low := input.GetField(i.Fields[0]), input.GetField(i.Fields[1])
+	if math.IsNaN(high) || math.IsNaN(low) {
+		return tick.New()
+	}
+
+	s := &i.state
+	s.Count++
+	prevHigh, prevLow := s.PrevHigh, s.PrevLow
+	s.PrevHigh, s.PrevLow = high, low
+	switch s.Count {
+	case 1:
+		return tick.New()
+	case 2:
+		i.start(prevHigh, prevLow, high, low)
+		// the second bar is its own previous bar
+		prevHigh, prevLow = high, low
+	}
+
+	// the SAR of the bar, moved to the extreme point when the bar reverses the trend
+	value, reversed := s.SAR, false
+	if s.Long && low <= s.SAR {
+		s.Long, reversed = false, true
+		s.SAR = max(s.EP, prevHigh, high)
+		s.SAR += s.SAR * i.Offset
+		value = s.SAR
+		s.AF, s.EP = i.Short.Init, low
+	} else if !s.Long && high >= s.SAR {
+		s.Long, reversed = true, true
+		s.SAR = min(s.EP, prevLow, low)
+		s.SAR -= s.SAR * i.Offset
+		value = s.SAR
+		s.AF, s.EP = i.Long.Init, high
+	} else if s.Long && high > s.EP {
+		s.EP, s.AF = high, min(s.AF+i.Long.Step, i.Long.Max)
+	} else if !s.Long && low < s.EP {
+		s.EP, s.AF = low, min(s.AF+i.Short.Step, i.Short.Max)
+	}
+
+	// the SAR of the next bar, outside of the range of the last two bars
+	s.SAR += s.AF * (s.EP - s.SAR)
+	if s.Long {
+		s.SAR = min(s.SAR, prevLow, low)
+	} else {
+		s.SAR = max(s.SAR, prevHigh, high)
+	}
+
+	direction := 1.0
+	if !s.Long {
+		direction = -1
+	}
+	output = plugins.Output(input, map[string]float64{"sar": value, "direction": direction})
+	i.signal(output, reversed)
+	return output
+}
+
+// start sets the first trend from the first two bars.
+func (i *sar) start(prevHigh, prevLow, high, low float64) {
+	s := &i.state
+	switch {
+	case i.Start > 0:
+		s.Long, s.SAR = true, i.Start
+	case i.Start < 0:
+		s.Long, s.SAR = false, -i.Start
+	default:
+		// long unless the bar has a -DM
+		down := prevLow - low
+		s.Long = !(down > 0 && high-prevHigh < down)
+		s.SAR = prevLow
+		if !s.Long {
+			s.SAR = prevHigh
+		}
+	}
+
+	s.EP, s.AF = high, i.Long.Init
+	if !s.Long {
+		s.EP, s.AF = low, i.Short.Init
+	}
+}
+
+// signal sets the direction of the trend with the strength of its
+// acceleration, and the reversal.
+func (i *sar) signal(output *tick.Tick, reversed bool) {
+	s, a := &i.state, i.Long
+	if !s.Long {
+		a = i.Short
+	}
+	strength := sig.MEDIUM
+	switch {
+	case s.AF >= a.Max:
+		strength = sig.STRONG
+	case s.AF <= a.Init:
+		strength = sig.WEAK
+	}
+
+	if s.Long {
+		output.SetSignal(sig.BULLISH, strength)
+	} else {
+		output.SetSignal(sig.BEARISH, strength)
+	}
+	if reversed && s.Long {
+		output.SetSignal(sig.CROSSOVER, sig.STRONG)
+	} else if reversed {
+		output.SetSignal(sig.CROSSUNDER, sig.STRONG)
+	}
+}
+
+func (i *sar) Reset() {
+	i.state = sarState{}
+}
+
+func (i *sar) Warmup() int {
+	return 2
+}
+
+func (i *sar) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(i.state)
+}
+
+func (i *sar) Restore(state []byte) error {
+	var s sarState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.state = s
+	return nil
+}
+
+func init() {
+	indicators.Add(sarPluginID, sarNew, indicators.TREND)
+}
@@ -0,0 +1,241 @@
+package sar
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+	"github.com/rangertaha/gotal/internal/series"
+	sig "github.com/rangertaha/gotal/internal/signals"
+)
+
+// refSAREXT is TA_SAREXT over the whole input, the SAR of each bar from the
+// second one, negative in short trends.
+func refSAREXT(b indicatortest.Bars, start, offset, initLong, long, maxLong, initShort, short, maxShort float64) []float64 {
+	initLong, long = min(initLong, maxLong), min(long, maxLong)
+	initShort, short = min(initShort, maxShort), min(short, maxShort)
+
+	var isLong bool
+	var ep, sar float64
+	switch {
+	case start == 0:
+		diffP, diffM := b.High[1]-b.High[0], b.Low[0]-b.Low[1]
+		isLong = !(diffM > 0 && diffP < diffM)
+		if isLong {
+			ep, sar = b.High[1], b.Low[0]
+		} else {
+			ep, sar = b.Low[1], b.High[0]
+		}
+	case start > 0:
+		isLong, ep, sar = true, b.High[1], start
+	default:
+		isLong, ep, sar = false, b.Low[1], -start
+	}
+
+	var out []float64
+	afLong, afShort := initLong, initShort
+	newLow, newHigh := b.Low[1], b.High[1]
+	for today := 1; today < len(b.High); today++ {
+		prevLow, prevHigh := newLow, newHigh
+		newLow, newHigh = b.Low[today], b.High[today]
+		if isLong {
+			if newLow <= sar {
+				isLong = false
+				sar = ep
+				if sar < prevHigh {
+					sar = prevHigh
+				}
+				if sar < newHigh {
+					sar = newHigh
+				}
+				if offset != 0 {
+					sar += sar * offset
+				}
+				out = append(out, -sar)
+				afShort = initShort
+				ep = newLow
+				sar = sar + afShort*(ep-sar)
+				if sar < prevHigh {
+					sar = prevHigh
+				}
+				if sar < newHigh {
+					sar = newHigh
+				}
+			} else {
+				out = append(out, sar)
+				if newHigh > ep {
+					ep = newHigh
+					afLong += long
+					if afLong > maxLong {
+						afLong = maxLong
+					}
+				}
+				sar = sar + afLong*(ep-sar)
+				if sar > prevLow {
+					sar = prevLow
+				}
+				if sar > newLow {
+					sar = newLow
+				}
+			}
+		} else {
+			if newHigh >= sar {
+				isLong = true
+				sar = ep
+				if sar > prevLow {
+					sar = prevLow
+				}
+				if sar > newLow {
+					sar = newLow
+				}
+				if offset != 0 {
+					sar -= sar * offset
+				}
+				out = append(out, sar)
+				afLong = initLong
+				ep = newHigh
+				sar = sar + afLong*(ep-sar)
+				if sar > prevLow {
+					sar = prevLow
+				}
+				if sar > newLow {
+					sar = newLow
+				}
+			} else {
+				out = append(out, -sar)
+				if newLow < ep {
+					ep = newLow
+					afShort += short
+					if afShort > maxShort {
+						afShort = maxShort
+					}
+				}
+				sar = sar + afShort*(ep-sar)
+				if sar < prevHigh {
+					sar = prevHigh
+				}
+				if sar < newHigh {
+					sar = newHigh
+				}
+			}
+		}
+	}
+	return out
+}
+
+func check(t *testing.T, name string, output *series.Series, want []float64) {
+	t.Helper()
+	if output.Len() != len(want) {
+		t.Fatalf("%s: got %d outputs, want %d", name, output.Len(), len(want))
+	}
+	reversals := 0
+	for i, w := range want {
+		out := output.At(i)
+		if got := out.GetField("direction") * out.GetField("sar"); math.Abs(got-w) > 1e-9 {
+			t.Errorf("%s[%d] = %v, want %v", name, i, got, w)
+		}
+		if i > 0 && (w > 0) != (want[i-1] > 0) {
+			reversals++
+		}
+	}
+	if reversals < 5 {
+		t.Errorf("%s: got %d reversals, want at least 5", name, reversals)
+	}
+}
+
+func TestSAR(t *testing.T) {
+	b := indicatortest.RandomBars(300, 22)
+	input := b.Series(time.Hour)
+
+	i := sarNew().(internal.Indicator)
+	check(t, "SAR", i.Compute(input), refSAREXT(b, 0, 0, 0.02, 0.02, 0.2, 0.02, 0.02, 0.2))
+	if i.Warmup() != 2 {
+		t.Errorf("Warmup() = %d, want 2", i.Warmup())
+	}
+
+	i = sarNew(opt.With("acceleration", 0.05), opt.With("maximum", 0.3)).(internal.Indicator)
+	check(t, "SAR 0.05/0.3", i.Compute(input), refSAREXT(b, 0, 0, 0.05, 0.05, 0.3, 0.05, 0.05, 0.3))
+
+	// the acceleration is limited to the maximum
+	i = sarNew(opt.With("acceleration", 0.5), opt.With("maximum", 0.1)).(internal.Indicator)
+	check(t, "SAR 0.5/0.1", i.Compute(input), refSAREXT(b, 0, 0, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1))
+
+	if err := sarNew(opt.With("acceleration", -0.02)).Init(); err == nil {
+		t.Error("Init() with a negative acceleration succeeded")
+	}
+}
+
+func TestSAREXT(t *testing.T) {
+	b := indicatortest.RandomBars(300, 22)
+	input := b.Series(time.Hour)
+
+	// the defaults are the SAR
+	check(t, "SAREXT", sarextNew().Compute(input), refSAREXT(b, 0, 0, 0.02, 0.02, 0.2, 0.02, 0.02, 0.2))
+
+	i := sarextNew(
+		opt.With("offset", 0.01),
+		opt.With("acceleration_init_long", 0.01), opt.With("acceleration_long", 0.03), opt.With("acceleration_max_long", 0.25),
+		opt.With("acceleration_init_short", 0.04), opt.With("acceleration_short", 0.01), opt.With("acceleration_max_short", 0.15),
+	)
+	check(t, "SAREXT long/short", i.Compute(input), refSAREXT(b, 0, 0.01, 0.01, 0.03, 0.25, 0.04, 0.01, 0.15))
+
+	// the shared factors apply to both directions
+	i = sarextNew(opt.With("acceleration_init", 0.03), opt.With("acceleration", 0.04), opt.With("acceleration_max", 0.3), opt.With("acceleration_short", 0.02))
+	check(t, "SAREXT shared", i.Compute(input), refSAREXT(b, 0, 0, 0.03, 0.04, 0.3, 0.03, 0.02, 0.3))
+
+	// the first trend of a start value
+	for _, start := range []float64{90, -110} {
+		i = sarextNew(opt.With("start", start))
+		check(t, "SAREXT start", i.Compute(input), refSAREXT(b, start, 0, 0.02, 0.02, 0.2, 0.02, 0.02, 0.2))
+	}
+
+	if err := sarextNew(opt.With("acceleration_max_short", -1.0)).Init(); err == nil {
+		t.Error("Init() with a negative maximum succeeded")
+	}
+}
+
+func TestSARSignals(t *testing.T) {
+	b := indicatortest.RandomBars(300, 22)
+	output := sarNew(opt.With("acceleration", 0.05), opt.With("maximum", 0.15)).Compute(b.Series(time.Hour))
+
+	// the first trend is long unless the second bar has a -DM, so the first
+	// output may already be a reversal
+	prev := 1.0
+	if down := b.Low[0] - b.Low[1]; down > 0 && b.High[1]-b.High[0] < down {
+		prev = -1
+	}
+	strengths := map[sig.Strength]int{}
+	for i := range output.Len() {
+		out := output.At(i)
+		direction := sig.BULLISH
+		if out.GetField("direction") < 0 {
+			direction = sig.BEARISH
+		}
+		if !out.HasSignal(direction) {
+			t.Errorf("output %d: direction %v with signals %v", i, out.GetField("direction"), out.SignalNames())
+		}
+		strengths[out.GetSignal(direction)]++
+
+		over, under := prev < 0 && direction == sig.BULLISH, prev > 0 && direction == sig.BEARISH
+		prev = out.GetField("direction")
+		if out.HasSignal(sig.CROSSOVER) != over || out.HasSignal(sig.CROSSUNDER) != under {
+			t.Errorf("output %d: direction %v with signals %v", i, out.GetField("direction"), out.SignalNames())
+		}
+		if (over || under) && (out.GetSignal(direction) != sig.WEAK || len(out.SignalNames()) != 2) {
+			t.Errorf("reversal %d: signals %v", i, out.Signals())
+		}
+	}
+	if strengths[sig.WEAK] == 0 || strengths[sig.MEDIUM] == 0 || strengths[sig.STRONG] == 0 {
+		t.Errorf("got strengths %v, want all of them", strengths)
+	}
+}
+
+func TestSARSnapshot(t *testing.T) {
+	input := indicatortest.RandomBars(100, 22).Series(time.Hour)
+	indicatortest.SnapshotReplay(t, func() internal.Indicator {
+		return sarextNew(opt.With("offset", 0.02), opt.With("acceleration_short", 0.03)).(internal.Indicator)
+	}, input, 40)
+}
@@ -0,0 +1,51 @@
+package sar
+
+import (
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// SAREXT = SAR with the first SAR, an offset on reversals and separate
+// initial factors, steps and maximums for long and short trends
+const sarextPluginID = "SAREXT"
+const sarextPluginName = "Parabolic SAR - Extended"
+const sarextPluginDescription = "Parabolic SAR - Extended is the Parabolic SAR with separate acceleration factors for long and short trends."
+const sarextPluginHCL = `
+indicator "sarext" {
+  start = 0
+  offset = 0
+  acceleration_init_long = 0.02
+  acceleration_long = 0.02
+  acceleration_max_long = 0.2
+  acceleration_init_short = 0.02
+  acceleration_short = 0.02
+  acceleration_max_short = 0.2
+}
+`
+
+// sarextNew returns a SAREXT, the factors of both directions default to the
+// acceleration_init, acceleration and acceleration_max parameters.
+func sarextNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	i := newSAR(sarextPluginID, sarextPluginName, sarextPluginDescription, sarextPluginHCL, params)
+	i.Start = params.Float("start", 0.0)
+	i.Offset = params.Float("offset", 0.0)
+
+	init := params.Float("acceleration_init", 0.02)
+	step := params.Float("acceleration", 0.02)
+	maximum := params.Float("acceleration_max", 0.2)
+	i.Long = newAcceleration(params,
+		params.Float("acceleration_init_long", init),
+		params.Float("acceleration_long", step),
+		params.Float("acceleration_max_long", maximum))
+	i.Short = newAcceleration(params,
+		params.Float("acceleration_init_short", init),
+		params.Float("acceleration_short", step),
+		params.Float("acceleration_max_short", maximum))
+	return i
+}
+
+func init() {
+	indicators.Add(sarextPluginID, sarextNew, indicators.TREND)
+}
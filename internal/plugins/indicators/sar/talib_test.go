@@ -0,0 +1,70 @@
+package sar
+
+import (
+	"testing"
+
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+	"github.com/rangertaha/gotal/internal/series"
+)
+
+// talibOutputs are the TA-Lib outputs of the indicators with the default
+// parameters. SAREXT is negative in short trends, the sar by the direction.
+var talibOutputs = []indicatortest.TALibCase{
+	{Name: sarPluginID, New: sarNew, Field: "sar", Want: []float64{
+		100.75000000, 100.75000000, 100.75000000, 100.63400000, 100.52264000,
+		100.33048160, 100.04964307, 99.71167876, 99.28667731, 98.91267604,
+		96.17000000, 96.26620000, 96.55075200, 96.82392192, 97.08616504,
+		97.33791844, 97.57960170, 97.81161764, 98.03435293, 98.24817881,
+		98.45345166, 98.65051359, 98.83969305, 99.16131147, 99.62040655,
+		100.04277403, 100.59349662, 101.08914696, 101.53523226, 101.93670904,
+		102.29803813, 105.55000000, 105.46320000, 105.37813600, 105.18741056,
+		105.00431414, 104.82854157, 104.65979991, 104.49780791, 104.34229560,
+		104.11295786, 103.89738039, 103.62078996, 103.36632676, 103.34000000,
+		103.34000000, 103.18000000, 103.18000000, 102.96080000, 102.92000000,
+		100.44000000, 100.51100000, 100.58058000, 100.64876840, 100.71559303,
+		103.99000000, 103.91800000, 103.84744000, 103.77829120, 103.71052538,
+		103.64411487, 103.57903257, 103.51525192, 103.45274688, 103.39149194,
+		103.33146210, 103.27263286, 103.21498021, 103.07918100, 102.86323014,
+		102.55257173, 102.07831455, 101.65148310, 101.18810513, 100.78033251,
+		100.42149261, 100.12000000, 97.79000000, 97.83660000, 97.88226800,
+		97.92702264, 97.97088219, 98.01386454, 98.10890996, 98.20015356,
+		98.34954435, 98.48997169, 98.62197339, 98.74605498, 100.69000000,
+		100.63200000, 100.57516000, 97.79000000, 97.84980000, 98.01140800,
+		98.24772352, 98.46986011, 98.88987130, 99.37488417,
+	}},
+	{Name: sarextPluginID, New: sarextNew, Field: "sar", Values: signed, Want: []float64{
+		-100.75000000, -100.75000000, -100.75000000, -100.63400000, -100.52264000,
+		-100.33048160, -100.04964307, -99.71167876, -99.28667731, -98.91267604,
+		96.17000000, 96.26620000, 96.55075200, 96.82392192, 97.08616504,
+		97.33791844, 97.57960170, 97.81161764, 98.03435293, 98.24817881,
+		98.45345166, 98.65051359, 98.83969305, 99.16131147, 99.62040655,
+		100.04277403, 100.59349662, 101.08914696, 101.53523226, 101.93670904,
+		102.29803813, -105.55000000, -105.46320000, -105.37813600, -105.18741056,
+		-105.00431414, -104.82854157, -104.65979991, -104.49780791, -104.34229560,
+		-104.11295786, -103.89738039, -103.62078996, -103.36632676, -103.34000000,
+		-103.34000000, -103.18000000, -103.18000000, -102.96080000, -102.92000000,
+		100.44000000, 100.51100000, 100.58058000, 100.64876840, 100.71559303,
+		-103.99000000, -103.91800000, -103.84744000, -103.77829120, -103.71052538,
+		-103.64411487, -103.57903257, -103.51525192, -103.45274688, -103.39149194,
+		-103.33146210, -103.27263286, -103.21498021, -103.07918100, -102.86323014,
+		-102.55257173, -102.07831455, -101.65148310, -101.18810513, -100.78033251,
+		-100.42149261, -100.12000000, 97.79000000, 97.83660000, 97.88226800,
+		97.92702264, 97.97088219, 98.01386454, 98.10890996, 98.20015356,
+		98.34954435, 98.48997169, 98.62197339, 98.74605498, -100.69000000,
+		-100.63200000, -100.57516000, 97.79000000, 97.84980000, 98.01140800,
+		98.24772352, 98.46986011, 98.88987130, 99.37488417,
+	}},
+}
+
+func TestTALib(t *testing.T) {
+	indicatortest.TALibCases(t, talibOutputs)
+}
+
+// signed returns the sar by the direction of the trend, as SAREXT outputs it.
+func signed(output *series.Series) []float64 {
+	sar := indicatortest.Fields(output, "sar")
+	for i, direction := range indicatortest.Fields(output, "direction") {
+		sar[i] *= direction
+	}
+	return sar
+}
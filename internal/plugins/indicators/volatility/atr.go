@@ -0,0 +1,76 @@
+package volatility
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// ATR = (ATR[1] * (n - 1) + TR) / n, seeded with the mean of the first n TR
+const atrPluginID = "ATR"
+const atrPluginName = "Average True Range"
+const atrPluginDescription = "Average True Range is the Wilder average of the true range, the typical range of a bar."
+const atrPluginHCL = `
+indicator "atr" {
+  period = 14
+}
+`
+
+type atrKernel struct {
+	TR     trueRange
+	Period int
+	Count  int
+	Value  float64 // sum of the true ranges until Period of them were added
+	Output string
+}
+
+func newATR(period int, output string) *atrKernel {
+	return &atrKernel{Period: max(period, 1), Output: output}
+}
+
+// atr adds a bar and returns the ATR, ok is false until warmed up.
+func (k *atrKernel) atr(high, low, last float64) (atr float64, ok bool) {
+	tr, ok := k.TR.add(high, low, last)
+	if !ok {
+		return 0, false
+	}
+
+	n := float64(k.Period)
+	switch k.Count++; {
+	case k.Count < k.Period:
+		k.Value += tr
+		return 0, false
+	case k.Count == k.Period:
+		k.Value = (k.Value + tr) / n
+	default:
+		k.Value = (k.Value*(n-1) + tr) / n
+	}
+	return k.Value, true
+}
+
+func (k *atrKernel) Add(values []float64) map[string]float64 {
+	atr, ok := k.atr(values[0], values[1], values[2])
+	if !ok {
+		return nil
+	}
+	return map[string]float64{k.Output: atr}
+}
+
+func (k *atrKernel) Warmup() int {
+	return k.Period + 1
+}
+
+func atrNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, output := params.Period(14), params.Output(strings.ToLower(atrPluginID))
+	return plugins.NewKernelIndicator(atrPluginID, atrPluginName, atrPluginDescription, atrPluginHCL, params, plugins.Bar(params, "high", "low", "close"), func() plugins.Kernel {
+		return newATR(period, output)
+	})
+}
+
+func init() {
+	indicators.Add(atrPluginID, atrNew, indicators.VOLATILITY)
+}
@@ -0,0 +1,92 @@
+package volatility
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/ma"
+)
+
+// Middle = MA(Price, n)
+// Upper = Middle + up * StdDev(Price, n), Lower = Middle - down * StdDev(Price, n)
+// Bandwidth = (Upper - Lower) / Middle
+// %B = (Price - Lower) / (Upper - Lower)
+const bbandsPluginID = "BBANDS"
+const bbandsPluginName = "Bollinger Bands"
+const bbandsPluginDescription = "Bollinger Bands are a moving average with bands a number of standard deviations above and below it."
+const bbandsPluginHCL = `
+indicator "bbands" {
+  field = "close"
+  period = 5
+  up = 2
+  down = 2
+  matype = "SMA"
+}
+`
+
+// bbandsKernel outputs upper, middle, lower, bandwidth and percent_b. The
+// standard deviation is the population one over the period, whatever the
+// average. Bandwidth is zero when the middle is, %B is 0.5 when the bands
+// are flat.
+type bbandsKernel struct {
+	Prices   plugins.Ring
+	Average  ma.Average
+	Up, Down float64
+}
+
+func (k *bbandsKernel) Add(values []float64) map[string]float64 {
+	price := values[0]
+	k.Prices.Push(price)
+	middle := k.Average.Add(price)
+	if math.IsNaN(middle) || !k.Prices.Full() {
+		return nil
+	}
+
+	n := float64(k.Prices.Len)
+	mean, variance := 0.0, 0.0
+	for j := range k.Prices.Values {
+		mean += k.Prices.Values[j]
+	}
+	mean /= n
+	for j := range k.Prices.Values {
+		d := k.Prices.Values[j] - mean
+		variance += d * d
+	}
+	std := math.Sqrt(variance / n)
+
+	upper, lower := middle+k.Up*std, middle-k.Down*std
+	bandwidth, percentB := 0.0, 0.5
+	if middle != 0 {
+		bandwidth = (upper - lower) / middle
+	}
+	if upper != lower {
+		percentB = (price - lower) / (upper - lower)
+	}
+	return map[string]float64{
+		"upper":     upper,
+		"middle":    middle,
+		"lower":     lower,
+		"bandwidth": bandwidth,
+		"percent_b": percentB,
+	}
+}
+
+func (k *bbandsKernel) Warmup() int {
+	return max(k.Average.Warmup(), len(k.Prices.Values))
+}
+
+func bbandsNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, maType := plugins.Period(params, "period", 5), params.MAType(ma.SMA)
+	up, down := params.Float("up", 2.0), params.Float("down", 2.0)
+	return plugins.NewKernelIndicator(bbandsPluginID, bbandsPluginName, bbandsPluginDescription, bbandsPluginHCL, params, []string{params.Field("close")}, func() plugins.Kernel {
+		return &bbandsKernel{Prices: plugins.NewRing(period), Average: ma.Select(params, maType, period), Up: up, Down: down}
+	})
+}
+
+func init() {
+	indicators.Add(bbandsPluginID, bbandsNew, indicators.VOLATILITY)
+}
@@ -0,0 +1,62 @@
+package volatility
+
+import (
+	"math"
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/ma"
+)
+
+// CHV = 100 * (EMA(High - Low, n) - EMA(High - Low, n)[roc]) / EMA(High - Low, n)[roc]
+const chvPluginID = "CHV"
+const chvPluginName = "Chaikin Volatility"
+const chvPluginDescription = "Chaikin Volatility is the rate of change of the average range of the bars, rising as ranges widen."
+const chvPluginHCL = `
+indicator "chv" {
+  period = 10
+  roc = 10
+}
+`
+
+type chvKernel struct {
+	Average ma.Average
+	Ranges  plugins.Ring // averages of the last roc+1 bars
+	Output  string
+}
+
+func (k *chvKernel) Add(values []float64) map[string]float64 {
+	avg := k.Average.Add(values[0] - values[1])
+	if math.IsNaN(avg) {
+		return nil
+	}
+	k.Ranges.Push(avg)
+	if !k.Ranges.Full() {
+		return nil
+	}
+
+	chv := 0.0
+	if prev := k.Ranges.At(k.Ranges.Len - 1); prev != 0 {
+		chv = 100 * (avg - prev) / prev
+	}
+	return map[string]float64{k.Output: chv}
+}
+
+func (k *chvKernel) Warmup() int {
+	return k.Average.Warmup() + len(k.Ranges.Values) - 1
+}
+
+func chvNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, roc, output := params.Period(10), params.Int("roc", 10), params.Output(strings.ToLower(chvPluginID))
+	return plugins.NewKernelIndicator(chvPluginID, chvPluginName, chvPluginDescription, chvPluginHCL, params, plugins.Bar(params, "high", "low"), func() plugins.Kernel {
+		return &chvKernel{Average: ma.Select(params, params.MAType(ma.EMA), period), Ranges: plugins.NewRing(roc + 1), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(chvPluginID, chvNew, indicators.VOLATILITY)
+}
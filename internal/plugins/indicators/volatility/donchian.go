@@ -0,0 +1,59 @@
+package volatility
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// Upper = HighestHigh(n), Lower = LowestLow(n), Middle = (Upper + Lower) / 2
+const donchianPluginID = "DONCHIAN"
+const donchianPluginName = "Donchian Channels"
+const donchianPluginDescription = "Donchian Channels are the highest high and the lowest low of the period, the breakout levels."
+const donchianPluginHCL = `
+indicator "donchian" {
+  period = 20
+}
+`
+
+type donchianKernel struct {
+	High, Low plugins.Ring
+}
+
+func (k *donchianKernel) Add(values []float64) map[string]float64 {
+	k.High.Push(values[0])
+	k.Low.Push(values[1])
+	if !k.High.Full() {
+		return nil
+	}
+
+	upper, lower := math.Inf(-1), math.Inf(1)
+	for j := range k.High.Values {
+		upper = max(upper, k.High.Values[j])
+		lower = min(lower, k.Low.Values[j])
+	}
+	return map[string]float64{
+		"upper":  upper,
+		"middle": (upper + lower) / 2,
+		"lower":  lower,
+	}
+}
+
+func (k *donchianKernel) Warmup() int {
+	return len(k.High.Values)
+}
+
+func donchianNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period := params.Period(20)
+	return plugins.NewKernelIndicator(donchianPluginID, donchianPluginName, donchianPluginDescription, donchianPluginHCL, params, plugins.Bar(params, "high", "low"), func() plugins.Kernel {
+		return &donchianKernel{High: plugins.NewRing(period), Low: plugins.NewRing(period)}
+	})
+}
+
+func init() {
+	indicators.Add(donchianPluginID, donchianNew, indicators.VOLATILITY)
+}
@@ -0,0 +1,61 @@
+package volatility
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/ma"
+)
+
+// Middle = EMA(Close, n)
+// Upper = Middle + multiplier * ATR(atr), Lower = Middle - multiplier * ATR(atr)
+const keltnerPluginID = "KELTNER"
+const keltnerPluginName = "Keltner Channels"
+const keltnerPluginDescription = "Keltner Channels are a moving average of the close with bands a multiple of the ATR above and below it."
+const keltnerPluginHCL = `
+indicator "keltner" {
+  period = 20
+  atr = 10
+  multiplier = 2
+  matype = "EMA"
+}
+`
+
+type keltnerKernel struct {
+	Average    ma.Average
+	ATR        *atrKernel
+	Multiplier float64
+}
+
+func (k *keltnerKernel) Add(values []float64) map[string]float64 {
+	middle := k.Average.Add(values[2])
+	atr, ok := k.ATR.atr(values[0], values[1], values[2])
+	if math.IsNaN(middle) || !ok {
+		return nil
+	}
+	return map[string]float64{
+		"upper":  middle + k.Multiplier*atr,
+		"middle": middle,
+		"lower":  middle - k.Multiplier*atr,
+	}
+}
+
+func (k *keltnerKernel) Warmup() int {
+	return max(k.Average.Warmup(), k.ATR.Warmup())
+}
+
+func keltnerNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, atr, maType := params.Period(20), params.Int("atr", 10), params.MAType(ma.EMA)
+	multiplier := params.Float("multiplier", 2.0)
+	return plugins.NewKernelIndicator(keltnerPluginID, keltnerPluginName, keltnerPluginDescription, keltnerPluginHCL, params, plugins.Bar(params, "high", "low", "close"), func() plugins.Kernel {
+		return &keltnerKernel{Average: ma.Select(params, maType, period), ATR: newATR(atr, ""), Multiplier: multiplier}
+	})
+}
+
+func init() {
+	indicators.Add(keltnerPluginID, keltnerNew, indicators.VOLATILITY)
+}
@@ -0,0 +1,53 @@
+package volatility
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// NATR = 100 * ATR / Close
+const natrPluginID = "NATR"
+const natrPluginName = "Normalized Average True Range"
+const natrPluginDescription = "Normalized Average True Range is the ATR in percent of the close, comparable across prices."
+const natrPluginHCL = `
+indicator "natr" {
+  period = 14
+}
+`
+
+type natrKernel struct {
+	ATR    *atrKernel
+	Output string
+}
+
+func (k *natrKernel) Add(values []float64) map[string]float64 {
+	atr, ok := k.ATR.atr(values[0], values[1], values[2])
+	if !ok {
+		return nil
+	}
+	natr := 0.0
+	if last := values[2]; last < -1e-8 || last > 1e-8 {
+		natr = (atr / last) * 100
+	}
+	return map[string]float64{k.Output: natr}
+}
+
+func (k *natrKernel) Warmup() int {
+	return k.ATR.Warmup()
+}
+
+func natrNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, output := params.Period(14), params.Output(strings.ToLower(natrPluginID))
+	return plugins.NewKernelIndicator(natrPluginID, natrPluginName, natrPluginDescription, natrPluginHCL, params, plugins.Bar(params, "high", "low", "close"), func() plugins.Kernel {
+		return &natrKernel{ATR: newATR(period, ""), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(natrPluginID, natrNew, indicators.VOLATILITY)
+}
@@ -0,0 +1,143 @@
+package volatility
+
+import (
+	"testing"
+
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+)
+
+// talibOutputs are the TA-Lib outputs of the indicators with the default parameters.
+var talibOutputs = []indicatortest.TALibCase{
+	{Name: trangePluginID, New: trangeNew, Field: "trange", Want: []float64{
+		2.48000000, 1.42000000, 1.36000000, 1.16000000, 1.48000000,
+		1.15000000, 1.55000000, 2.49000000, 0.25000000, 0.25000000,
+		3.57000000, 3.40000000, 1.48000000, 1.42000000, 1.43000000,
+		1.37000000, 2.07000000, 1.00000000, 2.00000000, 0.56000000,
+		1.16000000, 2.38000000, 2.10000000, 1.95000000, 1.00000000,
+		1.40000000, 2.34000000, 1.56000000, 2.65000000, 0.00000000,
+		0.50000000, 1.91000000, 1.91000000, 2.10000000, 2.10000000,
+		2.10000000, 0.86000000, 1.50000000, 1.50000000, 1.95000000,
+		0.65000000, 1.23000000, 2.15000000, 2.67000000, 2.51000000,
+		2.25000000, 0.74000000, 1.65000000, 1.00000000, 0.30000000,
+		2.42000000, 2.42000000, 2.91000000, 2.91000000, 0.93000000,
+		2.62000000, 2.75000000, 1.63000000, 0.25000000, 1.55000000,
+		1.22000000, 2.38000000, 2.38000000, 1.01000000, 1.46000000,
+		2.23000000, 2.04000000, 1.82000000, 1.59000000, 2.28000000,
+		3.20000000, 2.34000000, 2.11000000, 1.00000000, 2.11000000,
+		1.22000000, 0.50000000, 1.50000000, 1.50000000, 1.63000000,
+		1.32000000, 1.53000000, 1.80000000, 1.38000000, 1.93000000,
+		1.93000000, 0.54000000, 0.74000000, 0.74000000, 2.43000000,
+		1.49000000, 0.50000000, 2.00000000, 1.61000000, 1.31000000,
+		1.31000000, 2.27000000, 1.02000000, 2.05000000,
+	}},
+	{Name: atrPluginID, New: atrNew, Field: "atr", Want: []float64{
+		1.67571429, 1.65816327, 1.63758017, 1.66846731, 1.62071964,
+		1.64781109, 1.57011030, 1.54081671, 1.60075837, 1.63641849,
+		1.65881717, 1.61175880, 1.59663317, 1.64973080, 1.64332146,
+		1.71522707, 1.59271085, 1.51466007, 1.54289864, 1.56912017,
+		1.60704015, 1.64225157, 1.67494789, 1.61673732, 1.60839894,
+		1.60065616, 1.62560929, 1.55592292, 1.53264271, 1.57673966,
+		1.65482968, 1.71591328, 1.75406233, 1.68162930, 1.67937007,
+		1.63084363, 1.53578337, 1.59894171, 1.65758873, 1.74704667,
+		1.83011477, 1.76582086, 1.82683365, 1.89277411, 1.87400453,
+		1.75800420, 1.74314676, 1.70577914, 1.75393777, 1.79865650,
+		1.74232389, 1.72215790, 1.75843234, 1.77854431, 1.78150543,
+		1.76782647, 1.80441030, 1.90409527, 1.93523133, 1.94771480,
+		1.88002089, 1.89644797, 1.84813026, 1.75183524, 1.73384701,
+		1.71714365, 1.71091910, 1.68299631, 1.67206800, 1.68120600,
+		1.65969129, 1.67899905, 1.69692769, 1.61429000, 1.55184071,
+		1.49385209, 1.56071980, 1.55566838, 1.48026350, 1.51738754,
+		1.52400271, 1.50871680, 1.49452275, 1.54991398, 1.51206298,
+		1.55048705,
+	}},
+	{Name: natrPluginID, New: natrNew, Field: "natr", Want: []float64{
+		1.63372749, 1.63140817, 1.59748334, 1.64073882, 1.59378468,
+		1.61249740, 1.53736444, 1.51476279, 1.56400427, 1.58184484,
+		1.58890533, 1.54383027, 1.51987927, 1.59842147, 1.57602518,
+		1.66737345, 1.54827535, 1.47240213, 1.51323915, 1.53895662,
+		1.58157677, 1.61623026, 1.64840851, 1.58939965, 1.58120227,
+		1.57359041, 1.60522296, 1.53413815, 1.51461874, 1.54068757,
+		1.62764796, 1.67112707, 1.72508097, 1.64591299, 1.64611847,
+		1.59464519, 1.50096108, 1.55252132, 1.60946570, 1.71565027,
+		1.79722554, 1.72679528, 1.80624249, 1.84859274, 1.83707923,
+		1.72336458, 1.70378923, 1.66368783, 1.73399680, 1.77820712,
+		1.71387359, 1.70173706, 1.72091636, 1.75849744, 1.77140840,
+		1.77261253, 1.79973100, 1.93191485, 1.95182181, 1.97657277,
+		1.90787588, 1.91270597, 1.85984729, 1.76294177, 1.74483950,
+		1.72803024, 1.73275178, 1.69468967, 1.68317697, 1.68728021,
+		1.67628652, 1.68422013, 1.70220453, 1.61866038, 1.55230641,
+		1.49430038, 1.57983581, 1.56301455, 1.48725359, 1.51315071,
+		1.49941235, 1.48349735, 1.46954056, 1.50887264, 1.46816485,
+		1.49386940,
+	}},
+	{Name: bbandsPluginID, New: bbandsNew, Field: "upper", Want: []float64{
+		99.27692546, 99.49124688, 99.32514114, 99.47736402, 99.14383447,
+		98.87211386, 99.04011386, 100.48206135, 102.71359756, 103.76815527,
+		104.35839312, 103.47735855, 102.92468511, 102.89710127, 102.87078787,
+		102.63815848, 102.67222536, 102.33683109, 102.54418179, 103.52706169,
+		104.76955097, 105.42454067, 105.81106353, 105.45760466, 105.45451841,
+		105.56708432, 105.38714050, 104.30246484, 104.44856206, 103.39761427,
+		103.29189017, 102.92472639, 102.09292856, 101.97370572, 101.76177755,
+		101.78377755, 101.95657424, 101.94947332, 101.90736892, 102.42259212,
+		102.40720685, 102.98477553, 102.97282232, 102.88768199, 102.78875231,
+		102.81669901, 102.55215650, 103.02220356, 103.31513487, 103.37995555,
+		103.43203077, 103.42445201, 103.22477570, 102.76480283, 102.80277591,
+		102.83482530, 102.85927899, 102.66646128, 102.93994669, 102.98848177,
+		102.90877326, 102.60168416, 102.27689060, 102.27806896, 102.43074049,
+		102.57479359, 102.45087791, 101.80130386, 101.11356706, 100.58685922,
+		100.34458608, 99.37932394, 99.63853468, 99.75258816, 99.80299300,
+		99.50200000, 99.74800000, 99.72619025, 99.71403279, 99.86910101,
+		99.82293414, 99.89235210, 100.00625558, 100.09699908, 100.26079390,
+		100.07290683, 100.50196330, 100.47055029, 100.42096234, 100.62526613,
+		101.88549062, 102.46749062, 102.77319716, 103.16185456, 103.31468021,
+		104.18014999,
+	}},
+	{Name: bbandsPluginID, New: bbandsNew, Field: "middle", Want: []float64{
+		98.68000000, 98.44000000, 98.28000000, 98.09400000, 97.98400000,
+		97.90600000, 98.07400000, 98.52600000, 99.56800000, 100.31600000,
+		101.14800000, 101.79400000, 102.25000000, 102.11200000, 102.02000000,
+		101.94400000, 102.04200000, 101.88400000, 102.01600000, 102.36800000,
+		102.81000000, 103.26400000, 103.93000000, 104.10200000, 104.26600000,
+		103.96000000, 103.65400000, 103.21800000, 102.96800000, 102.50600000,
+		102.25400000, 102.00200000, 101.75000000, 101.70200000, 101.65400000,
+		101.67600000, 101.60800000, 101.57000000, 101.46400000, 101.58800000,
+		101.57800000, 101.86000000, 101.91200000, 102.10800000, 102.04400000,
+		102.16400000, 102.09200000, 102.35400000, 102.51800000, 102.48000000,
+		102.39200000, 102.38000000, 102.01000000, 101.89000000, 101.92600000,
+		101.96200000, 101.97200000, 102.25000000, 102.00200000, 101.83000000,
+		101.76000000, 101.53800000, 101.46800000, 101.46600000, 101.35000000,
+		100.96400000, 100.77600000, 100.05200000, 99.65400000, 99.24800000,
+		99.01000000, 98.78800000, 98.95000000, 98.99400000, 99.16000000,
+		99.32600000, 99.24400000, 99.23200000, 99.22600000, 99.28000000,
+		99.20800000, 99.39800000, 99.47400000, 99.55200000, 99.61800000,
+		99.81000000, 99.63000000, 99.59800000, 99.55800000, 99.62000000,
+		99.95400000, 100.53600000, 100.97000000, 101.60800000, 102.15000000,
+		102.58000000,
+	}},
+	{Name: bbandsPluginID, New: bbandsNew, Field: "lower", Want: []float64{
+		98.08307454, 97.38875312, 97.23485886, 96.71063598, 96.82416553,
+		96.93988614, 97.10788614, 96.56993865, 96.42240244, 96.86384473,
+		97.93760688, 100.11064145, 101.57531489, 101.32689873, 101.16921213,
+		101.24984152, 101.41177464, 101.43116891, 101.48781821, 101.20893831,
+		100.85044903, 101.10345933, 102.04893647, 102.74639534, 103.07748159,
+		102.35291568, 101.92085950, 102.13353516, 101.48743794, 101.61438573,
+		101.21610983, 101.07927361, 101.40707144, 101.43029428, 101.54622245,
+		101.56822245, 101.25942576, 101.19052668, 101.02063108, 100.75340788,
+		100.74879315, 100.73522447, 100.85117768, 101.32831801, 101.29924769,
+		101.51130099, 101.63184350, 101.68579644, 101.72086513, 101.58004445,
+		101.35196923, 101.33554799, 100.79522430, 101.01519717, 101.04922409,
+		101.08917470, 101.08472101, 101.83353872, 101.06405331, 100.67151823,
+		100.61122674, 100.47431584, 100.65910940, 100.65393104, 100.26925951,
+		99.35320641, 99.10112209, 98.30269614, 98.19443294, 97.90914078,
+		97.67541392, 98.19667606, 98.26146532, 98.23541184, 98.51700700,
+		99.15000000, 98.74000000, 98.73780975, 98.73796721, 98.69089899,
+		98.59306586, 98.90364790, 98.94174442, 99.00700092, 98.97520610,
+		99.54709317, 98.75803670, 98.72544971, 98.69503766, 98.61473387,
+		98.02250938, 98.60450938, 99.16680284, 100.05414544, 100.98531979,
+		100.97985001,
+	}},
+}
+
+func TestTALib(t *testing.T) {
+	indicatortest.TALibCases(t, talibOutputs)
+}
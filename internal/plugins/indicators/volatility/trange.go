@@ -0,0 +1,47 @@
+package volatility
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// TRANGE = max(High - Low, |High - PrevClose|, |Low - PrevClose|)
+const trangePluginID = "TRANGE"
+const trangePluginName = "True Range"
+const trangePluginDescription = "True Range is the range of a bar extended to the previous close, so gaps count as range."
+const trangePluginHCL = `
+indicator "trange" {}
+`
+
+type trangeKernel struct {
+	TR     trueRange
+	Output string
+}
+
+func (k *trangeKernel) Add(values []float64) map[string]float64 {
+	tr, ok := k.TR.add(values[0], values[1], values[2])
+	if !ok {
+		return nil
+	}
+	return map[string]float64{k.Output: tr}
+}
+
+func (k *trangeKernel) Warmup() int {
+	return 2
+}
+
+func trangeNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	output := params.Output(strings.ToLower(trangePluginID))
+	return plugins.NewKernelIndicator(trangePluginID, trangePluginName, trangePluginDescription, trangePluginHCL, params, plugins.Bar(params, "high", "low", "close"), func() plugins.Kernel {
+		return &trangeKernel{Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(trangePluginID, trangeNew, indicators.VOLATILITY)
+}
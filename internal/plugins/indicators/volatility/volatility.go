@@ -0,0 +1,32 @@
+package volatility
+
+import (
+	"encoding/gob"
+	"math"
+)
+
+func init() {
+	gob.Register(&trangeKernel{})
+	gob.Register(&atrKernel{})
+	gob.Register(&natrKernel{})
+	gob.Register(&bbandsKernel{})
+	gob.Register(&keltnerKernel{})
+	gob.Register(&donchianKernel{})
+	gob.Register(&chvKernel{})
+}
+
+// trueRange is the range of a bar extended to the previous close.
+type trueRange struct {
+	Count     int
+	PrevClose float64
+}
+
+// add adds a bar and returns its true range, ok is false for the first bar.
+func (t *trueRange) add(high, low, last float64) (tr float64, ok bool) {
+	prev := t.PrevClose
+	t.PrevClose = last
+	if t.Count++; t.Count == 1 {
+		return 0, false
+	}
+	return max(high-low, math.Abs(high-prev), math.Abs(low-prev)), true
+}
@@ -0,0 +1,140 @@
+package volatility
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+)
+
+func TestRanges(t *testing.T) {
+	b := indicatortest.RandomBars(120, 9)
+	input := b.Series(time.Minute)
+	tr := indicatortest.TrueRange(b)
+
+	indicatortest.Near(t, "TRANGE", indicatortest.Fields(trangeNew().Compute(input), "trange"), tr)
+	for _, n := range []int{1, 2, 14} {
+		atr := indicatortest.Wilder(tr, n)
+		natr := make([]float64, len(atr))
+		for i, last := range indicatortest.Tail(b.Close, len(atr)) {
+			natr[i] = 100 * atr[i] / last
+		}
+
+		i := atrNew(opt.WithPeriod(n)).(internal.Indicator)
+		indicatortest.Near(t, fmt.Sprintf("ATR(%d)", n), indicatortest.Fields(i.Compute(input), "atr"), atr)
+		indicatortest.Near(t, fmt.Sprintf("NATR(%d)", n), indicatortest.Fields(natrNew(opt.WithPeriod(n)).Compute(input), "natr"), natr)
+		if i.Warmup() != n+1 {
+			t.Errorf("ATR(%d): Warmup() = %d, want %d", n, i.Warmup(), n+1)
+		}
+	}
+}
+
+func TestBBANDS(t *testing.T) {
+	b := indicatortest.RandomBars(100, 9)
+	input := b.Series(time.Minute)
+
+	middle, std := indicatortest.SMA(b.Close, 5), indicatortest.Std(b.Close, 5)
+	var upper, lower, bandwidth, percentB []float64
+	for i := range middle {
+		u, l := middle[i]+2*std[i], middle[i]-1.5*std[i]
+		upper, lower = append(upper, u), append(lower, l)
+		bandwidth = append(bandwidth, (u-l)/middle[i])
+		percentB = append(percentB, (b.Close[i+4]-l)/(u-l))
+	}
+	output := bbandsNew(opt.With("down", 1.5)).Compute(input)
+	indicatortest.Near(t, "upper", indicatortest.Fields(output, "upper"), upper)
+	indicatortest.Near(t, "middle", indicatortest.Fields(output, "middle"), middle)
+	indicatortest.Near(t, "lower", indicatortest.Fields(output, "lower"), lower)
+	indicatortest.Near(t, "bandwidth", indicatortest.Fields(output, "bandwidth"), bandwidth)
+	indicatortest.Near(t, "percent_b", indicatortest.Fields(output, "percent_b"), percentB)
+
+	// the middle band follows the MA type, the deviation stays over the period
+	output = bbandsNew(opt.WithPeriod(10), opt.WithMAType("ema")).Compute(input)
+	ema, std := indicatortest.EMA(b.Close, 10), indicatortest.Std(b.Close, 10)
+	for i := range ema {
+		ema[i] += 2 * std[i]
+	}
+	indicatortest.Near(t, "EMA upper", indicatortest.Fields(output, "upper"), ema)
+
+	// flat prices have flat bands
+	var flat indicatortest.Bars
+	for range 5 {
+		flat.Add(1, 1, 1, 1, 1000)
+	}
+	out := bbandsNew().Compute(flat.Series(time.Minute)).At(0)
+	if out.GetField("upper") != 1 || out.GetField("lower") != 1 || out.GetField("percent_b") != 0.5 || out.GetField("bandwidth") != 0 {
+		t.Errorf("flat bands %v", out.Fields())
+	}
+
+	if err := bbandsNew(opt.WithPeriod(0)).Init(); err == nil {
+		t.Error("Init() with a period of 0 succeeded")
+	}
+}
+
+func TestChannels(t *testing.T) {
+	b := indicatortest.RandomBars(100, 9)
+	input := b.Series(time.Minute)
+
+	// Keltner
+	middle, atr := indicatortest.EMA(b.Close, 20), indicatortest.Wilder(indicatortest.TrueRange(b), 10)
+	atr = indicatortest.Tail(atr, len(middle))
+	var upper, lower []float64
+	for i := range middle {
+		upper, lower = append(upper, middle[i]+2*atr[i]), append(lower, middle[i]-2*atr[i])
+	}
+	output := keltnerNew().Compute(input)
+	indicatortest.Near(t, "Keltner upper", indicatortest.Fields(output, "upper"), upper)
+	indicatortest.Near(t, "Keltner middle", indicatortest.Fields(output, "middle"), middle)
+	indicatortest.Near(t, "Keltner lower", indicatortest.Fields(output, "lower"), lower)
+
+	// Donchian
+	upper, middle, lower = nil, nil, nil
+	for i := 19; i < len(b.Close); i++ {
+		hh, ll := math.Inf(-1), math.Inf(1)
+		for j := i - 19; j <= i; j++ {
+			hh, ll = math.Max(hh, b.High[j]), math.Min(ll, b.Low[j])
+		}
+		upper, middle, lower = append(upper, hh), append(middle, (hh+ll)/2), append(lower, ll)
+	}
+	output = donchianNew().Compute(input)
+	indicatortest.Near(t, "Donchian upper", indicatortest.Fields(output, "upper"), upper)
+	indicatortest.Near(t, "Donchian middle", indicatortest.Fields(output, "middle"), middle)
+	indicatortest.Near(t, "Donchian lower", indicatortest.Fields(output, "lower"), lower)
+}
+
+func TestCHV(t *testing.T) {
+	b := indicatortest.RandomBars(100, 9)
+	ranges := make([]float64, len(b.Close))
+	for i := range ranges {
+		ranges[i] = b.High[i] - b.Low[i]
+	}
+	avg := indicatortest.EMA(ranges, 10)
+	var want []float64
+	for i := 10; i < len(avg); i++ {
+		want = append(want, 100*(avg[i]-avg[i-10])/avg[i-10])
+	}
+
+	i := chvNew().(internal.Indicator)
+	indicatortest.Near(t, "CHV", indicatortest.Fields(i.Compute(b.Series(time.Minute)), "chv"), want)
+	if i.Warmup() != len(b.Close)-len(want)+1 {
+		t.Errorf("Warmup() = %d, want %d", i.Warmup(), len(b.Close)-len(want)+1)
+	}
+}
+
+func TestVolatilitySnapshot(t *testing.T) {
+	input := indicatortest.RandomBars(80, 9).Series(time.Minute)
+	for id, new := range map[string]func(...internal.PluginOptions) internal.Plugin{
+		trangePluginID: trangeNew, atrPluginID: atrNew, natrPluginID: natrNew, bbandsPluginID: bbandsNew,
+		keltnerPluginID: keltnerNew, donchianPluginID: donchianNew, chvPluginID: chvNew,
+	} {
+		t.Run(id, func(t *testing.T) {
+			indicatortest.SnapshotReplay(t, func() internal.Indicator {
+				return new(opt.WithPeriod(7)).(internal.Indicator)
+			}, input, 30)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package volume
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// CLV = ((Close - Low) - (High - Close)) / (High - Low)
+// AD = AD[1] + CLV * Volume
+// ADOSC = EMA(AD, fast) - EMA(AD, slow), both EMAs starting at the first AD
+const adPluginID = "AD"
+const adPluginName = "Chaikin A/D Line"
+const adPluginDescription = "Chaikin A/D Line is the running total of the volume weighted by where the close is within the range of the bar."
+const adPluginHCL = `
+indicator "ad" {}
+`
+
+const adoscPluginID = "ADOSC"
+const adoscPluginName = "Chaikin A/D Oscillator"
+const adoscPluginDescription = "Chaikin A/D Oscillator is the difference of a fast and a slow EMA of the A/D line, the momentum of accumulation."
+const adoscPluginHCL = `
+indicator "adosc" {
+  fast = 3
+  slow = 10
+}
+`
+
+type adKernel struct {
+	Value  float64
+	Output string
+}
+
+// ad adds a bar and returns the A/D line.
+func (k *adKernel) ad(values []float64) float64 {
+	k.Value += clv(values[0], values[1], values[2]) * values[3]
+	return k.Value
+}
+
+func (k *adKernel) Add(values []float64) map[string]float64 {
+	return map[string]float64{k.Output: k.ad(values)}
+}
+
+func (k *adKernel) Warmup() int {
+	return 1
+}
+
+// adoscKernel seeds the EMAs with the first A/D value like TA-Lib, and
+// outputs from the slow period on.
+type adoscKernel struct {
+	AD         adKernel
+	Count      int
+	FastPeriod int
+	SlowPeriod int
+	Fast, Slow float64
+	Output     string
+}
+
+func (k *adoscKernel) Add(values []float64) map[string]float64 {
+	ad := k.AD.ad(values)
+	if k.Count++; k.Count == 1 {
+		k.Fast, k.Slow = ad, ad
+	} else {
+		fast, slow := 2/float64(k.FastPeriod+1), 2/float64(k.SlowPeriod+1)
+		k.Fast = fast*ad + (1-fast)*k.Fast
+		k.Slow = slow*ad + (1-slow)*k.Slow
+	}
+	if k.Count < k.Warmup() {
+		return nil
+	}
+	return map[string]float64{k.Output: k.Fast - k.Slow}
+}
+
+func (k *adoscKernel) Warmup() int {
+	return max(k.FastPeriod, k.SlowPeriod)
+}
+
+func adNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	output := params.Output(strings.ToLower(adPluginID))
+	return plugins.NewKernelIndicator(adPluginID, adPluginName, adPluginDescription, adPluginHCL, params, plugins.Bar(params, "high", "low", "close", "volume"), func() plugins.Kernel {
+		return &adKernel{Output: output}
+	})
+}
+
+func adoscNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	fast, slow, output := params.FastPeriod(3), params.SlowPeriod(10), params.Output(strings.ToLower(adoscPluginID))
+	return plugins.NewKernelIndicator(adoscPluginID, adoscPluginName, adoscPluginDescription, adoscPluginHCL, params, plugins.Bar(params, "high", "low", "close", "volume"), func() plugins.Kernel {
+		return &adoscKernel{FastPeriod: fast, SlowPeriod: slow, Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(adPluginID, adNew, indicators.VOLUME)
+	indicators.Add(adoscPluginID, adoscNew, indicators.VOLUME)
+}
@@ -0,0 +1,56 @@
+package volume
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// CMF = Sum(CLV * Volume, n) / Sum(Volume, n)
+const cmfPluginID = "CMF"
+const cmfPluginName = "Chaikin Money Flow"
+const cmfPluginDescription = "Chaikin Money Flow is the volume weighted average close location value of the period, between -1 and 1."
+const cmfPluginHCL = `
+indicator "cmf" {
+  period = 20
+}
+`
+
+type cmfKernel struct {
+	Flows, Volumes plugins.Ring
+	Output         string
+}
+
+func (k *cmfKernel) Add(values []float64) map[string]float64 {
+	volume := values[3]
+	k.Flows.Push(clv(values[0], values[1], values[2]) * volume)
+	k.Volumes.Push(volume)
+	if !k.Flows.Full() {
+		return nil
+	}
+
+	cmf := 0.0
+	if volumes := k.Volumes.Sum(); volumes != 0 {
+		cmf = k.Flows.Sum() / volumes
+	}
+	return map[string]float64{k.Output: cmf}
+}
+
+func (k *cmfKernel) Warmup() int {
+	return len(k.Flows.Values)
+}
+
+func cmfNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, output := params.Period(20), params.Output(strings.ToLower(cmfPluginID))
+	return plugins.NewKernelIndicator(cmfPluginID, cmfPluginName, cmfPluginDescription, cmfPluginHCL, params, plugins.Bar(params, "high", "low", "close", "volume"), func() plugins.Kernel {
+		return &cmfKernel{Flows: plugins.NewRing(period), Volumes: plugins.NewRing(period), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(cmfPluginID, cmfNew, indicators.VOLUME)
+}
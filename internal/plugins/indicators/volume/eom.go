@@ -0,0 +1,70 @@
+package volume
+
+import (
+	"math"
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/ma"
+)
+
+// Distance = (High + Low) / 2 - (PrevHigh + PrevLow) / 2
+// EMV = Distance / ((Volume / scale) / (High - Low))
+// EOM = SMA(EMV, n)
+const eomPluginID = "EOM"
+const eomPluginName = "Ease of Movement"
+const eomPluginDescription = "Ease of Movement relates the move of the midpoint to the volume, high when prices move easily on little volume."
+const eomPluginHCL = `
+indicator "eom" {
+  period = 14
+  scale = 100000000
+}
+`
+
+// eomKernel counts bars without range or volume as not moving.
+type eomKernel struct {
+	Count             int
+	PrevHigh, PrevLow float64
+	Scale             float64
+	Average           ma.Average
+	Output            string
+}
+
+func (k *eomKernel) Add(values []float64) map[string]float64 {
+	high, low, volume := values[0], values[1], values[2]
+	distance := (high+low)/2 - (k.PrevHigh+k.PrevLow)/2
+	k.PrevHigh, k.PrevLow = high, low
+	if k.Count++; k.Count == 1 {
+		return nil
+	}
+
+	emv := 0.0
+	if volume != 0 && high != low {
+		emv = distance / ((volume / k.Scale) / (high - low))
+	}
+	eom := k.Average.Add(emv)
+	if math.IsNaN(eom) {
+		return nil
+	}
+	return map[string]float64{k.Output: eom}
+}
+
+func (k *eomKernel) Warmup() int {
+	return k.Average.Warmup() + 1
+}
+
+func eomNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, scale, output := plugins.Period(params, "period", 14), params.Float("scale", 1e8), params.Output(strings.ToLower(eomPluginID))
+	return plugins.NewKernelIndicator(eomPluginID, eomPluginName, eomPluginDescription, eomPluginHCL, params, plugins.Bar(params, "high", "low", "volume"), func() plugins.Kernel {
+		sma, _ := ma.NewAverage(ma.SMA, period)
+		return &eomKernel{Scale: scale, Average: sma, Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(eomPluginID, eomNew, indicators.VOLUME)
+}
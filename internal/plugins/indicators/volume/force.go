@@ -0,0 +1,59 @@
+package volume
+
+import (
+	"math"
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/ma"
+)
+
+// FORCE = EMA((Close - PrevClose) * Volume, n)
+const forcePluginID = "FORCE"
+const forcePluginName = "Force Index"
+const forcePluginDescription = "Force Index is the smoothed price change times the volume, the force behind a move."
+const forcePluginHCL = `
+indicator "force" {
+  period = 13
+}
+`
+
+type forceKernel struct {
+	Count     int
+	PrevClose float64
+	Average   ma.Average
+	Output    string
+}
+
+func (k *forceKernel) Add(values []float64) map[string]float64 {
+	prev := k.PrevClose
+	k.PrevClose = values[0]
+	if k.Count++; k.Count == 1 {
+		return nil
+	}
+
+	force := k.Average.Add((values[0] - prev) * values[1])
+	if math.IsNaN(force) {
+		return nil
+	}
+	return map[string]float64{k.Output: force}
+}
+
+func (k *forceKernel) Warmup() int {
+	return k.Average.Warmup() + 1
+}
+
+func forceNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, output := params.Period(13), params.Output(strings.ToLower(forcePluginID))
+	return plugins.NewKernelIndicator(forcePluginID, forcePluginName, forcePluginDescription, forcePluginHCL, params, plugins.Bar(params, "close", "volume"), func() plugins.Kernel {
+		return &forceKernel{Average: ma.NewEMA(period, 2/float64(period+1)), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(forcePluginID, forceNew, indicators.VOLUME)
+}
@@ -0,0 +1,56 @@
+package volume
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// OBV = OBV[1] + Volume when the close rises, - Volume when it falls,
+// starting at the first volume
+const obvPluginID = "OBV"
+const obvPluginName = "On Balance Volume"
+const obvPluginDescription = "On Balance Volume is the running total of the volume signed by the direction of the close."
+const obvPluginHCL = `
+indicator "obv" {}
+`
+
+type obvKernel struct {
+	Count     int
+	PrevClose float64
+	Value     float64
+	Output    string
+}
+
+func (k *obvKernel) Add(values []float64) map[string]float64 {
+	last, volume := values[0], values[1]
+	switch k.Count++; {
+	case k.Count == 1:
+		k.Value = volume
+	case last > k.PrevClose:
+		k.Value += volume
+	case last < k.PrevClose:
+		k.Value -= volume
+	}
+	k.PrevClose = last
+	return map[string]float64{k.Output: k.Value}
+}
+
+func (k *obvKernel) Warmup() int {
+	return 1
+}
+
+func obvNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	output := params.Output(strings.ToLower(obvPluginID))
+	return plugins.NewKernelIndicator(obvPluginID, obvPluginName, obvPluginDescription, obvPluginHCL, params, plugins.Bar(params, "close", "volume"), func() plugins.Kernel {
+		return &obvKernel{Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(obvPluginID, obvNew, indicators.VOLUME)
+}
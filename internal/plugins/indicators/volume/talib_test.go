@@ -0,0 +1,80 @@
+package volume
+
+import (
+	"testing"
+
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+)
+
+// talibOutputs are the TA-Lib outputs of the indicators with the default parameters.
+var talibOutputs = []indicatortest.TALibCase{
+	{Name: obvPluginID, New: obvNew, Field: "obv", Want: []float64{
+		2081.00000000, 2081.00000000, 870.00000000, 2398.00000000, -392.00000000,
+		-821.00000000, 592.00000000, -3486.00000000, -1297.00000000, -1297.00000000,
+		-1297.00000000, 266.00000000, 1843.00000000, 706.00000000, 4597.00000000,
+		1657.00000000, 4082.00000000, 672.00000000, 672.00000000, 4943.00000000,
+		2877.00000000, 1825.00000000, 3353.00000000, 6965.00000000, 9016.00000000,
+		9016.00000000, 12783.00000000, 10441.00000000, 14976.00000000, 10605.00000000,
+		10605.00000000, 10605.00000000, 9910.00000000, 9910.00000000, 6463.00000000,
+		6463.00000000, 6463.00000000, 10849.00000000, 10849.00000000, 10849.00000000,
+		8810.00000000, 10319.00000000, 9579.00000000, 10000.00000000, 6662.00000000,
+		10186.00000000, 6564.00000000, 10043.00000000, 5532.00000000, 8940.00000000,
+		11315.00000000, 12429.00000000, 12429.00000000, 7446.00000000, 7446.00000000,
+		10333.00000000, 6731.00000000, 8851.00000000, 8295.00000000, 8295.00000000,
+		11560.00000000, 16233.00000000, 16185.00000000, 16185.00000000, 20515.00000000,
+		17322.00000000, 18816.00000000, 16057.00000000, 12582.00000000, 11001.00000000,
+		12696.00000000, 11723.00000000, 13129.00000000, 10096.00000000, 10096.00000000,
+		12304.00000000, 14891.00000000, 14891.00000000, 14891.00000000, 14891.00000000,
+		13647.00000000, 15572.00000000, 18748.00000000, 19661.00000000, 17440.00000000,
+		18304.00000000, 18304.00000000, 22267.00000000, 25600.00000000, 25600.00000000,
+		25496.00000000, 28260.00000000, 28260.00000000, 30017.00000000, 31332.00000000,
+		34733.00000000, 34733.00000000, 36323.00000000, 39591.00000000, 42353.00000000,
+	}},
+	{Name: adPluginID, New: adNew, Field: "ad", Want: []float64{
+		-1241.88709677, -1656.04838710, -2440.63993639, -1474.40464227, -656.64602159,
+		-940.71358915, 472.28641085, -974.74584722, 774.69591985, 5477.69591985,
+		8743.69591985, 9649.97323078, 10299.32617195, 10314.69103682, 12835.62061428,
+		10923.59264225, 12463.55614590, 11524.57063866, 11524.57063866, 11524.57063866,
+		9458.57063866, 9313.46719038, 9557.43357694, 10589.43357694, 11588.63870514,
+		11588.63870514, 12664.92441943, 10322.92441943, 13404.39877840, 10682.83274066,
+		10682.83274066, 10682.83274066, 10533.64425899, 9711.28300244, 9547.14014530,
+		9389.14014530, 9214.14014530, 8500.14014530, 8500.14014530, 8500.14014530,
+		8029.60168376, 7217.06322223, 7379.50224662, 7506.78131638, 6669.15584822,
+		8789.17178447, 7581.83845114, 8710.16277546, 8300.07186637, 10004.07186637,
+		12379.07186637, 12572.41070935, 13021.39418042, 10606.95088145, 10589.50758248,
+		10372.20650722, 8832.42024767, 9410.60206586, 9025.15421309, 10503.15421309,
+		9555.25098729, 8482.75918401, 8444.84321762, 5655.64994031, 9985.64994031,
+		10073.12939237, 10227.21907847, 8144.44456866, 7533.45555767, 6449.62536899,
+		6657.78326373, 6140.87701373, 6345.16761202, 5468.32874946, 4206.82874946,
+		4845.16050302, 4251.42279810, 402.42279810, 402.42279810, 402.42279810,
+		303.20807417, 769.87474084, 1870.05774737, 2022.22441404, 1410.64470390,
+		1379.30791633, 1313.55143965, -2062.33744924, -981.36447627, -320.71582762,
+		-339.11911980, 569.84732315, 569.84732315, 1448.34732315, 2354.96222998,
+		4457.87062693, 5869.49658113, 6058.61552386, 4520.73317092, 5261.75756116,
+	}},
+	{Name: adoscPluginID, New: adoscNew, Field: "adosc", Want: []float64{
+		2308.51253327, 3788.28684047, 4337.62030684, 4374.63081466, 3996.96658716,
+		4281.22094264, 3399.93237490, 3220.30885973, 2555.30304578, 2050.95493592,
+		1658.18026043, 689.39241497, 184.22879783, 38.44855037, 303.67949002,
+		702.50473430, 801.79644930, 1111.97974640, 392.60202737, 1043.08916313,
+		348.41841910, 32.56053117, -99.61410763, -192.09878516, -474.13029986,
+		-598.63134679, -645.41528901, -661.56187546, -835.20712670, -830.31589977,
+		-752.83167969, -802.41112926, -1008.28213010, -949.15492904, -798.18183104,
+		-930.37531890, -225.32437341, -300.56192237, 54.99517646, 64.96732069,
+		605.32253236, 1527.02943419, 1826.78739550, 1926.20244815, 1023.53097019,
+		555.65786406, 244.59962723, -394.81979107, -436.54081177, -536.56547231,
+		-58.43324506, -159.12715620, -527.10140670, -641.78218877, -1517.82380706,
+		-360.49321390, 173.56669203, 425.29549077, -173.08875294, -596.55256042,
+		-1060.41076347, -1087.53790153, -1164.23845560, -1024.77454139, -1153.55398354,
+		-1502.75430228, -1305.88939270, -1295.55307282, -2398.22884444, -2631.30267424,
+		-2487.44172514, -2234.02677401, -1778.77882970, -1080.77563862, -648.55988118,
+		-607.37789898, -545.28534785, -491.23496972, -1498.61219151, -1430.53763707,
+		-1062.43369141, -821.11642557, -358.53218264, -136.69933938, 246.00040007,
+		668.66409111, 1449.89153728, 2086.82997457, 2217.85809210, 1580.51083768,
+		1411.87555435,
+	}},
+}
+
+func TestTALib(t *testing.T) {
+	indicatortest.TALibCases(t, talibOutputs)
+}
@@ -0,0 +1,22 @@
+package volume
+
+import "encoding/gob"
+
+func init() {
+	gob.Register(&obvKernel{})
+	gob.Register(&adKernel{})
+	gob.Register(&adoscKernel{})
+	gob.Register(&cmfKernel{})
+	gob.Register(&forceKernel{})
+	gob.Register(&eomKernel{})
+	gob.Register(&vwmaKernel{})
+}
+
+// clv is the close location value of a bar, from -1 at the low to 1 at the
+// high, zero for bars without range.
+func clv(high, low, last float64) float64 {
+	if diff := high - low; diff > 0 {
+		return ((last - low) - (high - last)) / diff
+	}
+	return 0
+}
@@ -0,0 +1,159 @@
+package volume
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins/indicators/indicatortest"
+	"github.com/rangertaha/gotal/internal/series"
+)
+
+func refCLV(b indicatortest.Bars, i int) float64 {
+	if b.High[i] == b.Low[i] {
+		return 0
+	}
+	return ((b.Close[i] - b.Low[i]) - (b.High[i] - b.Close[i])) / (b.High[i] - b.Low[i])
+}
+
+func refTP(b indicatortest.Bars, i int) float64 {
+	return (b.High[i] + b.Low[i] + b.Close[i]) / 3
+}
+
+// refVWAP returns the VWAP and the deviation of the bars from to to.
+func refVWAP(b indicatortest.Bars, from, to int) (vwap, std float64) {
+	volume := 0.0
+	for i := from; i <= to; i++ {
+		vwap += refTP(b, i) * b.Volume[i]
+		volume += b.Volume[i]
+	}
+	if volume == 0 {
+		return refTP(b, to), 0
+	}
+	vwap /= volume
+	for i := from; i <= to; i++ {
+		std += b.Volume[i] * (refTP(b, i) - vwap) * (refTP(b, i) - vwap)
+	}
+	return vwap, math.Sqrt(std / volume)
+}
+
+func TestVolume(t *testing.T) {
+	b := indicatortest.RandomBars(120, 13)
+	input := b.Series(time.Minute)
+
+	obv, ad := []float64{b.Volume[0]}, []float64{refCLV(b, 0) * b.Volume[0]}
+	for i := 1; i < len(b.Close); i++ {
+		v := obv[i-1]
+		if b.Close[i] > b.Close[i-1] {
+			v += b.Volume[i]
+		} else if b.Close[i] < b.Close[i-1] {
+			v -= b.Volume[i]
+		}
+		obv = append(obv, v)
+		ad = append(ad, ad[i-1]+refCLV(b, i)*b.Volume[i])
+	}
+	indicatortest.Near(t, "OBV", indicatortest.Fields(obvNew().Compute(input), "obv"), obv)
+	indicatortest.Near(t, "AD", indicatortest.Fields(adNew().Compute(input), "ad"), ad)
+
+	// the EMAs of ADOSC start at the first A/D value
+	fast, slow := ad[0], ad[0]
+	var adosc []float64
+	for i := 1; i < len(ad); i++ {
+		fast = 0.5*ad[i] + 0.5*fast
+		slow = 2.0/11*ad[i] + (1-2.0/11)*slow
+		if i >= 9 {
+			adosc = append(adosc, fast-slow)
+		}
+	}
+	indicatortest.Near(t, "ADOSC", indicatortest.Fields(adoscNew().Compute(input), "adosc"), adosc)
+
+	var cmf, vwma []float64
+	for i := 19; i < len(b.Close); i++ {
+		flows, values, volumes := 0.0, 0.0, 0.0
+		for j := i - 19; j <= i; j++ {
+			flows += refCLV(b, j) * b.Volume[j]
+			values += b.Close[j] * b.Volume[j]
+			volumes += b.Volume[j]
+		}
+		cmf, vwma = append(cmf, flows/volumes), append(vwma, values/volumes)
+	}
+	indicatortest.Near(t, "CMF", indicatortest.Fields(cmfNew().Compute(input), "cmf"), cmf)
+	indicatortest.Near(t, "VWMA", indicatortest.Fields(vwmaNew().Compute(input), "vwma"), vwma)
+
+	forces, emvs := make([]float64, len(b.Close)-1), make([]float64, len(b.Close)-1)
+	for i := 1; i < len(b.Close); i++ {
+		forces[i-1] = (b.Close[i] - b.Close[i-1]) * b.Volume[i]
+		if b.Volume[i] != 0 {
+			distance := (b.High[i]+b.Low[i])/2 - (b.High[i-1]+b.Low[i-1])/2
+			emvs[i-1] = distance / ((b.Volume[i] / 1e4) / (b.High[i] - b.Low[i]))
+		}
+	}
+	indicatortest.Near(t, "FORCE", indicatortest.Fields(forceNew().Compute(input), "force"), indicatortest.EMA(forces, 13))
+	indicatortest.Near(t, "EOM", indicatortest.Fields(eomNew(opt.With("scale", 1e4)).Compute(input), "eom"), indicatortest.SMA(emvs, 14))
+	if err := eomNew(opt.WithPeriod(0)).Init(); err == nil {
+		t.Error("EOM: Init() with a period of 0 succeeded")
+	}
+}
+
+func TestVWAP(t *testing.T) {
+	// three days of hourly bars
+	b := indicatortest.RandomBars(72, 13)
+	input := b.Series(time.Hour)
+
+	check := func(name string, output *series.Series, start func(i int) int) {
+		t.Helper()
+		var want, upper, lower []float64
+		for i := range b.Close {
+			vwap, std := refVWAP(b, start(i), i)
+			want, upper, lower = append(want, vwap), append(upper, vwap+2*std), append(lower, vwap-2*std)
+		}
+		indicatortest.Near(t, name, indicatortest.Fields(output, "vwap"), want)
+		indicatortest.Near(t, name+" upper", indicatortest.Fields(output, "upper"), upper)
+		indicatortest.Near(t, name+" lower", indicatortest.Fields(output, "lower"), lower)
+	}
+
+	// midnight sessions, by time and by tag
+	daily := func(i int) int { return i - i%24 }
+	check("VWAP", vwapNew().Compute(input), daily)
+	check("VWAP tag", vwapNew(opt.With("session", "day"), opt.With("reset", 5*time.Hour)).Compute(input), daily)
+
+	// sessions starting at 09:30, the first bars belong to the session of the day before
+	check("VWAP 09:30", vwapNew(opt.With("reset", 9*time.Hour+30*time.Minute)).Compute(input), func(i int) int {
+		if i < 10 {
+			return 0
+		}
+		return i - (i-10)%24
+	})
+
+	// rolling
+	rolling := rvwapNew(opt.WithPeriod(5)).Compute(input)
+	if rolling.Len() != len(b.Close)-4 {
+		t.Fatalf("RVWAP: got %d outputs, want %d", rolling.Len(), len(b.Close)-4)
+	}
+	for i := range rolling.Len() {
+		vwap, std := refVWAP(b, i, i+4)
+		if out := rolling.At(i); math.Abs(out.GetField("vwap")-vwap) > 1e-9 || math.Abs(out.GetField("upper")-vwap-2*std) > 1e-9 {
+			t.Errorf("RVWAP[%d] = %v, want %v ± %v", i, out.Fields(), vwap, 2*std)
+		}
+	}
+
+	if err := vwapNew(opt.With("timezone", "Nowhere/Nothing")).Init(); err == nil {
+		t.Error("Init() with an unknown time zone succeeded")
+	}
+}
+
+func TestVolumeSnapshot(t *testing.T) {
+	input := indicatortest.RandomBars(80, 13).Series(time.Hour)
+	for id, new := range map[string]func(...internal.PluginOptions) internal.Plugin{
+		obvPluginID: obvNew, adPluginID: adNew, adoscPluginID: adoscNew, vwapPluginID: vwapNew, rvwapPluginID: rvwapNew,
+		cmfPluginID: cmfNew, forcePluginID: forceNew, eomPluginID: eomNew, vwmaPluginID: vwmaNew,
+	} {
+		t.Run(id, func(t *testing.T) {
+			indicatortest.SnapshotReplay(t, func() internal.Indicator {
+				return new(opt.WithPeriod(6)).(internal.Indicator)
+			}, input, 30)
+		})
+	}
+}
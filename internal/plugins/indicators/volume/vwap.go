@@ -0,0 +1,184 @@
+package volume
+
+import (
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+	"github.com/rangertaha/gotal/internal/series"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// TP = (High + Low + Close) / 3
+// VWAP = Sum(TP * Volume) / Sum(Volume)
+// Upper = VWAP + deviation * StdDev, Lower = VWAP - deviation * StdDev
+// where StdDev is the volume weighted deviation of TP from the VWAP, all
+// summed over the session or the last n bars
+const vwapPluginID = "VWAP"
+const vwapPluginName = "Volume Weighted Average Price"
+const vwapPluginDescription = "Volume Weighted Average Price is the average typical price of the session weighted by the volume, with standard deviation bands."
+const vwapPluginHCL = `
+indicator "vwap" {
+  deviation = 2
+  reset = "0h"
+  timezone = "UTC"
+}
+`
+
+const rvwapPluginID = "RVWAP"
+const rvwapPluginName = "Rolling Volume Weighted Average Price"
+const rvwapPluginDescription = "Rolling Volume Weighted Average Price is the average typical price of the period weighted by the volume, with standard deviation bands."
+const rvwapPluginHCL = `
+indicator "rvwap" {
+  period = 20
+  deviation = 2
+}
+`
+
+// vwap is the VWAP anchored to sessions, or rolling over the last Period bars.
+// A session starts when the value of the session tag changes or, without a
+// tag, at the reset time of day. Without volume the VWAP is the typical price.
+type vwap struct {
+	plugins.Plugin
+
+	Period    int     `hcl:"period,optional"`    // bars of the rolling VWAP, 0 anchors it to sessions
+	Deviation float64 `hcl:"deviation,optional"` // width of the bands in standard deviations
+
+	sessions plugins.Sessions
+
+	state vwapState
+}
+
+// vwapState is the snapshot of a vwap.
+type vwapState struct {
+	Session              string       // the current session
+	Volume, Mean, Square float64      // session volume, VWAP and sum of V * (TP - VWAP)^2
+	Prices, Volumes      plugins.Ring // the last bars of the rolling VWAP
+}
+
+func newVWAP(id, name, description, hcl string, params internal.Options, period int) *vwap {
+	i := &vwap{
+		Plugin: plugins.Plugin{
+			PID:         id,
+			Title:       name,
+			Summary:     description,
+			Template:    hcl,
+			Params:      params,
+			Fields:      plugins.Bar(params, "high", "low", "close", "volume"),
+			Initialized: true,
+		},
+		Period:    period,
+		Deviation: params.Float("deviation", 2.0),
+		sessions:  plugins.NewSessions(params),
+	}
+	i.Reset()
+	return i
+}
+
+func vwapNew(opts ...internal.PluginOptions) internal.Plugin {
+	return newVWAP(vwapPluginID, vwapPluginName, vwapPluginDescription, vwapPluginHCL, opt.New(opts...), 0)
+}
+
+func rvwapNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	return newVWAP(rvwapPluginID, rvwapPluginName, rvwapPluginDescription, rvwapPluginHCL, params, max(params.Period(20), 1))
+}
+
+func (i *vwap) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+func (i *vwap) Compute(input *series.Series) (output *series.Series) {
+	return plugins.Compute(i.ID(), i, input)
+}
+
+func (i *vwap) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the bar and returns the VWAP and its bands, from the first bar
+// of a session or once Period bars were added.
+func (i *vwap) Update(input *tick.Tick) (output *tick.Tick) {
+	values := make([]float64, len(i.Fields))
+	for j, field := range i.Fields {
+		if values[j] = input.GetField(field); math.IsNaN(values[j]) {
+			return tick.New()
+		}
+	}
+	price, volume := (values[0]+values[1]+values[2])/3, values[3]
+
+	var vwap, std float64
+	if s := &i.state; i.Period > 0 {
+		s.Prices.Push(price)
+		s.Volumes.Push(volume)
+		if !s.Prices.Full() {
+			return tick.New()
+		}
+		vwap, std = i.rolling(price)
+	} else {
+		if session := i.sessions.Key(input); session != s.Session {
+			*s = vwapState{Session: session}
+		}
+		// weighted Welford update, stable where sums of squares cancel out
+		vwap = price
+		if s.Volume += volume; s.Volume != 0 {
+			d := price - s.Mean
+			s.Mean += volume / s.Volume * d
+			s.Square += volume * d * (price - s.Mean)
+			vwap, std = s.Mean, math.Sqrt(max(s.Square/s.Volume, 0))
+		}
+	}
+
+	return plugins.Output(input, map[string]float64{
+		"vwap":  vwap,
+		"upper": vwap + i.Deviation*std,
+		"lower": vwap - i.Deviation*std,
+	})
+}
+
+// rolling returns the VWAP and the deviation of the last Period bars.
+func (i *vwap) rolling(price float64) (vwap, std float64) {
+	prices, volumes := &i.state.Prices, &i.state.Volumes
+	total := volumes.Sum()
+	if total == 0 {
+		return price, 0
+	}
+
+	for k := prices.Len - 1; k >= 0; k-- {
+		vwap += prices.At(k) * volumes.At(k)
+	}
+	vwap /= total
+	for k := prices.Len - 1; k >= 0; k-- {
+		d := prices.At(k) - vwap
+		std += volumes.At(k) * d * d
+	}
+	return vwap, math.Sqrt(std / total)
+}
+
+func (i *vwap) Reset() {
+	i.state = vwapState{Prices: plugins.NewRing(i.Period), Volumes: plugins.NewRing(i.Period)}
+}
+
+func (i *vwap) Warmup() int {
+	return max(i.Period, 1)
+}
+
+func (i *vwap) Snapshot() ([]byte, error) {
+	return plugins.Snapshot(i.state)
+}
+
+func (i *vwap) Restore(state []byte) error {
+	var s vwapState
+	if err := plugins.Restore(state, &s); err != nil {
+		return err
+	}
+	i.state = s
+	return nil
+}
+
+func init() {
+	indicators.Add(vwapPluginID, vwapNew, indicators.VOLUME)
+	indicators.Add(rvwapPluginID, rvwapNew, indicators.VOLUME)
+}
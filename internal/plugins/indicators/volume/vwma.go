@@ -0,0 +1,58 @@
+package volume
+
+import (
+	"strings"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/opt"
+	"github.com/rangertaha/gotal/internal/plugins"
+	"github.com/rangertaha/gotal/internal/plugins/indicators"
+)
+
+// VWMA = Sum(Close * Volume, n) / Sum(Volume, n)
+const vwmaPluginID = "VWMA"
+const vwmaPluginName = "Volume Weighted Moving Average"
+const vwmaPluginDescription = "Volume Weighted Moving Average is the average close of the period weighted by the volume."
+const vwmaPluginHCL = `
+indicator "vwma" {
+  period = 20
+}
+`
+
+// vwmaKernel falls back to the simple average when the period has no volume.
+type vwmaKernel struct {
+	Prices, Volumes, Values plugins.Ring
+	Output                  string
+}
+
+func (k *vwmaKernel) Add(values []float64) map[string]float64 {
+	price, volume := values[0], values[1]
+	k.Prices.Push(price)
+	k.Volumes.Push(volume)
+	k.Values.Push(price * volume)
+	if !k.Prices.Full() {
+		return nil
+	}
+
+	vwma := k.Prices.Sum() / float64(k.Prices.Len)
+	if volumes := k.Volumes.Sum(); volumes != 0 {
+		vwma = k.Values.Sum() / volumes
+	}
+	return map[string]float64{k.Output: vwma}
+}
+
+func (k *vwmaKernel) Warmup() int {
+	return len(k.Prices.Values)
+}
+
+func vwmaNew(opts ...internal.PluginOptions) internal.Plugin {
+	params := opt.New(opts...)
+	period, output := params.Period(20), params.Output(strings.ToLower(vwmaPluginID))
+	return plugins.NewKernelIndicator(vwmaPluginID, vwmaPluginName, vwmaPluginDescription, vwmaPluginHCL, params, plugins.Bar(params, "close", "volume"), func() plugins.Kernel {
+		return &vwmaKernel{Prices: plugins.NewRing(period), Volumes: plugins.NewRing(period), Values: plugins.NewRing(period), Output: output}
+	})
+}
+
+func init() {
+	indicators.Add(vwmaPluginID, vwmaNew, indicators.VOLUME)
+}
@@ -0,0 +1,130 @@
+package plugins
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/series"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// Kernel is the incremental state of an indicator over a fixed set of input
+// fields. Kernels are snapshotted through this interface, so each package
+// registers its kernels with gob. They follow the TA-Lib definitions where
+// TA-Lib has the indicator, including their warm-up and the value returned
+// when a ratio is undefined.
+type Kernel interface {
+	// Add adds the values of the input fields and returns the output fields, nil until Warmup values were added
+	Add(values []float64) map[string]float64
+	Warmup() int
+}
+
+// KernelIndicator is the plugin of the indicators driven by a Kernel, it
+// feeds the input fields of each tick to the kernel.
+type KernelIndicator struct {
+	Plugin
+
+	new    func() Kernel
+	kernel Kernel
+}
+
+// kernelState is the snapshot of a KernelIndicator.
+type kernelState struct {
+	Kernel Kernel
+}
+
+// NewKernelIndicator returns the plugin of an indicator over the input
+// fields, with the kernels new creates.
+func NewKernelIndicator(id, name, description, hcl string, params internal.Options, fields []string, new func() Kernel) *KernelIndicator {
+	i := &KernelIndicator{
+		Plugin: Plugin{
+			PID:         id,
+			Title:       name,
+			Summary:     description,
+			Template:    hcl,
+			Params:      params,
+			Fields:      fields,
+			Initialized: true,
+		},
+		new: new,
+	}
+	i.kernel = new()
+	return i
+}
+
+// Bar returns the input fields of indicators over bars, each named as by the
+// OHLCV indicators unless set.
+func Bar(params internal.Options, fields ...string) []string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = params.String(field, field)
+	}
+	return names
+}
+
+// Period returns the period parameter of the key, the fallback after
+// recording the error when it is below 1.
+func Period(params internal.Options, key string, fallback int) int {
+	period := params.GetInt(key, fallback)
+	if period < 1 {
+		params.AddError(fmt.Errorf("invalid %s: %d", key, period))
+		return fallback
+	}
+	return period
+}
+
+// IsZero reports whether x is zero up to the tolerance TA-Lib uses before dividing.
+func IsZero(x float64) bool {
+	return -1e-8 < x && x < 1e-8
+}
+
+func (i *KernelIndicator) Init(opts ...internal.PluginOptions) error {
+	return i.Params.Errors()
+}
+
+func (i *KernelIndicator) Compute(input *series.Series) (output *series.Series) {
+	return Compute(i.ID(), i, input)
+}
+
+func (i *KernelIndicator) Process(input *tick.Tick) (output *tick.Tick) {
+	return i.Update(input)
+}
+
+// Update adds the input fields and returns the outputs once warmed up. Ticks
+// missing an input field are skipped.
+func (i *KernelIndicator) Update(input *tick.Tick) (output *tick.Tick) {
+	values := make([]float64, len(i.Fields))
+	for j, field := range i.Fields {
+		if values[j] = input.GetField(field); math.IsNaN(values[j]) {
+			return tick.New()
+		}
+	}
+
+	fields := i.kernel.Add(values)
+	if fields == nil {
+		return tick.New()
+	}
+	return Output(input, fields)
+}
+
+func (i *KernelIndicator) Reset() {
+	i.kernel = i.new()
+}
+
+func (i *KernelIndicator) Warmup() int {
+	return i.kernel.Warmup()
+}
+
+func (i *KernelIndicator) Snapshot() ([]byte, error) {
+	return Snapshot(kernelState{Kernel: i.kernel})
+}
+
+func (i *KernelIndicator) Restore(state []byte) error {
+	var s kernelState
+	if err := Restore(state, &s); err != nil {
+		return err
+	}
+	i.kernel = s.Kernel
+	return nil
+}
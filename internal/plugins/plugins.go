@@ -10,7 +10,7 @@ type Plugin struct {
 	PID      string   `hcl:"id"`
 	Title    string   `hcl:"name"`
 	Summary  string   `hcl:"description"`
-	Fields   []string `hcl:"inputs,optional,default=[value]"` // input field names to compute
+	Fields   []string `hcl:"fields,optional,default=[value]"` // input field names to compute
 	Template string   `hcl:"-"`                               // template to compute the plugin
 
 	// input data
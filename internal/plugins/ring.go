@@ -0,0 +1,65 @@
+package plugins
+
+import "math"
+
+// Ring holds the last values added, for the windows of the indicator
+// kernels. Its fields are exported so snapshots encode it.
+type Ring struct {
+	Values []float64
+	Pos    int
+	Len    int
+}
+
+// NewRing returns a ring holding the last size values.
+func NewRing(size int) Ring {
+	return Ring{Values: make([]float64, max(size, 1))}
+}
+
+// Push adds a value, dropping the oldest once the ring is full.
+func (r *Ring) Push(value float64) {
+	r.Values[r.Pos] = value
+	r.Pos = (r.Pos + 1) % len(r.Values)
+	r.Len = min(r.Len+1, len(r.Values))
+}
+
+// At returns the value added k values ago, 0 is the newest.
+func (r *Ring) At(k int) float64 {
+	return r.Values[(r.Pos-1-k+2*len(r.Values))%len(r.Values)]
+}
+
+// Full reports whether the ring holds as many values as it can.
+func (r *Ring) Full() bool {
+	return r.Len == len(r.Values)
+}
+
+// Sum returns the sum of the values, oldest first.
+func (r *Ring) Sum() (sum float64) {
+	for k := r.Len - 1; k >= 0; k-- {
+		sum += r.At(k)
+	}
+	return
+}
+
+// Highest returns the largest of the values and how many values ago it was
+// added, the newest on ties.
+func (r *Ring) Highest() (value float64, ago int) {
+	value = math.Inf(-1)
+	for k := r.Len - 1; k >= 0; k-- {
+		if v := r.At(k); v >= value {
+			value, ago = v, k
+		}
+	}
+	return
+}
+
+// Lowest returns the smallest of the values and how many values ago it was
+// added, the newest on ties.
+func (r *Ring) Lowest() (value float64, ago int) {
+	value = math.Inf(1)
+	for k := r.Len - 1; k >= 0; k-- {
+		if v := r.At(k); v <= value {
+			value, ago = v, k
+		}
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package plugins
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/rangertaha/gotal/internal"
+	"github.com/rangertaha/gotal/internal/tick"
+)
+
+// Sessions splits the ticks into the sessions of the indicators anchored to
+// them, such as the VWAP and the pivot points. A session starts when the
+// value of the session tag changes or, without a tag, at the reset time of day.
+type Sessions struct {
+	Tag      string         // tag whose changes start sessions
+	Start    time.Duration  // time of day sessions start at without a tag
+	Location *time.Location // time zone of the reset time
+}
+
+// NewSessions returns the sessions set by the session, reset and timezone
+// parameters, in UTC after recording the error of an unknown time zone.
+func NewSessions(params internal.Options) Sessions {
+	s := Sessions{
+		Tag:      params.String("session", ""),
+		Start:    params.Duration("reset", time.Duration(0)),
+		Location: time.UTC,
+	}
+	location, err := time.LoadLocation(params.String("timezone", "UTC"))
+	if err != nil {
+		params.AddError(err)
+	} else {
+		s.Location = location
+	}
+	return s
+}
+
+// Key returns the session of the tick, the value of the session tag or the
+// start of the day at the reset time.
+func (s Sessions) Key(input *tick.Tick) string {
+	if s.Tag != "" {
+		return input.GetTag(s.Tag)
+	}
+	t := input.Time().In(s.Location)
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.Location).Add(s.Start)
+	if t.Before(start) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return strconv.FormatInt(start.UnixNano(), 10)
+}
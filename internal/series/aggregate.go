@@ -0,0 +1,247 @@
+package series
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+type AggType string
+
+const (
+	FIRST AggType = "first"
+	LAST  AggType = "last"
+	MIN   AggType = "min"
+	MAX   AggType = "max"
+	SUM   AggType = "sum"
+	MEAN  AggType = "mean"
+	VWAP  AggType = "vwap"
+	COUNT AggType = "count"
+)
+
+// Aggregation reduces the Input field of the ticks in a bucket into the Output field.
+type Aggregation struct {
+	Output string  // output field name
+	Input  string  // input field name
+	Type   AggType // aggregation function
+	Weight string  // weight field name, the volume for VWAP
+}
+
+// Agg returns an aggregation of the input field into the output field.
+func Agg(output string, typ AggType, input string) Aggregation {
+	return Aggregation{Output: output, Input: input, Type: typ}
+}
+
+// VWAPAgg returns a volume weighted average of the price field.
+func VWAPAgg(output, price, volume string) Aggregation {
+	return Aggregation{Output: output, Input: price, Type: VWAP, Weight: volume}
+}
+
+// OHLC returns the open, high, low and close aggregations of the price field.
+func OHLC(price string) []Aggregation {
+	return []Aggregation{
+		Agg("open", FIRST, price),
+		Agg("high", MAX, price),
+		Agg("low", MIN, price),
+		Agg("close", LAST, price),
+	}
+}
+
+// OHLCV returns the OHLC aggregations of the price field with the summed volume.
+func OHLCV(price, volume string) []Aggregation {
+	return append(OHLC(price), Agg("volume", SUM, volume))
+}
+
+// accumulator holds the running state of one aggregation.
+type accumulator struct {
+	Aggregation
+
+	First, Last float64
+	Min, Max    float64
+	Sum, WSum   float64
+	Count       int
+
+	Prev float64 // last value of the previous non empty bucket
+}
+
+func (a *accumulator) reset() {
+	if a.Count > 0 {
+		a.Prev = a.Last
+	}
+	a.First, a.Last = math.NaN(), math.NaN()
+	a.Min, a.Max = math.Inf(1), math.Inf(-1)
+	a.Sum, a.WSum, a.Count = 0, 0, 0
+}
+
+func (a *accumulator) add(t *tick.Tick) {
+	value := t.GetField(a.Input)
+	if a.Type == COUNT {
+		a.Count++
+		return
+	}
+	if math.IsNaN(value) {
+		return
+	}
+
+	if a.Count == 0 {
+		a.First = value
+	}
+	a.Last = value
+	a.Min = math.Min(a.Min, value)
+	a.Max = math.Max(a.Max, value)
+	a.Count++
+
+	if a.Type == VWAP {
+		weight := t.GetField(a.Weight)
+		if math.IsNaN(weight) {
+			return
+		}
+		a.Sum += value * weight
+		a.WSum += weight
+		return
+	}
+	a.Sum += value
+}
+
+func (a *accumulator) value() float64 {
+	switch a.Type {
+	case COUNT:
+		return float64(a.Count)
+	case SUM:
+		return a.Sum
+	}
+	if a.Count == 0 {
+		return math.NaN()
+	}
+
+	switch a.Type {
+	case FIRST:
+		return a.First
+	case LAST:
+		return a.Last
+	case MIN:
+		return a.Min
+	case MAX:
+		return a.Max
+	case MEAN:
+		return a.Sum / float64(a.Count)
+	case VWAP:
+		if a.WSum == 0 {
+			return math.NaN()
+		}
+		return a.Sum / a.WSum
+	}
+	return math.NaN()
+}
+
+// filled returns the value of an empty bucket carried forward from the
+// previous one. Sums and counts of an empty bucket are zero.
+func (a *accumulator) filled() float64 {
+	switch a.Type {
+	case SUM, COUNT:
+		return 0
+	}
+	return a.Prev
+}
+
+// Bucket aggregates the ticks of one time window. It is shared by the batch
+// resampler and the stream windows so both produce identical bars.
+type Bucket struct {
+	accs []*accumulator
+	tags map[string]string
+	len  int
+}
+
+// NewBucket creates an empty bucket for the given aggregations.
+func NewBucket(aggs ...Aggregation) *Bucket {
+	b := &Bucket{accs: make([]*accumulator, len(aggs))}
+	for i, agg := range aggs {
+		b.accs[i] = &accumulator{Aggregation: agg, Prev: math.NaN()}
+		b.accs[i].reset()
+	}
+	return b
+}
+
+// Add adds a tick to the bucket.
+func (b *Bucket) Add(t *tick.Tick) {
+	for _, acc := range b.accs {
+		acc.add(t)
+	}
+	b.tags = t.Tags()
+	b.len++
+}
+
+// Len returns the number of ticks in the bucket.
+func (b *Bucket) Len() int {
+	return b.len
+}
+
+// Reset empties the bucket, keeping the last values for forward filling.
+func (b *Bucket) Reset() {
+	for _, acc := range b.accs {
+		acc.reset()
+	}
+	b.len = 0
+}
+
+// Fields returns the aggregated values of the bucket.
+func (b *Bucket) Fields() map[string]float64 {
+	fields := make(map[string]float64, len(b.accs))
+	for _, acc := range b.accs {
+		fields[acc.Output] = acc.value()
+	}
+	return fields
+}
+
+// Filled returns the values of an empty bucket, forward filled from the last non empty one.
+func (b *Bucket) Filled() map[string]float64 {
+	fields := make(map[string]float64, len(b.accs))
+	for _, acc := range b.accs {
+		fields[acc.Output] = acc.filled()
+	}
+	return fields
+}
+
+// Tick returns the aggregated bar stamped at the given time.
+func (b *Bucket) Tick(timestamp time.Time, duration time.Duration) *tick.Tick {
+	return bar(timestamp, duration, b.Fields(), b.tags)
+}
+
+// bucketState is the encoding of a bucket, its aggregations with their
+// running values.
+type bucketState struct {
+	Accs []*accumulator
+	Tags map[string]string
+	Len  int
+}
+
+// GobEncode encodes the bucket, so the snapshots of the indicators
+// aggregating ticks keep the bar in progress.
+func (b *Bucket) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(bucketState{Accs: b.accs, Tags: b.tags, Len: b.len}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes a bucket encoded by GobEncode.
+func (b *Bucket) GobDecode(data []byte) error {
+	var s bucketState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s); err != nil {
+		return err
+	}
+	b.accs, b.tags, b.len = s.Accs, s.Tags, s.Len
+	return nil
+}
+
+// bar creates an aggregated tick.
+func bar(timestamp time.Time, duration time.Duration, fields map[string]float64, tags map[string]string) *tick.Tick {
+	t := tick.New(tick.WithFields(fields), tick.WithTags(cloneTags(tags)))
+	t.SetUnixNano(timestamp.UnixNano())
+	t.SetDuration(duration)
+	return t
+}
@@ -0,0 +1,362 @@
+package series
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/sig"
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+// column holds the values of a field by row. Rows without the field hold NaN,
+// a filler left out of the statistics, and are unset in the mask.
+type column struct {
+	values []float64
+	set    []bool
+}
+
+// newColumn returns a column of n rows without the field.
+func newColumn(n int) *column {
+	return &column{values: nanColumn(n), set: make([]bool, n)}
+}
+
+func (c *column) append(value float64, ok bool) {
+	if !ok {
+		value = math.NaN()
+	}
+	c.values = append(c.values, value)
+	c.set = append(c.set, ok)
+}
+
+// full returns true if every row has the field.
+func (c *column) full() bool {
+	for _, ok := range c.set {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// empty returns true if no row has the field.
+func (c *column) empty() bool {
+	for _, ok := range c.set {
+		if ok {
+			return false
+		}
+	}
+	return true
+}
+
+// columns is the storage of a series, one slice per column indexed by row.
+// Tags and signals are kept as the maps of each row, fields as one contiguous
+// slice each. It implements tick.Columns, so the ticks of a series are views
+// of its rows.
+type columns struct {
+	times     []int64 // nanoseconds since the Unix epoch
+	durations []time.Duration
+	ids       []string
+	fields    map[string]*column
+	tags      []map[string]string
+	signals   []map[sig.Signal]sig.Strength // nil for the rows without signals
+	views     []*tick.Tick                  // ticks bound to the rows, nil until handed out
+
+	// parent is the storage a slice views from the row offset on, until rows
+	// are added to the slice.
+	parent *columns
+	offset int
+}
+
+func newColumns() *columns {
+	return &columns{fields: make(map[string]*column)}
+}
+
+func (c *columns) len() int {
+	return len(c.times)
+}
+
+// add appends the tick as a row. A tick holding its own state becomes a view
+// of the row, a tick already viewing another row is copied.
+func (c *columns) add(t *tick.Tick) {
+	row := c.len()
+	c.parent = nil
+	fields := t.Fields()
+	c.times = append(c.times, t.UnixNano())
+	c.durations = append(c.durations, t.Duration())
+	c.ids = append(c.ids, t.ID())
+	for name, col := range c.fields {
+		value, ok := fields[name]
+		col.append(value, ok)
+	}
+	for name, value := range fields {
+		if _, ok := c.fields[name]; !ok {
+			col := newColumn(row)
+			col.append(value, true)
+			c.fields[name] = col
+		}
+	}
+	c.tags = append(c.tags, t.Tags())
+	c.signals = append(c.signals, cloneSignals(t.Signals()))
+
+	if bound, _ := t.Columns(); bound != nil {
+		if c.views != nil {
+			c.views = append(c.views, nil)
+		}
+		return
+	}
+	if c.views == nil {
+		c.views = make([]*tick.Tick, row, row+1)
+	}
+	c.views = append(c.views, t)
+	t.Bind(c, row)
+}
+
+// append adds a row of fields and tags without a tick.
+func (c *columns) append(nsec int64, duration time.Duration, fields map[string]float64, tags map[string]string) {
+	row := c.len()
+	c.parent = nil
+	c.times = append(c.times, nsec)
+	c.durations = append(c.durations, duration)
+	c.ids = append(c.ids, "")
+	for name, col := range c.fields {
+		value, ok := fields[name]
+		col.append(value, ok)
+	}
+	for name, value := range fields {
+		if _, ok := c.fields[name]; !ok {
+			col := newColumn(row)
+			col.append(value, true)
+			c.fields[name] = col
+		}
+	}
+	c.tags = append(c.tags, tags)
+	c.signals = append(c.signals, nil)
+	if c.views != nil {
+		c.views = append(c.views, nil)
+	}
+}
+
+// view returns the tick viewing the row.
+func (c *columns) view(row int) *tick.Tick {
+	if c.views == nil {
+		c.views = make([]*tick.Tick, c.len())
+	}
+	if c.views[row] == nil {
+		c.views[row] = tick.View(c, row)
+	}
+	return c.views[row]
+}
+
+// slice returns the rows between start and end sharing their memory. The
+// capacity is cut at end, so appending to either side does not overwrite
+// the other.
+func (c *columns) slice(start, end int) *columns {
+	s := &columns{
+		times:     c.times[start:end:end],
+		durations: c.durations[start:end:end],
+		ids:       c.ids[start:end:end],
+		fields:    make(map[string]*column, len(c.fields)),
+		tags:      c.tags[start:end:end],
+		signals:   c.signals[start:end:end],
+		parent:    c,
+		offset:    start,
+	}
+	for name, col := range c.fields {
+		s.fields[name] = &column{values: col.values[start:end:end], set: col.set[start:end:end]}
+	}
+	return s
+}
+
+// column returns the column of the field, adding it without values when it
+// is missing. A slice adds it to the storage it views and keeps the part of
+// its rows, so a new field is shared the same way as the existing ones.
+func (c *columns) column(name string) *column {
+	if col, ok := c.fields[name]; ok {
+		return col
+	}
+	col := newColumn(c.len())
+	if end := c.offset + c.len(); c.parent != nil && end <= c.parent.len() {
+		parent := c.parent.column(name)
+		col = &column{values: parent.values[c.offset:end:end], set: parent.set[c.offset:end:end]}
+	}
+	c.fields[name] = col
+	return col
+}
+
+// take keeps the rows in the given order. The ticks viewing the rows left out
+// keep a copy of them.
+func (c *columns) take(rows []int) {
+	kept := make([]bool, c.len())
+	for _, row := range rows {
+		kept[row] = true
+	}
+	for row, t := range c.views {
+		if t != nil && !kept[row] {
+			t.Unbind()
+		}
+	}
+
+	times, durations, ids := make([]int64, len(rows)), make([]time.Duration, len(rows)), make([]string, len(rows))
+	tags, signals := make([]map[string]string, len(rows)), make([]map[sig.Signal]sig.Strength, len(rows))
+	var views []*tick.Tick
+	if c.views != nil {
+		views = make([]*tick.Tick, len(rows))
+	}
+	for i, row := range rows {
+		times[i], durations[i], ids[i] = c.times[row], c.durations[row], c.ids[row]
+		tags[i], signals[i] = c.tags[row], c.signals[row]
+		if views != nil {
+			if views[i] = c.views[row]; views[i] != nil {
+				views[i].Bind(c, i)
+			}
+		}
+	}
+	for name, col := range c.fields {
+		taken := &column{values: make([]float64, len(rows)), set: make([]bool, len(rows))}
+		for i, row := range rows {
+			taken.values[i], taken.set[i] = col.values[row], col.set[row]
+		}
+		c.fields[name] = taken
+	}
+	c.times, c.durations, c.ids, c.tags, c.signals, c.views = times, durations, ids, tags, signals, views
+	c.parent = nil
+}
+
+// truncate keeps the first n rows.
+func (c *columns) truncate(n int) {
+	for _, t := range c.views[min(n, len(c.views)):] {
+		if t != nil {
+			t.Unbind()
+		}
+	}
+	c.times, c.durations, c.ids = c.times[:n:n], c.durations[:n:n], c.ids[:n:n]
+	c.tags, c.signals = c.tags[:n:n], c.signals[:n:n]
+	if c.views != nil {
+		c.views = c.views[:n:n]
+	}
+	for _, col := range c.fields {
+		col.values, col.set = col.values[:n:n], col.set[:n:n]
+	}
+}
+
+// sorted returns true if the rows from the given one on are in time order.
+func (c *columns) sorted(from int) bool {
+	for i := max(from, 1); i < c.len(); i++ {
+		if c.times[i] < c.times[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// sort orders the rows by time, keeping the order of equal timestamps.
+func (c *columns) sort() {
+	if c.sorted(0) {
+		return
+	}
+	rows := make([]int, c.len())
+	for i := range rows {
+		rows[i] = i
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return c.times[rows[i]] < c.times[rows[j]] })
+	c.take(rows)
+}
+
+// tick.Columns implementation
+
+func (c *columns) ID(row int) string {
+	return c.ids[row]
+}
+
+func (c *columns) SetID(row int, id string) {
+	c.ids[row] = id
+}
+
+func (c *columns) UnixNano(row int) int64 {
+	return c.times[row]
+}
+
+func (c *columns) SetUnixNano(row int, nsec int64) {
+	c.times[row] = nsec
+}
+
+func (c *columns) Duration(row int) time.Duration {
+	return c.durations[row]
+}
+
+func (c *columns) SetDuration(row int, duration time.Duration) {
+	c.durations[row] = duration
+}
+
+func (c *columns) Field(row int, key string) (float64, bool) {
+	col, ok := c.fields[key]
+	if !ok || !col.set[row] {
+		return math.NaN(), false
+	}
+	return col.values[row], true
+}
+
+func (c *columns) SetField(row int, key string, value float64) {
+	col := c.column(key)
+	col.values[row], col.set[row] = value, true
+}
+
+func (c *columns) RemoveField(row int, key string) {
+	if col, ok := c.fields[key]; ok {
+		col.values[row], col.set[row] = math.NaN(), false
+	}
+}
+
+func (c *columns) Fields(row int) map[string]float64 {
+	fields := make(map[string]float64, len(c.fields))
+	for name, col := range c.fields {
+		if col.set[row] {
+			fields[name] = col.values[row]
+		}
+	}
+	return fields
+}
+
+func (c *columns) Tags(row int) map[string]string {
+	if c.tags[row] == nil {
+		c.tags[row] = map[string]string{}
+	}
+	return c.tags[row]
+}
+
+func (c *columns) SetTags(row int, tags map[string]string) {
+	c.tags[row] = tags
+}
+
+func (c *columns) Signals(row int) map[sig.Signal]sig.Strength {
+	if c.signals[row] == nil {
+		c.signals[row] = map[sig.Signal]sig.Strength{}
+	}
+	return c.signals[row]
+}
+
+func (c *columns) SetSignals(row int, signals map[sig.Signal]sig.Strength) {
+	c.signals[row] = signals
+}
+
+// cloneSignals returns a copy of the signals, nil when there are none.
+func cloneSignals(signals map[sig.Signal]sig.Strength) map[sig.Signal]sig.Strength {
+	if len(signals) == 0 {
+		return nil
+	}
+	clone := make(map[sig.Signal]sig.Strength, len(signals))
+	for k, v := range signals {
+		clone[k] = v
+	}
+	return clone
+}
+
+// nanColumn returns a column of n NaN values.
+func nanColumn(n int) []float64 {
+	col := make([]float64, n)
+	for i := range col {
+		col[i] = math.NaN()
+	}
+	return col
+}
@@ -0,0 +1,169 @@
+package series
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/sig"
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+func TestColumns(t *testing.T) {
+	s := testSeries()
+
+	if got := s.Field("price"); got[0] != 100 || got[2] != 102 {
+		t.Errorf("price = %v", got)
+	}
+	if got := s.Field("missing"); !math.IsNaN(got[0]) || !math.IsNaN(got[1]) {
+		t.Errorf("missing = %v, want NaN", got)
+	}
+	if s.At(0).HasField("missing") || !s.At(1).HasField("missing") {
+		t.Errorf("missing is held by %v and %v, want only the explicit NaN", s.At(0).Fields(), s.At(1).Fields())
+	}
+
+	// the field is a copy of the column, and so are the values set
+	s.Field("price")[1] = 200
+	if got := s.At(1).GetField("price"); got == 200 {
+		t.Error("writing to the field wrote to the series")
+	}
+	s.FieldMap()["price"][1] = 200
+	if got := s.At(1).GetField("price"); got == 200 {
+		t.Error("writing to the field map wrote to the series")
+	}
+	values := []float64{1, 2, 3}
+	s.SetField("size", values)
+	values[0] = 10
+	if got := s.At(0).GetField("size"); got != 1 {
+		t.Errorf("size = %v, want the value set 1", got)
+	}
+
+	// the ticks are views of the rows
+	s.At(2).SetField("price", 300)
+	if got := s.Field("price")[2]; got != 300 {
+		t.Errorf("column = %v, want the tick value 300", got)
+	}
+	if s.At(1).GetSignal(sig.BULLISH) != sig.STRONG || s.At(1).GetTag("symbol") != "BTC" {
+		t.Errorf("tick 1 signals = %v, tags = %v", s.At(1).Signals(), s.At(1).Tags())
+	}
+}
+
+func TestColumnsAddedTicks(t *testing.T) {
+	base := time.Unix(0, 0)
+	signals := map[sig.Signal]sig.Strength{sig.BULLISH: sig.STRONG}
+	a := tick.New(tick.WithTime(base.Add(2*time.Second)), tick.WithFields(map[string]float64{"a": 2}), tick.WithSignals(signals))
+	b := tick.New(tick.WithTime(base), tick.WithFields(map[string]float64{"a": 0}))
+	c := tick.New(tick.WithTime(base.Add(time.Second)), tick.WithFields(map[string]float64{"b": 1}))
+
+	s := New("ooo").Add(a, b, c)
+	if got := s.Field("a"); got[0] != 0 || !math.IsNaN(got[1]) || got[2] != 2 {
+		t.Errorf("a = %v", got)
+	}
+	if got := s.Field("b"); !math.IsNaN(got[0]) || got[1] != 1 || !math.IsNaN(got[2]) {
+		t.Errorf("b = %v", got)
+	}
+
+	// the ticks added are views of their rows, wherever the rows are sorted to
+	if s.At(2) != a || s.At(0) != b {
+		t.Error("the ticks added are not the ticks of the series")
+	}
+	a.SetField("a", 20)
+	if got := s.Field("a")[2]; got != 20 {
+		t.Errorf("a = %v, want 20 set through the tick", got)
+	}
+
+	// the signals are copied, not shared with the map given
+	s.At(2).SetSignal(sig.BEARISH, sig.WEAK)
+	if _, ok := signals[sig.BEARISH]; ok {
+		t.Errorf("the signals given were modified: %v", signals)
+	}
+
+	// a tick removed from the series keeps its row
+	last := s.Pop()
+	if last != a || last.GetField("a") != 20 || last.GetSignal(sig.BULLISH) != sig.STRONG || s.Len() != 2 {
+		t.Errorf("popped %v %v, len %d", last.Fields(), last.Signals(), s.Len())
+	}
+	last.SetField("a", 30)
+	if got := s.Field("a"); len(got) != 2 || got[0] != 0 {
+		t.Errorf("a = %v after writing the popped tick", got)
+	}
+}
+
+func TestColumnsViews(t *testing.T) {
+	s := New("view")
+	base := time.Unix(0, 0)
+	for i := 0; i < 10; i++ {
+		s.Append(base.Add(time.Duration(i)*time.Second), map[string]float64{"value": float64(i)}, nil)
+	}
+
+	head, tail := s.Head(3), s.Tail(3)
+	if head.Len() != 3 || head.Field("value")[0] != 0 || tail.Field("value")[0] != 7 {
+		t.Errorf("head = %v, tail = %v", head.Field("value"), tail.Field("value"))
+	}
+	if s.Head(20).Len() != 10 || s.Tail(20).Len() != 10 {
+		t.Errorf("head and tail longer than the series")
+	}
+
+	// views share memory with the series
+	s.At(1).SetField("value", 100)
+	if head.Field("value")[1] != 100 {
+		t.Errorf("head is a copy, want a view")
+	}
+	tail.At(2).SetField("value", 90)
+	if s.Field("value")[9] != 90 {
+		t.Errorf("tail is a copy, want a view")
+	}
+
+	windows := s.Moving(0, -1, 4)
+	if len(windows) != 7 || windows[6].Field("value")[3] != 90 {
+		t.Errorf("moving windows = %d", len(windows))
+	}
+	if got := windows[0].Mean("value"); got != (0+100+2+3)/4.0 {
+		t.Errorf("mean = %v", got)
+	}
+
+	// fields new to the series are set on it too, by tick or by column
+	tail.At(0).SetField("size", 7)
+	head.SetField("weight", []float64{1, 2, 3})
+	if size := s.Field("size"); size[7] != 7 || s.At(6).HasField("size") {
+		t.Errorf("size = %v, want only tick 7 with 7", size)
+	}
+	if weight := s.Field("weight"); weight[2] != 3 || s.At(3).HasField("weight") || tail.At(0).HasField("weight") {
+		t.Errorf("weight = %v, want only the first 3 ticks", weight)
+	}
+	if tail.Field("size")[0] != 7 || s.Slice(6, 9).Field("size")[1] != 7 {
+		t.Error("the other views do not see the new field")
+	}
+
+	// appending to a view does not clobber the series
+	head.Append(base.Add(time.Hour), map[string]float64{"value": -1}, nil)
+	if s.Field("value")[3] != 3 {
+		t.Errorf("append to view modified the series: %v", s.Field("value"))
+	}
+}
+
+// TestColumnsFiller checks the statistics leave out the ticks without the
+// field, unlike the NaN values they hold.
+func TestColumnsFiller(t *testing.T) {
+	s := New("filler")
+	base := time.Unix(0, 0)
+	s.Append(base, map[string]float64{"a": 1, "b": 1}, nil)
+	s.Append(base.Add(time.Second), map[string]float64{"b": 2}, nil)
+	s.Append(base.Add(2*time.Second), map[string]float64{"a": 3, "b": math.NaN()}, nil)
+
+	if got := s.Mean("a"); got != 2 {
+		t.Errorf("mean = %v, want 2 without the filler", got)
+	}
+	if got := s.Sum("a"); got != 4 {
+		t.Errorf("sum = %v, want 4 without the filler", got)
+	}
+	if got := s.Mean("b"); !math.IsNaN(got) {
+		t.Errorf("mean = %v, want the NaN propagated", got)
+	}
+	if got := s.Copy(WithNaN(SkipNaN)).Mean("b"); got != 1.5 {
+		t.Errorf("mean = %v, want 1.5 skipping NaN", got)
+	}
+	if got := s.Median("a"); got != 1 || s.Field("a")[0] != 1 || s.Field("a")[2] != 3 {
+		t.Errorf("median = %v, a = %v", got, s.Field("a"))
+	}
+}
@@ -0,0 +1,368 @@
+package series
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/diag"
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+// maxRowDiagnostics caps the number of per-row diagnostics a CSVReader keeps,
+// the remaining skipped rows are only counted.
+const maxRowDiagnostics = 100
+
+// timeLayouts are tried in order when no time layout is configured and the
+// time column is not a numeric epoch.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// CSVConfig describes how the columns of a CSV file map onto ticks.
+type CSVConfig struct {
+	Comma      rune              // field delimiter
+	Comment    rune              // lines beginning with this rune are ignored
+	Columns    []string          // column names of a file without a header row
+	TimeColumn string            // column holding the timestamp, "time" or "timestamp" when empty
+	TimeLayout string            // time.Parse layout of the time column, epoch or RFC3339 when empty
+	TimeUnit   time.Duration     // unit of numeric epochs, detected from the magnitude when zero
+	Location   *time.Location    // location of timestamps without a zone
+	Fields     map[string]string // column to field mapping, every other numeric column when empty
+	Tags       map[string]string // column to tag mapping
+	Strict     bool              // stop at the first invalid row instead of skipping it
+}
+
+type CSVOptions func(*CSVConfig)
+
+// WithDelimiter sets the field delimiter, e.g. ';' or '\t'.
+func WithDelimiter(comma rune) CSVOptions {
+	return func(c *CSVConfig) { c.Comma = comma }
+}
+
+// WithComment ignores lines starting with the given rune.
+func WithComment(comment rune) CSVOptions {
+	return func(c *CSVConfig) { c.Comment = comment }
+}
+
+// WithColumns names the columns of a file that has no header row.
+func WithColumns(columns ...string) CSVOptions {
+	return func(c *CSVConfig) { c.Columns = columns }
+}
+
+// WithTimeColumn sets the column holding the timestamp.
+func WithTimeColumn(column string) CSVOptions {
+	return func(c *CSVConfig) { c.TimeColumn = column }
+}
+
+// WithTimeLayout parses the time column with a time.Parse layout.
+func WithTimeLayout(layout string) CSVOptions {
+	return func(c *CSVConfig) { c.TimeLayout = layout }
+}
+
+// WithTimeUnit sets the unit of numeric epochs, e.g. time.Millisecond.
+func WithTimeUnit(unit time.Duration) CSVOptions {
+	return func(c *CSVConfig) { c.TimeUnit = unit }
+}
+
+// WithLocation sets the time zone of timestamps that carry none.
+func WithLocation(loc *time.Location) CSVOptions {
+	return func(c *CSVConfig) { c.Location = loc }
+}
+
+// WithFieldMap maps columns to field names, unmapped columns are ignored.
+func WithFieldMap(fields map[string]string) CSVOptions {
+	return func(c *CSVConfig) {
+		if c.Fields == nil {
+			c.Fields = make(map[string]string)
+		}
+		for column, field := range fields {
+			c.Fields[column] = field
+		}
+	}
+}
+
+// WithTagMap maps columns to tag names.
+func WithTagMap(tags map[string]string) CSVOptions {
+	return func(c *CSVConfig) {
+		for column, tag := range tags {
+			c.Tags[column] = tag
+		}
+	}
+}
+
+// WithTagColumns reads the given columns as tags of the same name.
+func WithTagColumns(columns ...string) CSVOptions {
+	return func(c *CSVConfig) {
+		for _, column := range columns {
+			c.Tags[column] = column
+		}
+	}
+}
+
+// WithStrict fails on the first invalid row instead of skipping it.
+func WithStrict() CSVOptions {
+	return func(c *CSVConfig) { c.Strict = true }
+}
+
+// csvColumn is the role of a single CSV column.
+type csvColumn struct {
+	name  string
+	time  bool
+	field string
+	tag   string
+}
+
+// CSVReader reads ticks from a CSV stream one row at a time.
+type CSVReader struct {
+	config  CSVConfig
+	reader  *csv.Reader
+	columns []csvColumn
+	diags   diag.Diagnostics
+	skipped int
+	warned  map[string]bool
+}
+
+// NewCSVReader returns a reader of ticks from r. The tag columns listed in
+// Loaders[".csv"] are read as tags unless they are mapped otherwise.
+func NewCSVReader(r io.Reader, opts ...CSVOptions) *CSVReader {
+	config := CSVConfig{
+		Comma:    ',',
+		Location: time.UTC,
+		Tags:     make(map[string]string),
+	}
+	for _, tag := range Loaders[".csv"].Tags {
+		config.Tags[tag] = tag
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = config.Comma
+	reader.Comment = config.Comment
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	return &CSVReader{
+		config: config,
+		reader: reader,
+		warned: make(map[string]bool),
+	}
+}
+
+// Diagnostics returns the warnings and errors collected so far.
+func (r *CSVReader) Diagnostics() diag.Diagnostics {
+	return r.diags
+}
+
+// Read returns the next tick, or io.EOF when the input is exhausted. Invalid
+// rows are skipped and reported as warnings, in strict mode the first
+// invalid row is returned as an error.
+func (r *CSVReader) Read() (*tick.Tick, error) {
+	if r.columns == nil {
+		if err := r.header(); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		record, err := r.reader.Read()
+		if errors.Is(err, io.EOF) {
+			r.summarize()
+			return nil, io.EOF
+		}
+
+		var parseErr *csv.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			r.diags.AddError("Failed to read CSV", err.Error())
+			return nil, err
+		}
+
+		if err == nil {
+			var t *tick.Tick
+			if t, err = r.parse(record); err == nil {
+				return t, nil
+			}
+			line, _ := r.reader.FieldPos(0)
+			err = fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if r.config.Strict {
+			r.diags.AddError("Invalid CSV row", err.Error())
+			return nil, err
+		}
+		r.skip(err)
+	}
+}
+
+// header resolves the role of each column from the header row or the
+// configured column names.
+func (r *CSVReader) header() error {
+	names := r.config.Columns
+	if len(names) == 0 {
+		record, err := r.reader.Read()
+		if errors.Is(err, io.EOF) {
+			err = errors.New("empty CSV file")
+		}
+		if err != nil {
+			r.diags.AddError("Failed to read CSV header", err.Error())
+			return err
+		}
+		names = make([]string, len(record))
+		copy(names, record)
+	}
+
+	timeColumn := r.config.TimeColumn
+	r.columns = make([]csvColumn, len(names))
+	found := false
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		col := csvColumn{name: name}
+
+		switch {
+		case timeColumn == "" && (name == "time" || name == "timestamp") && !found,
+			timeColumn != "" && name == timeColumn:
+			col.time = true
+			found = true
+		case r.config.Fields[name] != "":
+			col.field = r.config.Fields[name]
+		case r.config.Tags[name] != "":
+			col.tag = r.config.Tags[name]
+		case r.config.Fields != nil:
+			// only mapped columns are read
+		default:
+			col.field = name
+		}
+		r.columns[i] = col
+	}
+
+	if timeColumn != "" && !found {
+		err := fmt.Errorf("time column %q not found", timeColumn)
+		r.diags.AddError("Failed to read CSV header", err.Error())
+		return err
+	}
+	return nil
+}
+
+// parse converts a record into a tick.
+func (r *CSVReader) parse(record []string) (*tick.Tick, error) {
+	t := tick.New()
+
+	for i, col := range r.columns {
+		if i >= len(record) {
+			break
+		}
+		value := strings.TrimSpace(record[i])
+
+		switch {
+		case col.time:
+			tm, err := r.parseTime(value)
+			if err != nil {
+				return nil, fmt.Errorf("column %q: %w", col.name, err)
+			}
+			t.SetTime(tm)
+		case col.tag != "":
+			if value != "" {
+				t.SetTag(col.tag, value)
+			}
+		case col.field != "":
+			if value == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(value, 64)
+			if err == nil {
+				t.SetField(col.field, v)
+				continue
+			}
+			if r.config.Fields != nil {
+				return nil, fmt.Errorf("column %q: invalid number %q", col.name, value)
+			}
+			r.warnColumn(col.name, value)
+		}
+	}
+
+	return t, nil
+}
+
+// parseTime parses a timestamp using the configured layout, or as a numeric
+// epoch, or with one of the common layouts.
+func (r *CSVReader) parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("missing timestamp")
+	}
+
+	if r.config.TimeLayout != "" {
+		return time.ParseInLocation(r.config.TimeLayout, value, r.config.Location)
+	}
+
+	if epoch, err := strconv.ParseFloat(value, 64); err == nil {
+		return epochTime(value, epoch, r.config.TimeUnit), nil
+	}
+
+	for _, layout := range timeLayouts {
+		if tm, err := time.ParseInLocation(layout, value, r.config.Location); err == nil {
+			return tm, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", value)
+}
+
+// epochTime converts a numeric epoch into a time. When unit is zero it is
+// inferred from the magnitude: seconds, milliseconds, microseconds or nanoseconds.
+func epochTime(value string, epoch float64, unit time.Duration) time.Time {
+	if unit == 0 {
+		switch abs := math.Abs(epoch); {
+		case abs < 1e11:
+			unit = time.Second
+		case abs < 1e14:
+			unit = time.Millisecond
+		case abs < 1e17:
+			unit = time.Microsecond
+		default:
+			unit = time.Nanosecond
+		}
+	}
+
+	// Integer epochs are converted exactly, float64 cannot hold nanoseconds.
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(0, n*int64(unit)).UTC()
+	}
+	sec, frac := math.Modf(epoch * float64(unit) / float64(time.Second))
+	return time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC()
+}
+
+// skip records a skipped row.
+func (r *CSVReader) skip(err error) {
+	r.skipped++
+	if r.skipped <= maxRowDiagnostics {
+		r.diags.AddWarning("Skipped CSV row", err.Error())
+	}
+}
+
+// warnColumn reports a non numeric value in an auto-mapped column once per column.
+func (r *CSVReader) warnColumn(name, value string) {
+	if r.warned[name] {
+		return
+	}
+	r.warned[name] = true
+	r.diags.AddWarning("Ignored non-numeric CSV value",
+		fmt.Sprintf("column %q: value %q is not a number, map the column as a tag to keep it", name, value))
+}
+
+// summarize reports the number of skipped rows once the input is exhausted.
+func (r *CSVReader) summarize() {
+	if r.skipped > maxRowDiagnostics {
+		r.diags.AddWarning("Skipped CSV rows",
+			fmt.Sprintf("%d invalid rows were skipped, only the first %d are reported", r.skipped, maxRowDiagnostics))
+	}
+}
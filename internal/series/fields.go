@@ -1,72 +1,93 @@
 package series
 
-// Field retrieves a slice of float64 values for the specified field from all ticks.
-// Returns an array containing the field values in chronological order.
+import "slices"
+
+// SetField sets the values of a field, one per tick. The values are copied
+// into the column of the field, so the series and its views see them and the
+// caller keeps its slice.
 func (t *Series) SetField(name string, field []float64) {
 	if len(field) != t.Len() {
 		panic("field length does not match series length")
 	}
-	for i := range t.ticks {
-		t.ticks[i].SetField(name, field[i])
+	col := t.cols.column(name)
+	copy(col.values, field)
+	for i := range col.set {
+		col.set[i] = true
 	}
-
 }
 
-// Field retrieves a slice of float64 values for the specified field from all ticks.
-// Returns an array containing the field values in chronological order.
-func (s *Series) Field(field string) (out []float64) {
-	for _, tick := range s.ticks {
-		out = append(out, tick.GetField(field))
+// Field returns a copy of the values of the specified field, one per tick in
+// chronological order. The ticks without the field hold NaN.
+func (s *Series) Field(field string) []float64 {
+	if col, ok := s.cols.fields[field]; ok {
+		return slices.Clone(col.values)
 	}
-	return out
+	return nanColumn(s.Len())
 }
 
-// Field retrieves a slice of float64 values for the specified field from all ticks.
-// Returns an array containing the field values in chronological order.
+// GetCol returns the timestamp in nanoseconds and the value of the field of every tick.
 func (s *Series) GetCol(field string) (column [][]float64) {
-	for _, tick := range s.ticks {
-		column = append(column, []float64{float64(tick.Epock()), tick.GetField(field)})
+	values := s.Field(field)
+	for i, nsec := range s.cols.times {
+		column = append(column, []float64{float64(nsec), values[i]})
 	}
 	return column
 }
 
-// Fields retrieves a map of field names to slices of float64 values from all ticks.
-// Returns a map where the keys are field names and the values are slices of field values in chronological order.
+// FieldMap returns a copy of the columns of the fields by name.
 func (t *Series) FieldMap() map[string][]float64 {
-	fields := make(map[string][]float64)
-
-	// Add the timestamp field
-	for _, tick := range t.ticks {
-		for k, v := range tick.Fields() {
-			fields[k] = append(fields[k], v)
+	fields := make(map[string][]float64, len(t.cols.fields))
+	for name, col := range t.cols.fields {
+		if !col.empty() {
+			fields[name] = slices.Clone(col.values)
 		}
 	}
 	return fields
 }
 
+// Columns returns the timestamps in nanoseconds since the Unix epoch, the
+// fields and the tags of the ticks, one column each.
 func (t *Series) Columns() map[string][]interface{} {
-	columns := make(map[string][]interface{})
-	
-	for i, tick := range t.ticks {
-		// Add the timestamp field
-		columns["timestamp"][i] = float64(tick.Epock())
+	n := t.Len()
+	columns := map[string][]interface{}{"timestamp": make([]interface{}, n)}
+	column := func(name string) []interface{} {
+		if _, ok := columns[name]; !ok {
+			columns[name] = make([]interface{}, n)
+		}
+		return columns[name]
+	}
 
-		// Add the fields
-		for k, v := range tick.Fields() {
-			columns[k][i] = v
+	// Add the fields
+	for name, col := range t.cols.fields {
+		if col.empty() {
+			continue
 		}
+		values := column(name)
+		for i, v := range col.values {
+			if col.set[i] {
+				values[i] = v
+			}
+		}
+	}
+
+	for i, nsec := range t.cols.times {
+		// Add the timestamp field
+		columns["timestamp"][i] = nsec
 
 		// Add the tags
-		for k, v := range tick.Tags() {
-			columns[k][i] = v
+		for k, v := range t.cols.tags[i] {
+			column(k)[i] = v
 		}
 	}
 	return columns
 }
 
+// FieldNames returns the names of the fields held by at least one tick.
 func (t *Series) FieldNames() (names []string) {
-	for name := range t.FieldMap() {
-		names = append(names, name)
+	for name, col := range t.cols.fields {
+		if !col.empty() {
+			names = append(names, name)
+		}
 	}
 	return names
 }
@@ -75,13 +96,18 @@ func (t *Series) FieldLen(field string) int {
 	return len(t.Field(field))
 }
 
-// HasField returns true if the Series collection contains the specified field.
+// HasField returns true if every tick of the Series collection has the specified fields.
 func (t *Series) HasField(fields ...string) bool {
 	for _, field := range fields {
-		for _, tick := range t.ticks {
-			if !tick.HasField(field) {
+		col, ok := t.cols.fields[field]
+		if !ok {
+			if t.Len() > 0 {
 				return false
 			}
+			continue
+		}
+		if !col.full() {
+			return false
 		}
 	}
 	return true
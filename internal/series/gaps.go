@@ -0,0 +1,238 @@
+package series
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"gonum.org/v1/gonum/interp"
+)
+
+// Gap is a run of consecutive expected timestamps without a tick.
+type Gap struct {
+	Start   time.Time // first missing timestamp
+	End     time.Time // last missing timestamp
+	Missing int       // number of missing timestamps
+}
+
+// GapReport compares the timestamps of a series with the timestamps expected
+// at its duration, and counts the NaN holes of each field.
+type GapReport struct {
+	Duration time.Duration  // spacing of the expected timestamps
+	Expected int            // number of expected timestamps between the first and last tick
+	Actual   int            // number of distinct expected timestamps that have a tick
+	Missing  []time.Time    // expected timestamps without a tick
+	Gaps     []Gap          // runs of missing timestamps
+	Holes    map[string]int // number of ticks missing each field or holding NaN
+}
+
+// Gaps reports the missing timestamps of the series at the given duration,
+// by default the series duration or the smallest spacing between ticks.
+func (s *Series) Gaps(duration ...time.Duration) *GapReport {
+	report := &GapReport{Duration: s.interval(duration...), Holes: make(map[string]int)}
+
+	for _, name := range s.FieldNames() {
+		for _, v := range s.cols.fields[name].values {
+			if math.IsNaN(v) {
+				report.Holes[name]++
+			}
+		}
+	}
+
+	if s.IsEmpty() || report.Duration <= 0 {
+		return report
+	}
+
+	present := make(map[int64]bool, s.Len())
+	for _, nsec := range s.cols.times {
+		present[time.Unix(0, nsec).Truncate(report.Duration).UnixNano()] = true
+	}
+
+	first, last := s.TimeRange()
+	var gap *Gap
+	for ts := first.Truncate(report.Duration); !ts.After(last); ts = ts.Add(report.Duration) {
+		report.Expected++
+		if present[ts.UnixNano()] {
+			report.Actual++
+			gap = nil
+			continue
+		}
+
+		report.Missing = append(report.Missing, ts)
+		if gap == nil {
+			report.Gaps = append(report.Gaps, Gap{Start: ts})
+			gap = &report.Gaps[len(report.Gaps)-1]
+		}
+		gap.End = ts
+		gap.Missing++
+	}
+	return report
+}
+
+// Reindex inserts an empty tick at every missing timestamp of the given
+// duration, carrying the tags of the previous tick, so the holes can be filled.
+func (s *Series) Reindex(duration ...time.Duration) *Series {
+	report := s.Gaps(duration...)
+	if len(report.Missing) == 0 {
+		return s
+	}
+
+	// the empty rows are appended and sorted into place
+	n, row := s.Len(), 0
+	for _, ts := range report.Missing {
+		nsec := ts.UnixNano()
+		for row < n && s.cols.times[row] < nsec {
+			row++
+		}
+		tags := map[string]string{}
+		if row > 0 {
+			tags = cloneTags(s.cols.Tags(row - 1))
+		}
+		s.cols.append(nsec, report.Duration, nil, tags)
+	}
+	s.cols.sort()
+	s.update()
+	return s
+}
+
+// interval returns the given duration, the series duration or the smallest
+// spacing between consecutive ticks.
+func (s *Series) interval(duration ...time.Duration) time.Duration {
+	if len(duration) > 0 && duration[0] > 0 {
+		return duration[0]
+	}
+	if d := s.Duration(); d > 0 {
+		return d
+	}
+
+	var smallest time.Duration
+	for i := 1; i < s.Len(); i++ {
+		d := time.Duration(s.cols.times[i] - s.cols.times[i-1])
+		if d > 0 && (smallest == 0 || d < smallest) {
+			smallest = d
+		}
+	}
+	return smallest
+}
+
+// FillStrategy decides how missing field values are replaced.
+type FillStrategy int
+
+const (
+	ForwardFill  FillStrategy = iota // carry the last known value forward
+	BackwardFill                     // carry the next known value backward
+	LinearFill                       // interpolate linearly in time between known values
+	SplineFill                       // interpolate with a natural cubic spline in time
+	ConstantFill                     // replace with a constant value
+	DropFill                         // remove the ticks missing the field
+)
+
+// Fill replaces the missing or NaN values of a field using the strategy.
+// Interpolation only fills holes between two known values, and ConstantFill
+// takes the constant as the optional value, zero by default.
+func (s *Series) Fill(field string, strategy FillStrategy, value ...float64) *Series {
+	missing := func(row int) bool {
+		v, ok := s.cols.Field(row, field)
+		return !ok || math.IsNaN(v)
+	}
+	get := func(row int) float64 {
+		v, _ := s.cols.Field(row, field)
+		return v
+	}
+
+	switch strategy {
+	case ForwardFill:
+		last := math.NaN()
+		for row := range s.Len() {
+			if !missing(row) {
+				last = get(row)
+			} else if !math.IsNaN(last) {
+				s.cols.SetField(row, field, last)
+			}
+		}
+
+	case BackwardFill:
+		next := math.NaN()
+		for row := s.Len() - 1; row >= 0; row-- {
+			if !missing(row) {
+				next = get(row)
+			} else if !math.IsNaN(next) {
+				s.cols.SetField(row, field, next)
+			}
+		}
+
+	case LinearFill, SplineFill:
+		s.interpolate(field, strategy)
+
+	case ConstantFill:
+		constant := 0.0
+		if len(value) > 0 {
+			constant = value[0]
+		}
+		for row := range s.Len() {
+			if missing(row) {
+				s.cols.SetField(row, field, constant)
+			}
+		}
+
+	case DropFill:
+		rows := make([]int, 0, s.Len())
+		for row := range s.Len() {
+			if !missing(row) {
+				rows = append(rows, row)
+			}
+		}
+		s.cols.take(rows)
+	}
+	return s
+}
+
+// interpolate fills the interior holes of a field in time.
+func (s *Series) interpolate(field string, strategy FillStrategy) {
+	if s.IsEmpty() {
+		return
+	}
+
+	// x is seconds since the first tick, float64 cannot hold epoch nanoseconds exactly
+	origin := s.cols.times[0]
+	x := func(row int) float64 {
+		return float64(s.cols.times[row]-origin) / float64(time.Second)
+	}
+
+	xs, ys := make([]float64, 0, s.Len()), make([]float64, 0, s.Len())
+	for row := range s.Len() {
+		v, ok := s.cols.Field(row, field)
+		if !ok || math.IsNaN(v) || (len(xs) > 0 && x(row) <= xs[len(xs)-1]) {
+			continue
+		}
+		xs, ys = append(xs, x(row)), append(ys, v)
+	}
+	if len(xs) < 2 {
+		return
+	}
+
+	var predictor interp.FittablePredictor = &interp.PiecewiseLinear{}
+	if strategy == SplineFill && len(xs) > 2 {
+		predictor = &interp.NaturalCubic{}
+	}
+	if err := predictor.Fit(xs, ys); err != nil {
+		return
+	}
+
+	for row := range s.Len() {
+		v, ok := s.cols.Field(row, field)
+		if xt := x(row); (!ok || math.IsNaN(v)) && xt > xs[0] && xt < xs[len(xs)-1] {
+			s.cols.SetField(row, field, predictor.Predict(xt))
+		}
+	}
+}
+
+// FillAll applies the strategy to every field of the series.
+func (s *Series) FillAll(strategy FillStrategy, value ...float64) *Series {
+	names := s.FieldNames()
+	sort.Strings(names)
+	for _, name := range names {
+		s.Fill(name, strategy, value...)
+	}
+	return s
+}
@@ -0,0 +1,123 @@
+package series
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+	"gonum.org/v1/gonum/stat"
+)
+
+func minutes(base time.Time, values map[int]float64) *Series {
+	s := New("gaps")
+	for i, v := range values {
+		t := tick.New(tick.WithTime(base.Add(time.Duration(i)*time.Minute)), tick.WithDuration(time.Minute))
+		if !math.IsNaN(v) {
+			t.SetField("price", v)
+		}
+		s.Add(t)
+	}
+	return s
+}
+
+func TestGaps(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := minutes(base, map[int]float64{0: 1, 1: math.NaN(), 4: 4, 5: 5, 7: 7})
+
+	report := s.Gaps()
+	if report.Duration != time.Minute || report.Expected != 8 || report.Actual != 5 {
+		t.Errorf("report = %+v", report)
+	}
+	if len(report.Gaps) != 2 || report.Gaps[0].Missing != 2 || !report.Gaps[0].Start.Equal(base.Add(2*time.Minute)) {
+		t.Errorf("gaps = %+v", report.Gaps)
+	}
+	if report.Holes["price"] != 1 {
+		t.Errorf("holes = %v", report.Holes)
+	}
+
+	s.Reindex().Fill("price", LinearFill)
+	want := []float64{1, 1.75, 2.5, 3.25, 4, 5, 6, 7}
+	for i, w := range want {
+		if got := s.At(i).GetField("price"); math.Abs(got-w) > 1e-9 {
+			t.Errorf("price[%d] = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestFillStrategies(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	values := map[int]float64{0: math.NaN(), 1: 1, 2: math.NaN(), 3: 3, 4: math.NaN()}
+
+	cases := []struct {
+		strategy FillStrategy
+		want     []float64
+	}{
+		{ForwardFill, []float64{math.NaN(), 1, 1, 3, 3}},
+		{BackwardFill, []float64{1, 1, 3, 3, math.NaN()}},
+		{ConstantFill, []float64{-1, 1, -1, 3, -1}},
+		{DropFill, []float64{1, 3}},
+	}
+	for _, c := range cases {
+		s := minutes(base, values).Fill("price", c.strategy, -1)
+		if s.Len() != len(c.want) {
+			t.Fatalf("strategy %d len = %d, want %d", c.strategy, s.Len(), len(c.want))
+		}
+		for i, w := range c.want {
+			got := s.At(i).GetField("price")
+			if got != w && !(math.IsNaN(got) && math.IsNaN(w)) {
+				t.Errorf("strategy %d price[%d] = %v, want %v", c.strategy, i, got, w)
+			}
+		}
+	}
+
+	spline := minutes(base, map[int]float64{0: 0, 1: 1, 2: math.NaN(), 3: 9, 4: 16}).Fill("price", SplineFill)
+	if got := spline.At(2).GetField("price"); got <= 1 || got >= 9 {
+		t.Errorf("spline price[2] = %v", got)
+	}
+}
+
+func TestNaNPolicy(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New("nan")
+	for i, v := range []float64{3, math.NaN(), 1, 2} {
+		s.Add(tick.New(tick.WithTime(base.Add(time.Duration(i)*time.Minute)), tick.WithFields(map[string]float64{"price": v})))
+	}
+
+	if !math.IsNaN(s.Mean("price")) || !math.IsNaN(s.Max("price")) || !math.IsNaN(s.Median("price")) {
+		t.Errorf("NaN should propagate")
+	}
+
+	skip := s.Copy(WithNaN(SkipNaN))
+	if skip.Mean("price") != 2 || skip.Sum("price") != 6 || skip.Median("price") != 2 || skip.Range("price") != 2 {
+		t.Errorf("mean = %v, sum = %v, median = %v", skip.Mean("price"), skip.Sum("price"), skip.Median("price"))
+	}
+	if skip.First("price") != 3 || skip.Last("price") != 2 {
+		t.Errorf("first = %v, last = %v", skip.First("price"), skip.Last("price"))
+	}
+}
+
+func TestWeightedNaN(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New("nan", WithNaN(SkipNaN))
+	for i, v := range []float64{1, math.NaN(), 2, 2, math.NaN(), 3} {
+		s.Add(tick.New(tick.WithTime(base.Add(time.Duration(i)*time.Minute)), tick.WithFields(map[string]float64{"price": v})))
+	}
+	// a tick without the price, left out like the NaN values
+	s.Add(tick.New(tick.WithTime(base.Add(6*time.Minute)), tick.WithFields(map[string]float64{"volume": 1})))
+
+	// the weights of the NaN ticks are dropped with them
+	weights := []float64{5, 100, 1, 1, 100, 1, 100}
+	if val, count := s.Mode("price", weights); val != 1 || count != 5 {
+		t.Errorf("weighted mode = %v (%v), want 1 (5)", val, count)
+	}
+	if got := s.Quantile("price")(0.5, stat.Empirical, weights); got != 1 {
+		t.Errorf("weighted median = %v, want 1", got)
+	}
+	if got := s.Quantile("price")(0.8, stat.Empirical, weights); got != 2 {
+		t.Errorf("weighted 0.8 quantile = %v, want 2", got)
+	}
+	if weights[1] != 100 || weights[0] != 5 {
+		t.Errorf("weights modified: %v", weights)
+	}
+}
@@ -0,0 +1,204 @@
+package series
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+// JoinType selects which timestamps a join keeps.
+type JoinType int
+
+const (
+	InnerJoin JoinType = iota // timestamps present in both series
+	LeftJoin                  // every timestamp of the left series
+	OuterJoin                 // timestamps present in either series
+	AsOfJoin                  // every left timestamp matched with the closest right tick
+)
+
+// Direction selects which right tick an as-of join matches.
+type Direction int
+
+const (
+	Backward Direction = iota // the last right tick at or before the left tick
+	Forward                   // the first right tick at or after the left tick
+	Nearest                   // the closest right tick in either direction
+)
+
+type joiner struct {
+	how         JoinType
+	direction   Direction
+	tolerance   time.Duration
+	leftPrefix  string
+	leftSuffix  string
+	rightPrefix string
+	rightSuffix string
+}
+
+type JoinOptions func(*joiner)
+
+// WithJoin sets the join type, InnerJoin by default.
+func WithJoin(how JoinType) JoinOptions {
+	return func(j *joiner) { j.how = how }
+}
+
+// WithDirection sets the match direction of an as-of join, Backward by default.
+func WithDirection(direction Direction) JoinOptions {
+	return func(j *joiner) { j.direction = direction }
+}
+
+// WithTolerance limits how far apart as-of matches may be, zero means unlimited.
+func WithTolerance(tolerance time.Duration) JoinOptions {
+	return func(j *joiner) { j.tolerance = tolerance }
+}
+
+// WithPrefix prefixes the field names of the left and right series.
+func WithPrefix(left, right string) JoinOptions {
+	return func(j *joiner) { j.leftPrefix, j.rightPrefix = left, right }
+}
+
+// WithSuffix suffixes the field names of the left and right series.
+func WithSuffix(left, right string) JoinOptions {
+	return func(j *joiner) { j.leftSuffix, j.rightSuffix = left, right }
+}
+
+// Join combines the fields of two series on timestamp. Right fields whose
+// name collides with a left field after prefixing and suffixing get the
+// right series name appended, e.g. "price_eth".
+func (s *Series) Join(other *Series, opts ...JoinOptions) *Series {
+	j := &joiner{}
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	leftNames := make(map[string]string)
+	for _, name := range s.FieldNames() {
+		leftNames[name] = j.leftPrefix + name + j.leftSuffix
+	}
+	rightNames := make(map[string]string)
+	for _, name := range other.FieldNames() {
+		renamed := j.rightPrefix + name + j.rightSuffix
+		for _, left := range leftNames {
+			if left == renamed {
+				renamed += "_" + other.Name()
+				break
+			}
+		}
+		rightNames[name] = renamed
+	}
+
+	join := func(left, right *tick.Tick) *tick.Tick {
+		base := left
+		if base == nil {
+			base = right
+		}
+		t := tick.New(tick.WithTags(cloneTags(base.Tags())))
+		t.SetUnixNano(base.UnixNano())
+		t.SetDuration(base.Duration())
+		if left != nil {
+			for k, v := range left.Fields() {
+				t.SetField(leftNames[k], v)
+			}
+			for k, v := range left.Signals() {
+				t.SetSignal(k, v)
+			}
+		}
+		if right != nil {
+			for k, v := range right.Fields() {
+				t.SetField(rightNames[k], v)
+			}
+		}
+		return t
+	}
+
+	output := s.Spawn()
+	ticks := make([]*tick.Tick, 0, s.Len())
+	lefts, rights := s.Ticks(), other.Ticks()
+
+	if j.how == AsOfJoin {
+		for _, left := range lefts {
+			ticks = append(ticks, join(left, j.match(left, rights)))
+		}
+		return output.Add(ticks...)
+	}
+
+	index := make(map[int64]*tick.Tick, other.Len())
+	for _, right := range rights {
+		index[right.UnixNano()] = right
+	}
+
+	for _, left := range lefts {
+		right, ok := index[left.UnixNano()]
+		if ok || j.how != InnerJoin {
+			ticks = append(ticks, join(left, right))
+		}
+	}
+
+	if j.how == OuterJoin {
+		seen := make(map[int64]bool, s.Len())
+		for _, left := range lefts {
+			seen[left.UnixNano()] = true
+		}
+		for _, right := range rights {
+			if !seen[right.UnixNano()] {
+				seen[right.UnixNano()] = true
+				ticks = append(ticks, join(nil, right))
+			}
+		}
+	}
+	return output.Add(ticks...)
+}
+
+// match returns the right tick an as-of join pairs with the left tick, or nil.
+func (j *joiner) match(left *tick.Tick, right []*tick.Tick) *tick.Tick {
+	ts := left.UnixNano()
+	// index of the first right tick after the left tick
+	after := sort.Search(len(right), func(i int) bool { return right[i].UnixNano() > ts })
+
+	var before, next *tick.Tick
+	if after > 0 {
+		before = right[after-1]
+	}
+	if after > 0 && right[after-1].UnixNano() == ts {
+		next = right[after-1]
+	} else if after < len(right) {
+		next = right[after]
+	}
+
+	var found *tick.Tick
+	switch j.direction {
+	case Backward:
+		found = before
+	case Forward:
+		found = next
+	case Nearest:
+		found = before
+		if found == nil || (next != nil && next.UnixNano()-ts < ts-found.UnixNano()) {
+			found = next
+		}
+	}
+
+	if found == nil {
+		return nil
+	}
+	if distance := time.Duration(abs(found.UnixNano() - ts)); j.tolerance > 0 && distance > j.tolerance {
+		return nil
+	}
+	return found
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func cloneTags(tags map[string]string) map[string]string {
+	clone := make(map[string]string, len(tags))
+	for k, v := range tags {
+		clone[k] = v
+	}
+	return clone
+}
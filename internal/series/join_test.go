@@ -0,0 +1,73 @@
+package series
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+func prices(name string, base time.Time, offsets []time.Duration, values []float64) *Series {
+	s := New(name)
+	for i, offset := range offsets {
+		s.Add(tick.New(
+			tick.WithTime(base.Add(offset)),
+			tick.WithFields(map[string]float64{"price": values[i]}),
+			tick.WithTags(map[string]string{"symbol": name}),
+		))
+	}
+	return s
+}
+
+func TestJoin(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	btc := prices("btc", base, []time.Duration{0, time.Minute, 2 * time.Minute}, []float64{100, 101, 102})
+	eth := prices("eth", base, []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute}, []float64{10, 11, 12})
+
+	inner := btc.Join(eth, WithSuffix("_btc", "_eth"))
+	if inner.Len() != 2 {
+		t.Fatalf("inner join len = %d, want 2", inner.Len())
+	}
+	if got := inner.At(0).GetField("price_btc") - inner.At(0).GetField("price_eth"); got != 91 {
+		t.Errorf("spread = %v, want 91", got)
+	}
+
+	left := btc.Join(eth, WithJoin(LeftJoin), WithPrefix("btc_", "eth_"))
+	if left.Len() != 3 || left.At(0).HasField("eth_price") || left.At(2).GetField("eth_price") != 11 {
+		t.Errorf("left join = %v", left.FieldMap())
+	}
+
+	outer := btc.Join(eth, WithJoin(OuterJoin))
+	if outer.Len() != 4 {
+		t.Fatalf("outer join len = %d, want 4", outer.Len())
+	}
+	last := outer.At(3)
+	if last.GetField("price_eth") != 12 || !math.IsNaN(last.GetField("price")) || last.GetTag("symbol") != "eth" {
+		t.Errorf("right only tick = %v %v", last.Fields(), last.Tags())
+	}
+}
+
+func TestAsOfJoin(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	trades := prices("trades", base, []time.Duration{10 * time.Millisecond, 55 * time.Millisecond, 300 * time.Millisecond}, []float64{1, 2, 3})
+	quotes := prices("quotes", base, []time.Duration{0, 50 * time.Millisecond, 60 * time.Millisecond}, []float64{10, 20, 30})
+
+	backward := trades.Join(quotes, WithJoin(AsOfJoin), WithPrefix("", "quote_"), WithTolerance(100*time.Millisecond))
+	want := []float64{10, 20, math.NaN()}
+	for i, w := range want {
+		got := backward.At(i).GetField("quote_price")
+		if got != w && !(math.IsNaN(got) && math.IsNaN(w)) {
+			t.Errorf("backward tick %d quote = %v, want %v", i, got, w)
+		}
+	}
+
+	nearest := trades.Join(quotes, WithJoin(AsOfJoin), WithDirection(Nearest), WithPrefix("", "quote_"))
+	if got := nearest.At(1).GetField("quote_price"); got != 20 {
+		t.Errorf("nearest quote = %v, want 20", got)
+	}
+	forward := trades.Join(quotes, WithJoin(AsOfJoin), WithDirection(Forward), WithPrefix("", "quote_"))
+	if got := forward.At(1).GetField("quote_price"); got != 30 {
+		t.Errorf("forward quote = %v, want 30", got)
+	}
+}
@@ -0,0 +1,75 @@
+package series
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+// jsonSeries is the wire format of a Series.
+type jsonSeries struct {
+	Name  string            `json:"name"`
+	Tags  map[string]string `json:"tags,omitempty"`
+	Ticks []*tick.Tick      `json:"ticks"`
+}
+
+// MarshalJSON encodes the series name, tags and ticks.
+func (s *Series) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonSeries{
+		Name:  s.name,
+		Tags:  s.tags,
+		Ticks: s.Ticks(),
+	})
+}
+
+// UnmarshalJSON decodes a series encoded by MarshalJSON.
+func (s *Series) UnmarshalJSON(data []byte) error {
+	var in jsonSeries
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	s.name = in.Name
+	s.tags = in.Tags
+	if s.tags == nil {
+		s.tags = make(map[string]string)
+	}
+	s.cols = newColumns()
+	for _, t := range Sort(in.Ticks) {
+		s.cols.add(t)
+	}
+	return nil
+}
+
+// ScanJSONL decodes one tick per line from r and passes each to fn, without
+// holding more than a single line in memory. Blank lines are skipped and
+// decoding stops at the first error returned by fn.
+func ScanJSONL(r io.Reader, fn func(t *tick.Tick) error) error {
+	reader := bufio.NewReaderSize(r, 64*1024)
+
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			t := tick.New()
+			if jerr := json.Unmarshal(data, t); jerr != nil {
+				return fmt.Errorf("line %d: %w", line, jerr)
+			}
+			if ferr := fn(t); ferr != nil {
+				return ferr
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
@@ -1,14 +1,16 @@
 package series
 
 import (
-	"encoding/csv"
+	"bufio"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
+	"path/filepath"
 	"strings"
-	"time"
 
+	"github.com/rangertaha/gotal/internal/diag"
 	"github.com/rangertaha/gotal/internal/pkg/tick"
 )
 
@@ -74,73 +76,97 @@ func Load(path string, options ...Option) (*Series, error) {
 
 // ReadCSV reads a CSV file and returns a series collection.
 func ReadCSV(path string) (*Series, error) {
+	s, diags := LoadCSV(path)
+	if diags.HasError() {
+		return nil, diagError(diags)
+	}
+	return s, nil
+}
+
+// LoadCSV streams a CSV file into a series collection using the given column
+// mapping. Skipped rows are reported as warnings, failures as errors.
+func LoadCSV(path string, opts ...CSVOptions) (*Series, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	file, err := os.Open(path)
 	if err != nil {
-		return nil, err
+		diags.AddError("Failed to open CSV file", err.Error())
+		return nil, diags
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
-	reader.TrimLeadingSpace = true
+	reader := NewCSVReader(bufio.NewReader(file), opts...)
+	ticks := make([]*tick.Tick, 0)
+	for {
+		t, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			diags.Append(reader.Diagnostics()...)
+			return nil, diags
+		}
+		ticks = append(ticks, t)
+	}
+	diags.Append(reader.Diagnostics()...)
+
+	s := New(fileName(path))
+	s.Set(ticks...)
+	return s, diags
+}
 
-	records, err := reader.ReadAll()
+// ReadJSON reads a JSON file and returns a series collection.
+func ReadJSON(path string) (*Series, error) {
+	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
 
-	if len(records) == 0 {
-		return nil, errors.New("empty CSV file")
+	s := New("series")
+	if err := json.NewDecoder(bufio.NewReader(file)).Decode(s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
 	}
-
-	header := records[0]
-	fieldIdx := make(map[string]int)
-	for i, field := range header {
-		fieldIdx[field] = i
+	if s.Name() == "" {
+		s.SetName(fileName(path))
 	}
+	return s, nil
+}
 
-	ticks := make([]*tick.Tick, 0, len(records)-1)
-
-	for _, row := range records[1:] {
-		t := tick.New()
+// ReadJSONL reads a JSONL file, one tick per line, and returns a series collection.
+// The series is named after the file.
+func ReadJSONL(path string) (*Series, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
 
-		for k, idx := range fieldIdx {
-			if idx >= len(row) {
-				continue
-			}
-			valueStr := row[idx]
-			if k == "time" || k == "timestamp" {
-				// Parse as integer seconds since epoch
-				ts, err := strconv.ParseInt(valueStr, 10, 64)
-				if err != nil {
-					// try RFC3339
-					tm, err2 := time.Parse(time.RFC3339, valueStr)
-					if err2 == nil {
-						t.SetTime(tm)
-					}
-				} else {
-					t.SetTime(time.Unix(ts, 0))
-				}
-			} else {
-				v, err := strconv.ParseFloat(valueStr, 64)
-				if err == nil {
-					t.SetField(k, v)
-				}
-			}
-		}
+	ticks := make([]*tick.Tick, 0)
+	err = ScanJSONL(file, func(t *tick.Tick) error {
 		ticks = append(ticks, t)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
 	}
 
-	s := New("series")
-	s.ticks = ticks
+	s := New(fileName(path))
+	s.Set(ticks...)
 	return s, nil
 }
 
-// ReadJSON reads a JSON file and returns a series collection.
-func ReadJSON(path string) (*Series, error) {
-	return nil, errors.New("JSON format not implemented yet")
+// diagError joins the error diagnostics into a single error.
+func diagError(diags diag.Diagnostics) error {
+	errs := make([]error, 0, diags.ErrorsCount())
+	for _, d := range diags.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return errors.Join(errs...)
 }
 
-// ReadJSONL reads a JSONL file and returns a series collection.
-func ReadJSONL(path string) (*Series, error) {
-	return nil, errors.New("JSONL format not implemented yet")
+// fileName returns the base name of the path without its extension.
+func fileName(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
 }
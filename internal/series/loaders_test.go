@@ -0,0 +1,216 @@
+package series
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/sig"
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+func testSeries() *Series {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New("btc")
+	for i := 0; i < 3; i++ {
+		t := tick.New(
+			tick.WithTime(start.Add(time.Duration(i)*time.Minute)),
+			tick.WithDuration(time.Minute),
+			tick.WithFields(map[string]float64{"price": 100 + float64(i), "volume": 2.5}),
+			tick.WithTags(map[string]string{"symbol": "BTC"}),
+		)
+		if i == 1 {
+			t.SetSignal(sig.BULLISH, sig.STRONG)
+			t.SetField("missing", math.NaN())
+		}
+		s.Add(t)
+	}
+	s.SetTag("exchange", "coinbase")
+	return s
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, ext := range []string{".json", ".jsonl"} {
+		t.Run(ext, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "btc"+ext)
+			want := testSeries()
+			if err := want.Save(path); err != nil {
+				t.Fatalf("save: %v", err)
+			}
+
+			got, err := Load(path)
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if got.Name() != "btc" {
+				t.Errorf("name = %q, want %q", got.Name(), "btc")
+			}
+			if ext == ".json" && got.GetTag("exchange") != "coinbase" {
+				t.Errorf("series tag exchange = %q, want %q", got.GetTag("exchange"), "coinbase")
+			}
+			if got.Len() != want.Len() {
+				t.Fatalf("len = %d, want %d", got.Len(), want.Len())
+			}
+			for i, w := range want.Ticks() {
+				g := got.At(i)
+				if !g.Time().Equal(w.Time()) {
+					t.Errorf("tick %d time = %v, want %v", i, g.Time(), w.Time())
+				}
+				if g.Duration() != w.Duration() {
+					t.Errorf("tick %d duration = %v, want %v", i, g.Duration(), w.Duration())
+				}
+				if g.GetField("price") != w.GetField("price") || g.GetField("volume") != w.GetField("volume") {
+					t.Errorf("tick %d fields = %v, want %v", i, g.Fields(), w.Fields())
+				}
+				if g.GetTag("symbol") != "BTC" {
+					t.Errorf("tick %d symbol = %q", i, g.GetTag("symbol"))
+				}
+			}
+			if !got.At(1).HasField("missing") || !math.IsNaN(got.At(1).GetField("missing")) {
+				t.Errorf("NaN field not preserved: %v", got.At(1).Fields())
+			}
+			if got.At(1).GetSignal(sig.BULLISH) != sig.STRONG {
+				t.Errorf("signals = %v, want BULLISH:STRONG", got.At(1).Signals())
+			}
+		})
+	}
+}
+
+func TestScanJSONLReportsLine(t *testing.T) {
+	input := `{"time":"2025-01-01T00:00:00Z","fields":{"price":1}}
+
+{"time":"2025-01-01T00:01:00Z","fields":{"price":"x"}}
+`
+	count := 0
+	err := ScanJSONL(strings.NewReader(input), func(t *tick.Tick) error {
+		count++
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("err = %v, want error on line 3", err)
+	}
+	if count != 1 {
+		t.Errorf("decoded %d ticks before the error, want 1", count)
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	input := `ts;symbol;px;qty;side
+1735689600000;BTC;100.5;1;buy
+1735689600250;BTC;bad;2;sell
+1735689601500;ETH;101;;buy
+`
+	reader := NewCSVReader(strings.NewReader(input),
+		WithDelimiter(';'),
+		WithTimeColumn("ts"),
+		WithFieldMap(map[string]string{"px": "price", "qty": "volume"}),
+		WithTagColumns("side"),
+	)
+
+	ticks := make([]*tick.Tick, 0)
+	for {
+		tk, err := reader.Read()
+		if err != nil {
+			break
+		}
+		ticks = append(ticks, tk)
+	}
+
+	if len(ticks) != 2 {
+		t.Fatalf("read %d ticks, want 2", len(ticks))
+	}
+	if got := ticks[0].Time(); !got.Equal(time.UnixMilli(1735689600000)) {
+		t.Errorf("time = %v", got)
+	}
+	if ticks[0].GetField("price") != 100.5 || ticks[0].GetField("volume") != 1 {
+		t.Errorf("fields = %v", ticks[0].Fields())
+	}
+	if ticks[1].HasField("volume") {
+		t.Errorf("empty cell should not set a field: %v", ticks[1].Fields())
+	}
+	if ticks[1].GetTag("symbol") != "ETH" || ticks[1].GetTag("side") != "buy" {
+		t.Errorf("tags = %v", ticks[1].Tags())
+	}
+
+	diags := reader.Diagnostics()
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("diagnostics = %v, want one warning", diags)
+	}
+	if detail := diags.Warnings()[0].Detail(); !strings.Contains(detail, "line 3") {
+		t.Errorf("warning detail = %q, want line 3", detail)
+	}
+}
+
+func TestCSVReaderLayout(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	input := "01/02/2025 09:30,10\n01/02/2025 09:31,11\n"
+	reader := NewCSVReader(strings.NewReader(input),
+		WithColumns("date", "close"),
+		WithTimeColumn("date"),
+		WithTimeLayout("01/02/2006 15:04"),
+		WithLocation(ny),
+		WithStrict(),
+	)
+
+	tk, err := reader.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 1, 2, 9, 30, 0, 0, ny); !tk.Time().Equal(want) {
+		t.Errorf("time = %v, want %v", tk.Time(), want)
+	}
+	if tk.GetField("close") != 10 {
+		t.Errorf("close = %v", tk.GetField("close"))
+	}
+}
+
+func TestSubSecondRoundTrip(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := New("trades")
+	for _, offset := range []time.Duration{3 * time.Microsecond, time.Microsecond, 2 * time.Microsecond} {
+		want.Add(tick.New(
+			tick.WithTime(base.Add(offset)),
+			tick.WithFields(map[string]float64{"price": float64(offset)}),
+		))
+	}
+	if got := want.At(0).GetField("price"); got != float64(time.Microsecond) {
+		t.Fatalf("ticks within the same second are not ordered: first price = %v", got)
+	}
+
+	for _, ext := range []string{".csv", ".json", ".jsonl"} {
+		path := filepath.Join(t.TempDir(), "trades"+ext)
+		if err := want.Save(path); err != nil {
+			t.Fatalf("%s save: %v", ext, err)
+		}
+		got, err := Load(path)
+		if err != nil {
+			t.Fatalf("%s load: %v", ext, err)
+		}
+		for i, w := range want.Ticks() {
+			if g := got.AtTime(w.Time()); g == nil || g.GetField("price") != w.GetField("price") {
+				t.Errorf("%s tick %d at %v not found", ext, i, w.Time())
+			}
+		}
+	}
+}
+
+func TestSubSecondColumns(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New("trades")
+	for _, offset := range []time.Duration{time.Microsecond, 2 * time.Microsecond} {
+		s.Add(tick.New(tick.WithTime(base.Add(offset)), tick.WithFields(map[string]float64{"price": 1})))
+	}
+
+	timestamps := s.Columns()["timestamp"]
+	if timestamps[0] == timestamps[1] || timestamps[1] != base.Add(2*time.Microsecond).UnixNano() {
+		t.Errorf("timestamps = %v", timestamps)
+	}
+	if col := s.GetCol("price"); col[0][0] == col[1][0] {
+		t.Errorf("GetCol timestamps = %v, %v", col[0][0], col[1][0])
+	}
+}
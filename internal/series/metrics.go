@@ -0,0 +1,341 @@
+package series
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Year is the default length of a year used to annualise metrics. Markets
+// that only trade on business days can use WithPeriodsPerYear(252) instead.
+const Year = 365 * 24 * time.Hour
+
+// Returns writes the simple return of the field, x[i]/x[i-1] - 1.
+func (s *Series) Returns(field string, output ...string) *Series {
+	return s.change(name(output, field, "return"), field, func(prev, x float64) float64 {
+		return x/prev - 1
+	})
+}
+
+// LogReturns writes the log return of the field, ln(x[i]/x[i-1]).
+func (s *Series) LogReturns(field string, output ...string) *Series {
+	return s.change(name(output, field, "logreturn"), field, func(prev, x float64) float64 {
+		return math.Log(x / prev)
+	})
+}
+
+// CumulativeReturns writes the return of the field since the first value.
+func (s *Series) CumulativeReturns(field string, output ...string) *Series {
+	xs := s.Field(field)
+	out := make([]float64, len(xs))
+	first := math.NaN()
+	for i, x := range xs {
+		if math.IsNaN(first) {
+			first = x
+		}
+		out[i] = x/first - 1
+	}
+	s.SetField(name(output, field, "cumreturn"), out)
+	return s
+}
+
+// Drawdown writes the fall of the field from its running peak, x/peak - 1.
+func (s *Series) Drawdown(field string, output ...string) *Series {
+	xs := s.Field(field)
+	out := make([]float64, len(xs))
+	peak := math.NaN()
+	for i, x := range xs {
+		if math.IsNaN(peak) || x > peak {
+			peak = x
+		}
+		out[i] = x/peak - 1
+	}
+	s.SetField(name(output, field, "drawdown"), out)
+	return s
+}
+
+// change writes fn of each value and the previous non NaN value of the field.
+func (s *Series) change(output, field string, fn func(prev, x float64) float64) *Series {
+	xs := s.Field(field)
+	out := make([]float64, len(xs))
+	prev := math.NaN()
+	for i, x := range xs {
+		out[i] = fn(prev, x)
+		if !math.IsNaN(x) {
+			prev = x
+		}
+	}
+	s.SetField(output, out)
+	return s
+}
+
+// Metrics evaluates a price or equity field of a series as an investment.
+// Returns are simple period returns between consecutive non NaN values and
+// annual figures scale them by the number of periods per year, which follows
+// from the series Duration() or the smallest spacing between ticks.
+type Metrics struct {
+	values  []float64 // non NaN values of the field
+	times   []int64   // timestamps of the values
+	returns []float64 // returns[i] is the return from values[i] to values[i+1]
+
+	periods  float64 // periods per year
+	riskFree float64 // risk free rate per period
+}
+
+type MetricsOptions func(*metrics)
+
+type metrics struct {
+	periods  float64
+	riskFree float64
+}
+
+// WithPeriodsPerYear sets the number of periods per year, e.g. 252 for daily bars of a stock exchange.
+func WithPeriodsPerYear(periods float64) MetricsOptions {
+	return func(m *metrics) { m.periods = periods }
+}
+
+// WithRiskFree sets the annual risk free rate, zero by default.
+func WithRiskFree(rate float64) MetricsOptions {
+	return func(m *metrics) { m.riskFree = rate }
+}
+
+// Metrics returns the metrics of the field.
+func (s *Series) Metrics(field string, opts ...MetricsOptions) *Metrics {
+	o := &metrics{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.periods <= 0 {
+		if d := s.interval(); d > 0 {
+			o.periods = float64(Year) / float64(d)
+		} else {
+			o.periods = 1
+		}
+	}
+
+	m := &Metrics{periods: o.periods, riskFree: math.Pow(1+o.riskFree, 1/o.periods) - 1}
+	times := s.Times()
+	for i, x := range s.Field(field) {
+		if math.IsNaN(x) {
+			continue
+		}
+		if n := len(m.values); n > 0 {
+			m.returns = append(m.returns, x/m.values[n-1]-1)
+		}
+		m.values = append(m.values, x)
+		m.times = append(m.times, times[i])
+	}
+	return m
+}
+
+// PeriodsPerYear returns the number of periods used to annualise.
+func (m *Metrics) PeriodsPerYear() float64 {
+	return m.periods
+}
+
+// Returns returns the simple period returns.
+func (m *Metrics) Returns() []float64 {
+	return m.returns
+}
+
+// LogReturns returns the log period returns.
+func (m *Metrics) LogReturns() []float64 {
+	out := make([]float64, len(m.returns))
+	for i, r := range m.returns {
+		out[i] = math.Log1p(r)
+	}
+	return out
+}
+
+// TotalReturn returns the return from the first to the last value.
+func (m *Metrics) TotalReturn() float64 {
+	if len(m.values) < 2 {
+		return math.NaN()
+	}
+	return m.values[len(m.values)-1]/m.values[0] - 1
+}
+
+// AnnualReturn returns the compound annual growth rate.
+func (m *Metrics) AnnualReturn() float64 {
+	if len(m.returns) == 0 {
+		return math.NaN()
+	}
+	return math.Pow(1+m.TotalReturn(), m.periods/float64(len(m.returns))) - 1
+}
+
+// Volatility returns the annualised standard deviation of the returns.
+func (m *Metrics) Volatility() float64 {
+	if len(m.returns) < 2 {
+		return math.NaN()
+	}
+	return stat.StdDev(m.returns, nil) * math.Sqrt(m.periods)
+}
+
+// Sharpe returns the annualised Sharpe ratio, the mean excess return over its standard deviation.
+func (m *Metrics) Sharpe() float64 {
+	if len(m.returns) < 2 {
+		return math.NaN()
+	}
+	mean, std := stat.MeanStdDev(m.returns, nil)
+	return (mean - m.riskFree) / std * math.Sqrt(m.periods)
+}
+
+// Sortino returns the annualised Sortino ratio, the mean excess return over
+// the downside deviation below the risk free rate.
+func (m *Metrics) Sortino() float64 {
+	if len(m.returns) < 2 {
+		return math.NaN()
+	}
+	var downside float64
+	for _, r := range m.returns {
+		if d := r - m.riskFree; d < 0 {
+			downside += d * d
+		}
+	}
+	downside = math.Sqrt(downside / float64(len(m.returns)))
+	return (stat.Mean(m.returns, nil) - m.riskFree) / downside * math.Sqrt(m.periods)
+}
+
+// Calmar returns the annual return over the maximum drawdown.
+func (m *Metrics) Calmar() float64 {
+	return m.AnnualReturn() / math.Abs(m.MaxDrawdown().Depth)
+}
+
+// Drawdown is a fall of the values from a peak.
+type Drawdown struct {
+	Depth     float64       // fall from the peak as a negative fraction, e.g. -0.25
+	Peak      time.Time     // time of the peak
+	Trough    time.Time     // time of the lowest value
+	Recovery  time.Time     // time the peak was regained, zero if it was not
+	Duration  time.Duration // from the peak to the recovery or to the last value
+	Recovered bool
+}
+
+// MaxDrawdown returns the deepest drawdown.
+func (m *Metrics) MaxDrawdown() Drawdown {
+	var max Drawdown
+	peak, maxPeak, maxTrough := 0, -1, 0
+	for i, x := range m.values {
+		if x >= m.values[peak] {
+			peak = i
+			continue
+		}
+		if depth := x/m.values[peak] - 1; depth < max.Depth {
+			max.Depth, maxPeak, maxTrough = depth, peak, i
+		}
+	}
+	if maxPeak < 0 {
+		return max
+	}
+
+	max.Peak = time.Unix(0, m.times[maxPeak])
+	max.Trough = time.Unix(0, m.times[maxTrough])
+	end := m.times[len(m.times)-1]
+	for i := maxTrough + 1; i < len(m.values); i++ {
+		if m.values[i] >= m.values[maxPeak] {
+			max.Recovery, max.Recovered = time.Unix(0, m.times[i]), true
+			end = m.times[i]
+			break
+		}
+	}
+	max.Duration = time.Duration(end - m.times[maxPeak])
+	return max
+}
+
+// VaRMethod selects how the value at risk is estimated.
+type VaRMethod int
+
+const (
+	HistoricalVaR VaRMethod = iota // from the empirical quantile of the returns
+	ParametricVaR                  // from a normal distribution fitted to the returns
+)
+
+// VaR returns the value at risk of one period at the confidence level, e.g.
+// 0.95, as a positive fraction: the loss that is not exceeded with that
+// probability. The historical estimate is the smallest loss of the worst
+// 1-confidence of the returns.
+func (m *Metrics) VaR(confidence float64, method VaRMethod) float64 {
+	if len(m.returns) < 2 {
+		return math.NaN()
+	}
+	if method == ParametricVaR {
+		mean, std := stat.MeanStdDev(m.returns, nil)
+		return -(mean + std*distuv.UnitNormal.Quantile(1-confidence))
+	}
+	tail := m.tail(confidence)
+	return -tail[len(tail)-1]
+}
+
+// CVaR returns the conditional value at risk, the expected loss of one
+// period in the worst 1-confidence of cases, as a positive fraction.
+func (m *Metrics) CVaR(confidence float64, method VaRMethod) float64 {
+	if len(m.returns) < 2 {
+		return math.NaN()
+	}
+	if method == ParametricVaR {
+		mean, std := stat.MeanStdDev(m.returns, nil)
+		z := distuv.UnitNormal.Quantile(1 - confidence)
+		return -(mean - std*distuv.UnitNormal.Prob(z)/(1-confidence))
+	}
+	return -stat.Mean(m.tail(confidence), nil)
+}
+
+// tail returns the worst 1-confidence of the returns, at least one, sorted.
+func (m *Metrics) tail(confidence float64) []float64 {
+	sorted := append([]float64(nil), m.returns...)
+	sort.Float64s(sorted)
+	// the epsilon keeps e.g. (1-0.95)*100 from rounding up to 6
+	n := int(math.Ceil((1-confidence)*float64(len(sorted)) - 1e-9))
+	return sorted[:min(max(n, 1), len(sorted))]
+}
+
+// Beta returns the sensitivity of the returns to the benchmark returns,
+// paired on timestamp.
+func (m *Metrics) Beta(benchmark *Metrics) float64 {
+	x, y := m.paired(benchmark)
+	if len(x) < 2 {
+		return math.NaN()
+	}
+	return stat.Covariance(x, y, nil) / stat.Variance(y, nil)
+}
+
+// Alpha returns the annualised Jensen's alpha, the excess return not
+// explained by the beta to the benchmark.
+func (m *Metrics) Alpha(benchmark *Metrics) float64 {
+	x, y := m.paired(benchmark)
+	if len(x) < 2 {
+		return math.NaN()
+	}
+	beta := stat.Covariance(x, y, nil) / stat.Variance(y, nil)
+	return (stat.Mean(x, nil) - m.riskFree - beta*(stat.Mean(y, nil)-m.riskFree)) * m.periods
+}
+
+// InformationRatio returns the annualised mean return in excess of the
+// benchmark over the tracking error.
+func (m *Metrics) InformationRatio(benchmark *Metrics) float64 {
+	x, y := m.paired(benchmark)
+	if len(x) < 2 {
+		return math.NaN()
+	}
+	floats.Sub(x, y)
+	mean, std := stat.MeanStdDev(x, nil)
+	return mean / std * math.Sqrt(m.periods)
+}
+
+// paired returns copies of the returns of both metrics at their common timestamps.
+func (m *Metrics) paired(benchmark *Metrics) (x, y []float64) {
+	index := make(map[int64]float64, len(benchmark.returns))
+	for i, r := range benchmark.returns {
+		index[benchmark.times[i+1]] = r
+	}
+	for i, r := range m.returns {
+		if b, ok := index[m.times[i+1]]; ok {
+			x, y = append(x, r), append(y, b)
+		}
+	}
+	return x, y
+}
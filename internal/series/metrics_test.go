@@ -0,0 +1,95 @@
+package series
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+func equity(values ...float64) *Series {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New("equity")
+	for i, v := range values {
+		s.Add(tick.New(
+			tick.WithTime(base.Add(time.Duration(i)*24*time.Hour)),
+			tick.WithFields(map[string]float64{"equity": v}),
+		))
+	}
+	return s
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
+
+func TestMetrics(t *testing.T) {
+	s := equity(100, 110, 99, 88, 99, 121, 110)
+	m := s.Metrics("equity")
+
+	if got := m.PeriodsPerYear(); got != 365 {
+		t.Errorf("PeriodsPerYear() = %v, want 365 for daily ticks", got)
+	}
+	if got := m.TotalReturn(); !near(got, 0.1) {
+		t.Errorf("TotalReturn() = %v, want 0.1", got)
+	}
+	if got, want := m.AnnualReturn(), math.Pow(1.1, 365.0/6)-1; !near(got, want) {
+		t.Errorf("AnnualReturn() = %v, want %v", got, want)
+	}
+
+	dd := m.MaxDrawdown()
+	if !near(dd.Depth, 88.0/110-1) || !dd.Recovered {
+		t.Errorf("MaxDrawdown() = %+v, want depth %v and recovered", dd, 88.0/110-1)
+	}
+	if dd.Duration != 4*24*time.Hour || dd.Trough.Sub(dd.Peak) != 2*24*time.Hour {
+		t.Errorf("MaxDrawdown() duration %v, trough after %v", dd.Duration, dd.Trough.Sub(dd.Peak))
+	}
+
+	s.Drawdown("equity")
+	if got := s.At(3).GetField("equity_drawdown"); !near(got, dd.Depth) {
+		t.Errorf("equity_drawdown[3] = %v, want %v", got, dd.Depth)
+	}
+	s.Returns("equity")
+	if got := s.At(1).GetField("equity_return"); !near(got, 0.1) || !math.IsNaN(s.At(0).GetField("equity_return")) {
+		t.Errorf("equity_return = %v, want NaN then 0.1", got)
+	}
+}
+
+func TestValueAtRisk(t *testing.T) {
+	values := []float64{100}
+	for i := 0; i < 100; i++ {
+		// returns cycle through -5% .. +4.5% in steps of 0.5%
+		values = append(values, values[len(values)-1]*(1+float64(i%20-10)/200))
+	}
+	m := equity(values...).Metrics("equity")
+
+	if got := m.VaR(0.9, HistoricalVaR); !near(got, 0.045) {
+		t.Errorf("historical VaR = %v, want 0.045", got)
+	}
+	if got := m.CVaR(0.9, HistoricalVaR); !near(got, 0.0475) {
+		t.Errorf("historical CVaR = %v, want 0.0475", got)
+	}
+	if v, c := m.VaR(0.99, ParametricVaR), m.CVaR(0.99, ParametricVaR); !(c > v && v > 0) {
+		t.Errorf("parametric VaR = %v, CVaR = %v, want 0 < VaR < CVaR", v, c)
+	}
+}
+
+func TestBeta(t *testing.T) {
+	benchmark := equity(100, 102, 101, 104, 103, 107)
+	returns := benchmark.Metrics("equity").Returns()
+
+	values := []float64{50}
+	for _, r := range returns {
+		values = append(values, values[len(values)-1]*(1+2*r+0.001))
+	}
+	m := equity(values...).Metrics("equity")
+	b := benchmark.Metrics("equity")
+
+	if got := m.Beta(b); !near(got, 2) {
+		t.Errorf("Beta() = %v, want 2", got)
+	}
+	if got := m.Alpha(b); !near(got, 0.001*365) {
+		t.Errorf("Alpha() = %v, want %v", got, 0.001*365)
+	}
+}
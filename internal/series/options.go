@@ -1,6 +1,7 @@
 package series
 
 import (
+	"math"
 	"time"
 
 	"github.com/rangertaha/gotal/internal/pkg/tick"
@@ -32,8 +33,14 @@ func WithName(name string) SeriesOptions {
 // 	}
 // }
 
+// WithNaN sets how the statistical methods treat NaN values, e.g.
+// s.Copy(WithNaN(SkipNaN)).Mean("price").
+func WithNaN(policy NaNPolicy) SeriesOptions {
+	return func(s *Series) { s.nan = policy }
+}
+
 func WithTicks(ticks ...*tick.Tick) SeriesOptions {
-	return func(s *Series) { s.ticks = ticks }
+	return func(s *Series) { s.Set(ticks...) }
 }
 
 func WithFields(fields ...map[string]float64) SeriesOptions {
@@ -42,7 +49,8 @@ func WithFields(fields ...map[string]float64) SeriesOptions {
 			tick := tick.New()
 			for k, v := range field {
 				if k == "time" {
-					tick.SetTime(time.Unix(int64(v), 0))
+					sec, frac := math.Modf(v)
+					tick.SetTime(time.Unix(int64(sec), int64(frac*float64(time.Second))))
 				} else {
 					tick.SetField(k, v)
 				}
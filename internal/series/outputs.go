@@ -1,13 +1,16 @@
 package series
 
 import (
+	"bufio"
 	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/rodaine/table"
@@ -53,7 +56,7 @@ func (s *Series) columns() (columns map[any][]any) {
 
 	for _, tick := range s.Ticks() {
 		// Add timestamp
-		columns["timestamp"] = append(columns["timestamp"], tick.Time().Unix())
+		columns["timestamp"] = append(columns["timestamp"], tick.Time().UTC().Format(time.RFC3339Nano))
 
 		// Add fields
 		for name, value := range tick.Fields() {
@@ -206,10 +209,33 @@ func (s *Series) SaveCSV(path string) error {
 
 // SaveJSON series collection to a JSON file.
 func (s *Series) SaveJSON(path string) error {
-	return errors.New("JSON format not implemented yet")
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	if err := json.NewEncoder(writer).Encode(s); err != nil {
+		return err
+	}
+	return writer.Flush()
 }
 
-// SaveJSONL series collection to a JSONL file.
+// SaveJSONL series collection to a JSONL file, one tick per line.
 func (s *Series) SaveJSONL(path string) error {
-	return errors.New("JSONL format not implemented yet")
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, tick := range s.Ticks() {
+		if err := encoder.Encode(tick); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"time"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
@@ -64,7 +65,7 @@ func (p *Plot) getPoints(field string) plotter.XYs {
 	pts := make(plotter.XYs, p.series.Len())
 	for i, tick := range p.series.Ticks() {
 		if tick.HasField(field) && !tick.IsEmpty() {
-			pts[i].X = float64(tick.Epock())
+			pts[i].X = float64(tick.UnixNano()) / float64(time.Second)
 			pts[i].Y = tick.GetField(field)
 		}
 	}
@@ -0,0 +1,105 @@
+package series
+
+import (
+	"math"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+// Empty decides what happens to buckets without ticks.
+type Empty int
+
+const (
+	SkipEmpty        Empty = iota // drop empty buckets
+	ForwardFillEmpty              // carry the last values forward, sums and counts are zero
+	NaNEmpty                      // emit NaN values, sums and counts are zero
+)
+
+// Label decides which edge of the bucket stamps the bar.
+type Label int
+
+const (
+	LabelLeft  Label = iota // the bucket start
+	LabelRight              // the bucket end
+)
+
+type resampler struct {
+	empty Empty
+	label Label
+}
+
+type ResampleOptions func(*resampler)
+
+// WithEmpty sets how empty buckets are handled.
+func WithEmpty(empty Empty) ResampleOptions {
+	return func(r *resampler) { r.empty = empty }
+}
+
+// WithLabel sets which edge of the bucket stamps the bar.
+func WithLabel(label Label) ResampleOptions {
+	return func(r *resampler) { r.label = label }
+}
+
+// Resample buckets the ticks into windows of the given duration, aligned the
+// same way as tick.SetDuration, and reduces each bucket with the aggregations.
+// Without aggregations the last value of every field is kept.
+func (s *Series) Resample(duration time.Duration, aggregations []Aggregation, opts ...ResampleOptions) *Series {
+	r := &resampler{}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	output := s.Spawn()
+	if s.IsEmpty() || duration <= 0 {
+		return output
+	}
+
+	if len(aggregations) == 0 {
+		for _, name := range s.FieldNames() {
+			aggregations = append(aggregations, Agg(name, LAST, name))
+		}
+	}
+
+	stamp := func(start time.Time) time.Time {
+		if r.label == LabelRight {
+			return start.Add(duration)
+		}
+		return start
+	}
+
+	bucket := NewBucket(aggregations...)
+	start := time.Unix(0, s.Times()[0]).Truncate(duration)
+	ticks := make([]*tick.Tick, 0, s.Len())
+
+	for _, t := range s.Ticks() {
+		ts := t.Time().Truncate(duration)
+		if ts.After(start) {
+			ticks = append(ticks, bucket.Tick(stamp(start), duration))
+			bucket.Reset()
+
+			// fill the gap between the previous and the current bucket
+			for gap := start.Add(duration); gap.Before(ts) && r.empty != SkipEmpty; gap = gap.Add(duration) {
+				fields := bucket.Filled()
+				if r.empty == NaNEmpty {
+					nanFields(fields, aggregations)
+				}
+				ticks = append(ticks, bar(stamp(gap), duration, fields, bucket.tags))
+			}
+			start = ts
+		}
+		bucket.Add(t)
+	}
+	ticks = append(ticks, bucket.Tick(stamp(start), duration))
+
+	return output.Add(ticks...)
+}
+
+// nanFields sets every non additive aggregation to NaN.
+func nanFields(fields map[string]float64, aggregations []Aggregation) {
+	for _, agg := range aggregations {
+		if agg.Type != SUM && agg.Type != COUNT {
+			fields[agg.Output] = math.NaN()
+		}
+	}
+}
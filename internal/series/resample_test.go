@@ -0,0 +1,73 @@
+package series
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+func trades(base time.Time, rows ...[3]float64) *Series {
+	s := New("trades")
+	for _, row := range rows {
+		s.Add(tick.New(
+			tick.WithTime(base.Add(time.Duration(row[0])*time.Second)),
+			tick.WithFields(map[string]float64{"price": row[1], "volume": row[2]}),
+		))
+	}
+	return s
+}
+
+func TestResampleOHLCV(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := trades(base,
+		[3]float64{5, 10, 1},
+		[3]float64{20, 12, 2},
+		[3]float64{40, 9, 1},
+		[3]float64{59, 11, 4},
+		[3]float64{185, 15, 2},
+	)
+
+	aggs := append(OHLCV("price", "volume"), VWAPAgg("vwap", "price", "volume"), Agg("trades", COUNT, "price"))
+	bars := s.Resample(time.Minute, aggs)
+	if bars.Len() != 2 {
+		t.Fatalf("bars = %d, want 2", bars.Len())
+	}
+
+	first := bars.At(0)
+	want := map[string]float64{"open": 10, "high": 12, "low": 9, "close": 11, "volume": 8, "trades": 4, "vwap": (10 + 24 + 9 + 44) / 8.0}
+	for k, v := range want {
+		if got := first.GetField(k); got != v {
+			t.Errorf("%s = %v, want %v", k, got, v)
+		}
+	}
+	if !first.Time().Equal(base) || first.Duration() != time.Minute {
+		t.Errorf("bar time = %v, duration = %v", first.Time(), first.Duration())
+	}
+	if !bars.At(1).Time().Equal(base.Add(3 * time.Minute)) {
+		t.Errorf("second bar time = %v", bars.At(1).Time())
+	}
+}
+
+func TestResampleEmptyBuckets(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := trades(base, [3]float64{0, 10, 1}, [3]float64{150, 12, 2})
+
+	ffill := s.Resample(time.Minute, OHLCV("price", "volume"), WithEmpty(ForwardFillEmpty), WithLabel(LabelRight))
+	if ffill.Len() != 3 {
+		t.Fatalf("bars = %d, want 3", ffill.Len())
+	}
+	gap := ffill.At(1)
+	if gap.GetField("open") != 10 || gap.GetField("close") != 10 || gap.GetField("volume") != 0 {
+		t.Errorf("forward filled bar = %v", gap.Fields())
+	}
+	if !gap.Time().Equal(base.Add(2 * time.Minute)) {
+		t.Errorf("right labelled time = %v", gap.Time())
+	}
+
+	nan := s.Resample(time.Minute, OHLCV("price", "volume"), WithEmpty(NaNEmpty))
+	if gap := nan.At(1); !math.IsNaN(gap.GetField("close")) || gap.GetField("volume") != 0 {
+		t.Errorf("NaN bar = %v", gap.Fields())
+	}
+}
@@ -0,0 +1,425 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+	"time"
+)
+
+// Rolling computes statistics over a moving window of ticks, either the last
+// n ticks or the ticks within a time duration, and writes each result back
+// into the series as a new field. Sums and moments are updated in constant
+// time per step, min and max in amortized constant time, and the median and
+// quantiles in logarithmic time with an order statistic tree. NaN values are
+// left out of the window and results with fewer than MinPeriods values are NaN.
+type Rolling struct {
+	series     *Series
+	window     int
+	duration   time.Duration
+	minPeriods int
+}
+
+// Rolling returns a window over the last n ticks. It panics if n is not positive.
+func (s *Series) Rolling(n int) *Rolling {
+	if n <= 0 {
+		panic("rolling window must be positive")
+	}
+	return &Rolling{series: s, window: n, minPeriods: n}
+}
+
+// RollingTime returns a window over the ticks within the duration, (t-d, t].
+// It panics if the duration is not positive.
+func (s *Series) RollingTime(duration time.Duration) *Rolling {
+	if duration <= 0 {
+		panic("rolling duration must be positive")
+	}
+	return &Rolling{series: s, duration: duration, minPeriods: 1}
+}
+
+// MinPeriods sets the number of values required for a result.
+func (r *Rolling) MinPeriods(n int) *Rolling {
+	r.minPeriods = n
+	return r
+}
+
+// Sum writes the rolling sum of the field.
+func (r *Rolling) Sum(field string, output ...string) *Series {
+	return r.apply(name(output, field, "sum"), field, "", 0, func(w *window, _ float64) float64 {
+		return w.sum()
+	})
+}
+
+// Mean writes the rolling mean of the field.
+func (r *Rolling) Mean(field string, output ...string) *Series {
+	return r.apply(name(output, field, "mean"), field, "", 0, func(w *window, _ float64) float64 {
+		return w.mean()
+	})
+}
+
+// Var writes the rolling sample variance of the field.
+func (r *Rolling) Var(field string, output ...string) *Series {
+	return r.apply(name(output, field, "var"), field, "", 0, func(w *window, _ float64) float64 {
+		return w.variance()
+	})
+}
+
+// Std writes the rolling sample standard deviation of the field.
+func (r *Rolling) Std(field string, output ...string) *Series {
+	return r.apply(name(output, field, "std"), field, "", 0, func(w *window, _ float64) float64 {
+		return math.Sqrt(w.variance())
+	})
+}
+
+// ZScore writes how many rolling standard deviations the value is from the rolling mean.
+func (r *Rolling) ZScore(field string, output ...string) *Series {
+	return r.apply(name(output, field, "zscore"), field, "", 0, func(w *window, x float64) float64 {
+		return (x - w.mean()) / math.Sqrt(w.variance())
+	})
+}
+
+// Skew writes the rolling sample skewness of the field.
+func (r *Rolling) Skew(field string, output ...string) *Series {
+	return r.apply(name(output, field, "skew"), field, "", 0, func(w *window, _ float64) float64 {
+		return w.skew()
+	})
+}
+
+// Kurtosis writes the rolling sample excess kurtosis of the field.
+func (r *Rolling) Kurtosis(field string, output ...string) *Series {
+	return r.apply(name(output, field, "kurtosis"), field, "", 0, func(w *window, _ float64) float64 {
+		return w.kurtosis()
+	})
+}
+
+// Min writes the rolling minimum of the field.
+func (r *Rolling) Min(field string, output ...string) *Series {
+	return r.apply(name(output, field, "min"), field, "", needOrder, func(w *window, _ float64) float64 {
+		return w.min()
+	})
+}
+
+// Max writes the rolling maximum of the field.
+func (r *Rolling) Max(field string, output ...string) *Series {
+	return r.apply(name(output, field, "max"), field, "", needOrder, func(w *window, _ float64) float64 {
+		return w.max()
+	})
+}
+
+// Median writes the rolling median of the field.
+func (r *Rolling) Median(field string, output ...string) *Series {
+	return r.apply(name(output, field, "median"), field, "", needSorted, func(w *window, _ float64) float64 {
+		return w.quantile(0.5)
+	})
+}
+
+// Quantile writes the rolling p quantile of the field, linearly interpolated.
+func (r *Rolling) Quantile(field string, p float64, output ...string) *Series {
+	return r.apply(name(output, field, fmt.Sprintf("q%g", p*100)), field, "", needSorted, func(w *window, _ float64) float64 {
+		return w.quantile(p)
+	})
+}
+
+// Cov writes the rolling sample covariance of two fields.
+func (r *Rolling) Cov(x, y string, output ...string) *Series {
+	return r.apply(name(output, x+"_"+y, "cov"), x, y, 0, func(w *window, _ float64) float64 {
+		return w.cov()
+	})
+}
+
+// Corr writes the rolling Pearson correlation of two fields.
+func (r *Rolling) Corr(x, y string, output ...string) *Series {
+	return r.apply(name(output, x+"_"+y, "corr"), x, y, 0, func(w *window, _ float64) float64 {
+		return w.corr()
+	})
+}
+
+// name returns the output field name, the given one or field_suffix.
+func name(output []string, field, suffix string) string {
+	if len(output) > 0 && output[0] != "" {
+		return output[0]
+	}
+	return field + "_" + suffix
+}
+
+// apply slides the window over the series and writes fn of each window into the output field.
+func (r *Rolling) apply(output, xField, yField string, need int, fn func(w *window, x float64) float64) *Series {
+	times := r.series.Times()
+	xs := r.series.Field(xField)
+	ys := make([]float64, len(xs))
+	for i := range ys {
+		ys[i] = 1
+	}
+	if yField != "" {
+		copy(ys, r.series.Field(yField))
+		for i := range xs {
+			if math.IsNaN(ys[i]) {
+				xs[i] = math.NaN()
+			} else if math.IsNaN(xs[i]) {
+				ys[i] = math.NaN()
+			}
+		}
+	}
+
+	out := make([]float64, len(xs))
+	w := newWindow(need, xs)
+	lo, removed := 0, 0
+	for i := range xs {
+		w.add(i, xs[i], ys[i])
+
+		if r.duration > 0 {
+			start := times[i] - int64(r.duration)
+			for ; times[lo] <= start; lo++ {
+				w.remove(lo, xs[lo], ys[lo])
+				removed++
+			}
+		} else {
+			for ; i-lo >= r.window; lo++ {
+				w.remove(lo, xs[lo], ys[lo])
+				removed++
+			}
+		}
+		// once a window of values has gone through the sums, they are
+		// recomputed around the current mean so rounding errors do not build up
+		if removed > i-lo {
+			w.anchor(xs[lo:i+1], ys[lo:i+1])
+			removed = 0
+		}
+
+		if w.n < float64(max(r.minPeriods, 1)) {
+			out[i] = math.NaN()
+			continue
+		}
+		out[i] = fn(w, xs[i])
+	}
+	r.series.SetField(output, out)
+	return r.series
+}
+
+const (
+	needOrder  = 1 << iota // min and max deques
+	needSorted             // order statistic tree
+)
+
+// window holds the running state of the values in the window. Moments are
+// kept as power sums of the values shifted by a value close to the mean,
+// which keeps the subtraction of large similar numbers out of the variance.
+// The shift starts at the first value and moves to the mean when the sums
+// are anchored again.
+type window struct {
+	need          int
+	n             float64
+	shift, shiftY float64
+	init          bool
+
+	sx, sxx, sx3, sx4 float64
+	sy, syy, sxy      float64
+
+	mins, maxs []int     // indices of candidate minimums and maximums
+	values     []float64 // values by index for the deques
+	order      *orderTree
+}
+
+func newWindow(need int, values []float64) *window {
+	w := &window{need: need, values: values}
+	if need&needSorted != 0 {
+		w.order = newOrderTree(values)
+	}
+	return w
+}
+
+// anchor recomputes the power sums of the values in the window, shifted by
+// their current mean.
+func (w *window) anchor(xs, ys []float64) {
+	if w.n > 0 {
+		w.shift, w.shiftY = w.mean(), w.shiftY+w.sy/w.n
+	}
+	w.sx, w.sxx, w.sx3, w.sx4 = 0, 0, 0, 0
+	w.sy, w.syy, w.sxy = 0, 0, 0
+	for i, x := range xs {
+		if !math.IsNaN(x) {
+			w.accumulate(x, ys[i], 1)
+		}
+	}
+}
+
+// accumulate adds the shifted powers of the values to the sums with the sign.
+func (w *window) accumulate(x, y, sign float64) {
+	d, y := x-w.shift, y-w.shiftY
+	w.sx += sign * d
+	w.sxx += sign * d * d
+	w.sx3 += sign * d * d * d
+	w.sx4 += sign * d * d * d * d
+	w.sy += sign * y
+	w.syy += sign * y * y
+	w.sxy += sign * d * y
+}
+
+func (w *window) add(i int, x, y float64) {
+	if math.IsNaN(x) {
+		return
+	}
+	if !w.init {
+		w.shift, w.shiftY, w.init = x, y, true
+	}
+
+	w.n++
+	w.accumulate(x, y, 1)
+
+	if w.need&needOrder != 0 {
+		for len(w.mins) > 0 && w.values[w.mins[len(w.mins)-1]] >= x {
+			w.mins = w.mins[:len(w.mins)-1]
+		}
+		w.mins = append(w.mins, i)
+		for len(w.maxs) > 0 && w.values[w.maxs[len(w.maxs)-1]] <= x {
+			w.maxs = w.maxs[:len(w.maxs)-1]
+		}
+		w.maxs = append(w.maxs, i)
+	}
+	if w.need&needSorted != 0 {
+		w.order.update(x, 1)
+	}
+}
+
+func (w *window) remove(i int, x, y float64) {
+	if math.IsNaN(x) {
+		return
+	}
+
+	w.n--
+	w.accumulate(x, y, -1)
+
+	if w.need&needOrder != 0 {
+		if len(w.mins) > 0 && w.mins[0] == i {
+			w.mins = w.mins[1:]
+		}
+		if len(w.maxs) > 0 && w.maxs[0] == i {
+			w.maxs = w.maxs[1:]
+		}
+	}
+	if w.need&needSorted != 0 {
+		w.order.update(x, -1)
+	}
+}
+
+func (w *window) sum() float64 {
+	return w.sx + w.n*w.shift
+}
+
+func (w *window) mean() float64 {
+	return w.shift + w.sx/w.n
+}
+
+// m2 returns the sum of squared deviations from the mean.
+func (w *window) m2() float64 {
+	md := w.sx / w.n
+	return math.Max(w.sxx-w.n*md*md, 0)
+}
+
+func (w *window) variance() float64 {
+	if w.n < 2 {
+		return math.NaN()
+	}
+	return w.m2() / (w.n - 1)
+}
+
+func (w *window) skew() float64 {
+	n := w.n
+	if n < 3 {
+		return math.NaN()
+	}
+	md := w.sx / n
+	m3 := w.sx3 - 3*md*w.sxx + 2*n*md*md*md
+	std := math.Sqrt(w.variance())
+	return m3 / (std * std * std) * n / ((n - 1) * (n - 2))
+}
+
+func (w *window) kurtosis() float64 {
+	n := w.n
+	if n < 4 {
+		return math.NaN()
+	}
+	md := w.sx / n
+	m4 := w.sx4 - 4*md*w.sx3 + 6*md*md*w.sxx - 3*n*md*md*md*md
+	v := w.variance()
+	mul := ((n + 1) / (n - 1)) * (n / (n - 2)) * (1 / (n - 3))
+	offset := 3 * ((n - 1) / (n - 2)) * ((n - 1) / (n - 3))
+	return m4/(v*v)*mul - offset
+}
+
+func (w *window) cov() float64 {
+	if w.n < 2 {
+		return math.NaN()
+	}
+	return (w.sxy - w.sx*w.sy/w.n) / (w.n - 1)
+}
+
+func (w *window) corr() float64 {
+	if w.n < 2 {
+		return math.NaN()
+	}
+	my := w.sy / w.n
+	syy := w.syy - w.n*my*my
+	return (w.sxy - w.sx*w.sy/w.n) / math.Sqrt(w.m2()*syy)
+}
+
+func (w *window) min() float64 {
+	return w.values[w.mins[0]]
+}
+
+func (w *window) max() float64 {
+	return w.values[w.maxs[0]]
+}
+
+func (w *window) quantile(p float64) float64 {
+	pos := p * (w.n - 1)
+	lo := w.order.kth(int(math.Floor(pos)))
+	hi := w.order.kth(int(math.Ceil(pos)))
+	return lo + (hi-lo)*(pos-math.Floor(pos))
+}
+
+// orderTree counts the values in the window by rank among all the values of
+// the series, a Fenwick tree answering the k-th smallest value of the window
+// in logarithmic time.
+type orderTree struct {
+	keys   []float64 // distinct values in increasing order
+	counts []int     // Fenwick tree of the counts by key, 1-based
+}
+
+func newOrderTree(values []float64) *orderTree {
+	keys := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			keys = append(keys, v)
+		}
+	}
+	sort.Float64s(keys)
+	distinct := keys[:0]
+	for i, v := range keys {
+		if i == 0 || v != keys[i-1] {
+			distinct = append(distinct, v)
+		}
+	}
+	return &orderTree{keys: distinct, counts: make([]int, len(distinct)+1)}
+}
+
+// update adds delta to the count of the value.
+func (t *orderTree) update(x float64, delta int) {
+	for i := sort.SearchFloat64s(t.keys, x) + 1; i < len(t.counts); i += i & -i {
+		t.counts[i] += delta
+	}
+}
+
+// kth returns the k-th smallest value in the window, from 0.
+func (t *orderTree) kth(k int) float64 {
+	pos := 0
+	for step := bits.Len(uint(len(t.counts))); step >= 0; step-- {
+		next := pos + 1<<step
+		if next < len(t.counts) && t.counts[next] <= k {
+			pos = next
+			k -= t.counts[next]
+		}
+	}
+	return t.keys[pos]
+}
@@ -0,0 +1,166 @@
+package series
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat"
+)
+
+func randomWalk(n int) *Series {
+	rng := rand.New(rand.NewSource(1))
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New("walk")
+	x, y := 50000.0, 3000.0
+	for i := 0; i < n; i++ {
+		x += rng.NormFloat64() * 10
+		y += rng.NormFloat64() + 0.05*(x-50000)
+		s.Add(tick.New(
+			tick.WithTime(base.Add(time.Duration(i)*time.Second)),
+			tick.WithFields(map[string]float64{"x": x, "y": y}),
+		))
+	}
+	return s
+}
+
+func TestRollingMatchesBruteForce(t *testing.T) {
+	const n, window = 300, 20
+	s := randomWalk(n)
+	s.At(100).SetField("x", math.NaN())
+
+	s.Rolling(window).MinPeriods(5).Mean("x")
+	s.Rolling(window).MinPeriods(5).Std("x")
+	s.Rolling(window).MinPeriods(5).Skew("x")
+	s.Rolling(window).MinPeriods(5).Kurtosis("x")
+	s.Rolling(window).MinPeriods(5).Min("x")
+	s.Rolling(window).MinPeriods(5).Max("x")
+	s.Rolling(window).MinPeriods(5).Median("x")
+	s.Rolling(window).MinPeriods(5).Quantile("x", 0.9)
+	s.Rolling(window).MinPeriods(5).Corr("x", "y")
+	s.Rolling(window).MinPeriods(5).Cov("x", "y")
+
+	for i := 0; i < n; i++ {
+		var xs, ys []float64
+		for j := max(i-window+1, 0); j <= i; j++ {
+			if x := s.At(j).GetField("x"); !math.IsNaN(x) {
+				xs, ys = append(xs, x), append(ys, s.At(j).GetField("y"))
+			}
+		}
+		sorted := append([]float64(nil), xs...)
+		sort.Float64s(sorted)
+
+		want := map[string]float64{
+			"x_mean":     stat.Mean(xs, nil),
+			"x_std":      stat.StdDev(xs, nil),
+			"x_skew":     stat.Skew(xs, nil),
+			"x_kurtosis": stat.ExKurtosis(xs, nil),
+			"x_min":      floats.Min(xs),
+			"x_max":      floats.Max(xs),
+			"x_median":   interpolated(sorted, 0.5),
+			"x_q90":      interpolated(sorted, 0.9),
+			"x_y_corr":   stat.Correlation(xs, ys, nil),
+			"x_y_cov":    stat.Covariance(xs, ys, nil),
+		}
+		for field, w := range want {
+			got := s.At(i).GetField(field)
+			if len(xs) < 5 {
+				if !math.IsNaN(got) {
+					t.Errorf("%s[%d] = %v, want NaN before min periods", field, i, got)
+				}
+				continue
+			}
+			if math.Abs(got-w) > 1e-6*math.Max(1, math.Abs(w)) {
+				t.Errorf("%s[%d] = %v, want %v", field, i, got, w)
+			}
+		}
+	}
+}
+
+func TestRollingTime(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New("time")
+	for i, offset := range []time.Duration{0, time.Second, 1500 * time.Millisecond, 4 * time.Second} {
+		s.Add(tick.New(tick.WithTime(base.Add(offset)), tick.WithFields(map[string]float64{"v": float64(i + 1)})))
+	}
+
+	s.RollingTime(2*time.Second).Sum("v", "sum2s")
+	want := []float64{1, 3, 6, 4}
+	for i, w := range want {
+		if got := s.At(i).GetField("sum2s"); got != w {
+			t.Errorf("sum2s[%d] = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestRollingLongSeries(t *testing.T) {
+	// a long walk around a large level with jumps, where sums shifted by the
+	// first value alone lose the variance to rounding
+	const n, window = 20000, 50
+	rng := rand.New(rand.NewSource(8))
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New("long")
+	x := 1e6
+	for i := 0; i < n; i++ {
+		if x += rng.NormFloat64() * 0.01; i%5000 == 4999 {
+			x += 1e5
+		}
+		s.Add(tick.New(tick.WithTime(base.Add(time.Duration(i)*time.Second)), tick.WithFields(map[string]float64{"x": x})))
+	}
+
+	s.Rolling(window).Std("x")
+	s.Rolling(window).Skew("x")
+	s.Rolling(window).Median("x")
+	s.Rolling(window).Quantile("x", 0.25)
+
+	xs := s.Field("x")
+	for i := window - 1; i < n; i += 7 {
+		values := xs[i-window+1 : i+1]
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		want := map[string]float64{
+			"x_std":    stat.StdDev(values, nil),
+			"x_skew":   stat.Skew(values, nil),
+			"x_median": interpolated(sorted, 0.5),
+			"x_q25":    interpolated(sorted, 0.25),
+		}
+		for field, w := range want {
+			if got := s.At(i).GetField(field); math.Abs(got-w) > 1e-6*math.Max(1, math.Abs(w)) {
+				t.Errorf("%s[%d] = %v, want %v", field, i, got, w)
+			}
+		}
+	}
+}
+
+func TestRollingInvalidWindow(t *testing.T) {
+	for name, roll := range map[string]func(){
+		"count": func() { randomWalk(5).Rolling(0) },
+		"time":  func() { randomWalk(5).RollingTime(-time.Second) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s window not positive did not panic", name)
+				}
+			}()
+			roll()
+		}()
+	}
+}
+
+// interpolated returns the p quantile interpolated between the closest ranks.
+func interpolated(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := p * float64(len(sorted)-1)
+	lo := int(pos)
+	if lo == len(sorted)-1 {
+		return sorted[lo]
+	}
+	return sorted[lo] + (sorted[lo+1]-sorted[lo])*(pos-float64(lo))
+}
@@ -1,6 +1,7 @@
 package series
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -8,20 +9,28 @@ import (
 )
 
 // Series represents a collection of market events, capturing the most granular form of market data.
+// The ticks are stored by column, a timestamp column and one contiguous slice
+// per field, and the Tick API is an adapter over them: the ticks of a series
+// are views of its rows, so writing to a tick writes to the series. Slice,
+// Head, Tail, Moving and Copy return views sharing the rows: a field set
+// through a view, new or not, is set on the rows of the series it views.
 type Series struct {
-	name  string
-	ticks []*tick.Tick
+	name string
+	cols *columns
 
 	// metadata
 	tags map[string]string
+
+	// statistics
+	nan NaNPolicy
 }
 
 // NewSeries creates a new Series of ticks
 func New(name string, opts ...SeriesOptions) (s *Series) {
 	s = &Series{
-		name:  name,
-		ticks: make([]*tick.Tick, 0),
-		tags:  make(map[string]string),
+		name: name,
+		cols: newColumns(),
+		tags: make(map[string]string),
 	}
 
 	for _, opt := range opts {
@@ -44,124 +53,155 @@ func (s *Series) SetName(name string) {
 
 // Duration returns the duration of the Series collection.
 func (s *Series) Duration() time.Duration {
-	if len(s.ticks) == 0 {
+	if s.Len() == 0 {
 		return 0
 	}
-	return s.ticks[len(s.ticks)-1].Duration()
+	return s.cols.durations[s.Len()-1]
 }
 
 // SetDuration sets the duration of the Series collection.
 func (s *Series) SetDuration(duration time.Duration) {
-	// s.duration = duration
-	for _, tick := range s.ticks {
-		tick.SetDuration(duration)
+	for i := range s.cols.times {
+		s.cols.durations[i] = duration
+		if duration > 0 {
+			s.cols.times[i] = time.Unix(0, s.cols.times[i]).Truncate(duration).UnixNano()
+		}
 	}
 }
 
 // Timestamps returns the timestamps of the Series collection.
 func (s *Series) Timestamp() time.Time {
-	if len(s.ticks) == 0 {
+	if s.Len() == 0 {
 		return time.Time{}
 	}
-	return s.ticks[len(s.ticks)-1].Time()
+	return time.Unix(0, s.cols.times[s.Len()-1])
 }
 
 func (s *Series) TimeRange() (time.Time, time.Time) {
-	if len(s.ticks) == 0 {
+	if s.Len() == 0 {
 		return time.Time{}, time.Time{}
 	}
-	return s.ticks[0].Time(), s.ticks[len(s.ticks)-1].Time()
+	return time.Unix(0, s.cols.times[0]), time.Unix(0, s.cols.times[s.Len()-1])
 }
 
 func (s *Series) Timestamps() (out []time.Time) {
-	for _, tick := range s.ticks {
-		out = append(out, tick.Time())
+	for _, nsec := range s.cols.times {
+		out = append(out, time.Unix(0, nsec))
 	}
 	return out
 }
 
+// Times returns a copy of the timestamp column in nanoseconds since the Unix epoch.
+func (s *Series) Times() []int64 {
+	return slices.Clone(s.cols.times)
+}
+
 // Reset the ticks only to nil
 func (s *Series) Reset() *Series {
-	s.ticks = make([]*tick.Tick, 0)
+	s.cols.truncate(0)
+	s.cols = newColumns()
 	return s
 }
 
 // Set the ticks
 func (s *Series) Set(ticks ...*tick.Tick) {
-	s.ticks = Sort(ticks)
+	ticks = Sort(append([]*tick.Tick(nil), ticks...))
+	for _, t := range ticks {
+		// rows of the series are read before the series is cleared
+		if c, _ := t.Columns(); c == s.cols {
+			t.Unbind()
+		}
+	}
+	s.Reset()
+	for _, t := range ticks {
+		s.cols.add(t)
+	}
 	s.update()
 }
 
-// Append adds one or more ticks to the end of the collection.
+// Append adds one or more ticks to the end of the collection. The ticks are
+// only re-sorted when the new ones are not already in time order.
 func (s *Series) Add(ticks ...*tick.Tick) *Series {
-	s.ticks = append(s.ticks, ticks...)
-	s.ticks = Sort(s.ticks)
+	n := s.Len()
+	for _, t := range ticks {
+		s.cols.add(t)
+	}
+	if !s.cols.sorted(n) {
+		s.cols.sort()
+	}
+	s.update()
+	return s
+}
+
+// Append adds a row of fields and tags at the time without creating a tick.
+// Rows are re-sorted when the time is before the last one.
+func (s *Series) Append(timestamp time.Time, fields map[string]float64, tags map[string]string) *Series {
+	n := s.Len()
+	s.cols.append(timestamp.UnixNano(), 0, fields, tags)
+	if !s.cols.sorted(n) {
+		s.cols.sort()
+	}
 	s.update()
 	return s
 }
 
-// Update updates the series with the given ticks.
+// Update updates the ticks of the series with the ticks of the given series at the same time.
 func (s *Series) Update(seriesInputs ...*Series) *Series {
+	index := make(map[int64]int, s.Len())
+	for row, nsec := range s.cols.times {
+		index[nsec] = row
+	}
+
 	for _, seriesInput := range seriesInputs {
 		for _, tickInput := range seriesInput.Ticks() {
-			for i, tick := range s.ticks {
-				if tick.Time().Equal(tickInput.Time()) {
-					s.ticks[i].Update(tickInput)
-				}
+			if row, ok := index[tickInput.UnixNano()]; ok {
+				s.At(row).Update(tickInput)
 			}
 		}
 	}
 
-	s.ticks = Sort(s.ticks)
+	s.cols.sort()
 	s.update()
 	return s
 }
 
 // Len returns the number of ticks in the collection.
 func (s *Series) Len() int {
-	return len(s.ticks)
+	return s.cols.len()
 }
 
 // IsEmpty returns true if the series is empty.
 func (s *Series) IsEmpty() bool {
-	return len(s.ticks) == 0
+	return s.Len() == 0
 }
 
-// Head returns the first n ticks.
+// Head returns a view of the first n ticks.
 func (s *Series) Head(n int) *Series {
-	return &Series{ticks: s.ticks[n:], name: s.name, tags: s.tags}
+	return s.Slice(0, max(min(n, s.Len()), 0))
 }
 
-// Tail returns the last n ticks.
+// Tail returns a view of the last n ticks.
 func (s *Series) Tail(n int) *Series {
-	return &Series{ticks: s.ticks[:n], name: s.name, tags: s.tags}
+	return s.Slice(min(max(s.Len()-n, 0), s.Len()), s.Len())
 }
 
-// Range returns a new Series collection containing ticks between start and end indices.
+// Slice returns a view of the ticks between the start and end indices.
 func (s *Series) Slice(start, end int) *Series {
-	return &Series{name: s.name, ticks: s.ticks[start:end], tags: s.tags}
+	return &Series{name: s.name, cols: s.cols.slice(start, end), tags: s.tags, nan: s.nan}
 }
 
-// Series returns the ticks of the Series collection.
+// Ticks returns the ticks of the series, each a view of its row.
 func (s *Series) Ticks() []*tick.Tick {
-	if len(s.ticks) == 0 {
-		return []*tick.Tick{}
+	ticks := make([]*tick.Tick, s.Len())
+	for i := range ticks {
+		ticks[i] = s.cols.view(i)
 	}
-	return s.ticks
+	return ticks
 }
 
-// Copy returns a new Series collection with the same ticks.
+// Copy returns a view of the series sharing its ticks, with the options applied.
 func (s *Series) Copy(opts ...SeriesOptions) *Series {
-	series := &Series{
-		name:  s.name,
-		ticks: s.ticks,
-		// meta:         s.meta,
-		tags: s.tags,
-		// fields:       s.fields,
-		// duration:     s.duration,
-		// timestamp:    s.timestamp,
-		// defaultField: s.defaultField,
-	}
+	series := s.Slice(0, s.Len())
 
 	for _, opt := range opts {
 		opt(series)
@@ -172,13 +212,15 @@ func (s *Series) Copy(opts ...SeriesOptions) *Series {
 
 // At returns the tick at the specified index.
 func (s *Series) At(index int) *tick.Tick {
-	return s.ticks[index]
+	return s.cols.view(index)
 }
 
+// AtTime returns the tick recorded at the given time, to the nanosecond.
 func (s *Series) AtTime(timestamp time.Time) *tick.Tick {
-	for _, tick := range s.ticks {
-		if tick.Time().Equal(timestamp) {
-			return tick
+	nsec := timestamp.UnixNano()
+	for row, t := range s.cols.times {
+		if t == nsec {
+			return s.At(row)
 		}
 	}
 	return nil
@@ -187,13 +229,24 @@ func (s *Series) AtTime(timestamp time.Time) *tick.Tick {
 // Pop returns the last tick and removes it from the Series collection.
 func (s *Series) Pop() *tick.Tick {
 	last := s.At(s.Len() - 1)
-	s.ticks = s.ticks[:s.Len()-1]
+	s.cols.truncate(s.Len() - 1)
 	return last
 }
 
 // Push adds a tick to the beginning of the Series collection.
 func (s *Series) Push(ticks ...*tick.Tick) {
-	s.ticks = append(ticks, s.ticks...)
+	n := s.Len()
+	for _, t := range ticks {
+		s.cols.add(t)
+	}
+	rows := make([]int, 0, s.Len())
+	for row := n; row < s.Len(); row++ {
+		rows = append(rows, row)
+	}
+	for row := range n {
+		rows = append(rows, row)
+	}
+	s.cols.take(rows)
 }
 
 // Shift removes the first tick and returns it.
@@ -204,40 +257,41 @@ func (s *Series) Shift(tick *tick.Tick) *tick.Tick {
 
 // Apply applies the given options to each tick in the series.
 func (s *Series) Apply(opts ...tick.TickOptions) {
-	for _, tick := range s.ticks {
+	for _, tick := range s.Ticks() {
 		for _, opt := range opts {
 			opt(tick)
 		}
 	}
-	s.ticks = Sort(s.ticks)
+	s.cols.sort()
 }
 
+// Moving returns overlapping views of the given window size between start and
+// stop. A negative stop counts from the end of the series.
 func (s *Series) Moving(start, stop, window int) (series []*Series) {
 	if stop < 0 {
-		stop = s.Len() - 1 + stop
+		stop = s.Len() + stop + 1
 	}
+	stop = min(stop, s.Len())
 
-	for i := start; i < stop-window; i++ {
+	for i := start; i+window <= stop; i++ {
 		series = append(series, s.Slice(i, i+window))
 	}
 	return series
 }
 
 func (s *Series) update() {
-	// s.timestamp = tick.Timestamp()
-	// s.duration = tick.Duration()
-
 	// update the tags with common tags from the ticks
-	if len(s.ticks) > 0 {
-		s.tags = s.ticks[len(s.ticks)-1].Tags()
+	if s.Len() > 0 {
+		s.tags = s.cols.Tags(s.Len() - 1)
 	}
 }
 
 func (s *Series) Spawn(opts ...SeriesOptions) *Series {
 	series := &Series{
-		name:  s.name,
-		ticks: make([]*tick.Tick, 0),
-		tags:  s.tags,
+		name: s.name,
+		cols: newColumns(),
+		tags: s.tags,
+		nan:  s.nan,
 	}
 
 	for _, opt := range opts {
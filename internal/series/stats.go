@@ -1,6 +1,10 @@
 package series
 
 import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/floats"
 	"gonum.org/v1/gonum/mat"
 	"gonum.org/v1/gonum/stat"
 )
@@ -8,7 +12,7 @@ import (
 // Statistical methods
 
 // Vec creates a dense vector from the specified field values.
-// Returns a VecDense containing the field values in chronological order.
+// Returns a VecDense backed by the field column, without copying it.
 func (s *Series) Vec(field string) *mat.VecDense {
 	return mat.NewVecDense(s.Len(), s.Field(field))
 }
@@ -25,69 +29,192 @@ func (s *Series) VecMap(fields ...string) map[string]*mat.VecDense {
 	return vecs
 }
 
+// NaNPolicy decides how the statistical methods treat NaN values.
+type NaNPolicy int
+
+const (
+	PropagateNaN NaNPolicy = iota // any NaN value makes the result NaN
+	SkipNaN                       // NaN values are ignored
+)
+
+// values returns the field values under the NaN policy of the series,
+// leaving out the ticks without the field. The result is the column itself
+// when nothing is left out, and nil when a NaN must be propagated.
+func (s *Series) values(field string) []float64 {
+	values, _ := s.weighted(field, nil)
+	return values
+}
+
+// weighted returns the field values like values, with the weights of the
+// ticks kept. The weights are given by tick and returned unchanged when no
+// tick is left out, nil weights stay nil.
+func (s *Series) weighted(field string, weights []float64) ([]float64, []float64) {
+	if weights != nil && len(weights) != s.Len() {
+		panic("weights length does not match series length")
+	}
+	col, ok := s.cols.fields[field]
+	if !ok {
+		return []float64{}, nil
+	}
+
+	skip := 0
+	for i, v := range col.values {
+		if !col.set[i] {
+			skip++
+		} else if math.IsNaN(v) {
+			if s.nan == PropagateNaN {
+				return nil, nil
+			}
+			skip++
+		}
+	}
+	if skip == 0 {
+		return col.values, weights
+	}
+
+	values := make([]float64, 0, len(col.values)-skip)
+	var kept []float64
+	if weights != nil {
+		kept = make([]float64, 0, len(col.values)-skip)
+	}
+	for i, v := range col.values {
+		if col.set[i] && !math.IsNaN(v) {
+			values = append(values, v)
+			if weights != nil {
+				kept = append(kept, weights[i])
+			}
+		}
+	}
+	return values, kept
+}
+
 // Mode calculates the most common value of the specified field across all ticks.
+// The weights, if any, are given by tick.
 func (s *Series) Mode(field string, weights []float64) (val float64, count float64) {
-	return stat.Mode(s.Field(field), weights)
+	values, weights := s.weighted(field, weights)
+	if len(values) == 0 {
+		return math.NaN(), 0
+	}
+	return stat.Mode(values, weights)
 }
 
 // Mean calculates the arithmetic mean of the specified field across all ticks.
 func (s *Series) Mean(field string) (output float64) {
-	return mat.Sum(s.Vec(field)) / float64(s.Len())
+	values := s.values(field)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return floats.Sum(values) / float64(len(values))
 }
 
 // Median calculates the median value of the specified field across all ticks.
 func (s *Series) Median(field string) (output float64) {
-	return stat.Quantile(0.5, stat.Empirical, s.Field(field), nil)
+	return s.Quantile(field)(0.5, stat.Empirical, nil)
 }
 
 // Range returns the difference between the maximum and minimum values
 func (s *Series) Range(field string) (output float64) {
-	return mat.Max(s.Vec(field)) - mat.Min(s.Vec(field))
+	return s.Max(field) - s.Min(field)
 }
 
 // Sum calculates the total sum of the specified field across all ticks.
 func (s *Series) Sum(field string) (output float64) {
-	return mat.Sum(s.Vec(field))
+	values := s.values(field)
+	if values == nil {
+		return math.NaN()
+	}
+	return floats.Sum(values)
 }
 
 // Min returns the minimum value of the specified field across all ticks.
 func (s *Series) Min(field string) (output float64) {
-	return mat.Min(s.Vec(field))
+	values := s.values(field)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return floats.Min(values)
 }
 
 // Max returns the maximum value of the specified field across all ticks.
 func (s *Series) Max(field string) (output float64) {
-	return mat.Max(s.Vec(field))
+	values := s.values(field)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return floats.Max(values)
 }
 
-// First returns the first value of the specified field.
+// First returns the first value of the specified field, the first non NaN value when skipping NaN.
 func (s *Series) First(field string) (output float64) {
-	return s.Vec(field).At(0, 0)
+	col, ok := s.cols.fields[field]
+	if !ok {
+		return math.NaN()
+	}
+	for i, v := range col.values {
+		if col.set[i] && (s.nan == PropagateNaN || !math.IsNaN(v)) {
+			return v
+		}
+	}
+	return math.NaN()
 }
 
-// Last returns the last value of the specified field.
+// Last returns the last value of the specified field, the last non NaN value when skipping NaN.
 func (s *Series) Last(field string) (output float64) {
-	return s.Vec(field).At(s.Vec(field).Len()-1, 0)
+	col, ok := s.cols.fields[field]
+	if !ok {
+		return math.NaN()
+	}
+	for i := len(col.values) - 1; i >= 0; i-- {
+		if v := col.values[i]; col.set[i] && (s.nan == PropagateNaN || !math.IsNaN(v)) {
+			return v
+		}
+	}
+	return math.NaN()
 }
 
 // Std calculates the standard deviation of the specified field across all ticks.
 func (s *Series) Std(field string) (output float64) {
-	return stat.StdDev(s.Field(field), nil)
+	values := s.values(field)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return stat.StdDev(values, nil)
 }
 
 // Var calculates the variance of the specified field across all ticks.
 func (s *Series) Var(field string) (output float64) {
-	return stat.Variance(s.Field(field), nil)
+	values := s.values(field)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return stat.Variance(values, nil)
 }
 
 // Norm calculates the L1 norm (sum of absolute values) of the specified field across all ticks.
 func (s *Series) Norm(field string) (output float64) {
-	return mat.Norm(s.Vec(field), 1)
+	values := s.values(field)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return floats.Norm(values, 1)
 }
 
 // Quantile calculates the quantile value of the specified field across all ticks.
+// The weights, if any, are given by tick.
 func (s *Series) Quantile(field string) func(p float64, c stat.CumulantKind, weights []float64) float64 {
 	return func(p float64, c stat.CumulantKind, weights []float64) float64 {
-		return stat.Quantile(p, c, s.Field(field), weights)
+		values, weights := s.weighted(field, weights)
+		if len(values) == 0 {
+			return math.NaN()
+		}
+		// sorting must not reorder the column of the series
+		values = append([]float64(nil), values...)
+		if weights == nil {
+			sort.Float64s(values)
+		} else {
+			weights = append([]float64(nil), weights...)
+			stat.SortWeighted(values, weights)
+		}
+		return stat.Quantile(p, c, values, weights)
 	}
 }
@@ -6,7 +6,7 @@ import "github.com/rangertaha/gotal/internal/pkg/sig"
 
 // Tags returns the tags of the Series collection.
 func (t *Series) Tags() map[string]string {
-	t.tags = t.ticks[len(t.ticks)-1].Tags()
+	t.update()
 	return t.tags
 }
 
@@ -15,7 +15,7 @@ func (t *Series) SetTag(name, value string, index ...int) {
 		t.tags[name] = value
 	}
 	for _, idx := range index {
-		t.ticks[idx].SetTag(name, value)
+		t.cols.Tags(idx)[name] = value
 	}
 
 }
@@ -55,5 +55,5 @@ func (t *Series) HasTag(name string) bool {
 // }
 
 func (t *Series) Signals() map[sig.Signal]sig.Strength {
-	return t.ticks[len(t.ticks)-1].Signals()
+	return t.cols.Signals(t.Len() - 1)
 }
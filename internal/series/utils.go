@@ -8,20 +8,17 @@ import (
 	"github.com/rangertaha/gotal/internal/pkg/tick"
 )
 
+// Sort orders the ticks by time, keeping the order of equal timestamps.
 func Sort(ticks []*tick.Tick) []*tick.Tick {
-	sort.Slice(ticks, func(i, j int) bool {
-		return ticks[i].Time().Before(ticks[j].Time())
+	sort.SliceStable(ticks, func(i, j int) bool {
+		return ticks[i].UnixNano() < ticks[j].UnixNano()
 	})
 	return ticks
 }
 
 func Random(name string, start, end time.Time, duration time.Duration, fields []string, opts ...SeriesOptions) (s *Series) {
 
-	s = &Series{
-		name:  name,
-		ticks: make([]*tick.Tick, 0),
-		tags:  make(map[string]string),
-	}
+	s = New(name)
 
 	// Generate random ticks between start and end time at given duration intervals
 	for t := start; t.Before(end); t = t.Add(duration) {
@@ -41,14 +38,9 @@ func Random(name string, start, end time.Time, duration time.Duration, fields []
 		tick.SetFields(values)
 
 		// Add tick to series
-		s.ticks = append(s.ticks, tick)
+		s.Add(tick)
 	}
 
-	// Sort ticks by timestamp
-	sort.Slice(s.ticks, func(i, j int) bool {
-		return s.ticks[i].Time().Before(s.ticks[j].Time())
-	})
-
 	for _, opt := range opts {
 		opt(s)
 	}
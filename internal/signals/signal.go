@@ -1,5 +1,7 @@
 package sig
 
+import "fmt"
+
 type (
 	Signal   int
 	Strength int
@@ -27,25 +29,51 @@ const (
 	CROSSUNDER
 )
 
+var names = []string{
+	// Actions
+	"BUY",
+	"SELL",
+	"HOLD",
+
+	// Indicators
+	"BULLISH",    // Bullish trend
+	"BEARISH",    // Bearish trend
+	"NEUTRAL",    // Neutral trend
+	"CONVERGING", // Converging: two or more indicators are moving closer together
+	"DIVERGING",  // Diverging: two or more indicators are moving further apart
+	"CROSSOVER",  // Crossover: one indicator crosses over another
+	"CROSSUNDER", // Crossunder: one indicator crosses under another
+
+}
+
 func (s Signal) String() string {
-	return []string{
-		// Actions
-		"BUY",
-		"SELL",
-		"HOLD",
-
-		// Indicators
-		"BULLISH",    // Bullish trend
-		"BEARISH",    // Bearish trend
-		"NEUTRAL",    // Neutral trend
-		"CONVERGING", // Converging: two or more indicators are moving closer together
-		"DIVERGING",  // Diverging: two or more indicators are moving further apart
-		"CROSSOVER",  // Crossover: one indicator crosses over another
-		"CROSSUNDER", // Crossunder: one indicator crosses under another
-
-	}[s]
+	return names[s]
 }
 
 func (s Signal) Int() int {
 	return int(s)
 }
+
+// Parse returns the signal with the given name.
+func Parse(name string) (Signal, error) {
+	for i, n := range names {
+		if n == name {
+			return Signal(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown signal: %q", name)
+}
+
+// MarshalText encodes the signal by name, so signal maps serialize with readable keys.
+func (s Signal) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(names) {
+		return nil, fmt.Errorf("unknown signal: %d", int(s))
+	}
+	return []byte(names[s]), nil
+}
+
+// UnmarshalText decodes a signal from its name.
+func (s *Signal) UnmarshalText(text []byte) (err error) {
+	*s, err = Parse(string(text))
+	return
+}
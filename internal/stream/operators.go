@@ -0,0 +1,146 @@
+package stream
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+// Operators read their input stream in a goroutine and write to new streams
+// that inherit the input context, buffer and policy unless overridden by the
+// options. Closing the input closes the outputs with the input error, and
+// closing an output closes the input so the producer stops.
+
+// Map returns a stream of fn applied to each tick, nil results are dropped.
+func (s *Stream) Map(fn func(*tick.Tick) *tick.Tick, opts ...StreamOptions) *Stream {
+	out := s.derive(opts)
+	go forward(s, func(t *tick.Tick) error {
+		if t = fn(t); t == nil {
+			return nil
+		}
+		return out.Send(t)
+	}, out)
+	return out
+}
+
+// FlatMap returns a stream of the ticks fn returns for each tick, in order.
+func (s *Stream) FlatMap(fn func(*tick.Tick) []*tick.Tick, opts ...StreamOptions) *Stream {
+	out := s.derive(opts)
+	go forward(s, func(t *tick.Tick) error {
+		for _, t := range fn(t) {
+			if err := out.Send(t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, out)
+	return out
+}
+
+// FlatMapFlush is FlatMap followed by the ticks flush returns once the input
+// is closed without an error, such as a bar still in progress.
+func (s *Stream) FlatMapFlush(fn func(*tick.Tick) []*tick.Tick, flush func() []*tick.Tick, opts ...StreamOptions) *Stream {
+	out := s.derive(opts)
+	send := func(ticks []*tick.Tick) error {
+		for _, t := range ticks {
+			if err := out.Send(t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	go func() {
+		forward(s, func(t *tick.Tick) error { return send(fn(t)) })
+		if s.Err() == nil {
+			send(flush())
+		}
+		out.CloseWithError(s.Err())
+	}()
+	return out
+}
+
+// Filter returns a stream of the ticks for which fn is true.
+func (s *Stream) Filter(fn func(*tick.Tick) bool, opts ...StreamOptions) *Stream {
+	out := s.derive(opts)
+	go forward(s, func(t *tick.Tick) error {
+		if !fn(t) {
+			return nil
+		}
+		return out.Send(t)
+	}, out)
+	return out
+}
+
+// Tee returns n streams that each receive every tick. A slow output holds
+// back the others under the Block policy. The input is closed once every
+// output is closed.
+func (s *Stream) Tee(n int, opts ...StreamOptions) []*Stream {
+	outs := make([]*Stream, n)
+	for i := range outs {
+		outs[i] = s.derive(opts)
+	}
+	go forward(s, func(t *tick.Tick) error {
+		var err error
+		open := 0
+		for _, out := range outs {
+			if err = out.Send(t); err == nil {
+				open++
+			}
+		}
+		if open == 0 {
+			return err
+		}
+		return nil
+	}, outs...)
+	return outs
+}
+
+// Merge returns a stream of the ticks of all the streams in arrival order.
+// It is closed with the joined errors of the inputs once they are all closed.
+func Merge(streams []*Stream, opts ...StreamOptions) *Stream {
+	if len(streams) == 0 {
+		out := New("merge", opts...)
+		out.Close()
+		return out
+	}
+
+	out := streams[0].derive(opts)
+	var wg sync.WaitGroup
+	for _, in := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			forward(in, out.Send)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		errs := make([]error, len(streams))
+		for i, in := range streams {
+			errs[i] = in.Err()
+		}
+		out.CloseWithError(errors.Join(errs...))
+	}()
+	return out
+}
+
+// derive creates an output stream with the settings of s.
+func (s *Stream) derive(opts []StreamOptions) *Stream {
+	defaults := []StreamOptions{WithContext(s.ctx), WithBuffer(s.buffer), WithPolicy(s.policy)}
+	return New(s.name, append(defaults, opts...)...)
+}
+
+// forward reads the input into fn until the input is closed or fn fails,
+// then closes the input with the failure and the outputs with the input error.
+func forward(in *Stream, fn func(*tick.Tick) error, outs ...*Stream) {
+	for t := range in.ticks {
+		if err := fn(t); err != nil {
+			in.CloseWithError(err)
+			break
+		}
+	}
+	for _, out := range outs {
+		out.CloseWithError(in.Err())
+	}
+}
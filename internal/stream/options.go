@@ -1,6 +1,8 @@
 package stream
 
 import (
+	"context"
+
 	"github.com/rangertaha/gotal/internal/pkg/tick"
 )
 
@@ -10,6 +12,22 @@ func WithName(name string) StreamOptions {
 	return func(s *Stream) { s.name = name }
 }
 
+// WithContext closes the stream with the context error when it is cancelled.
+func WithContext(ctx context.Context) StreamOptions {
+	return func(s *Stream) { s.ctx = ctx }
+}
+
+// WithBuffer sets the number of ticks buffered between producer and consumer.
+func WithBuffer(size int) StreamOptions {
+	return func(s *Stream) { s.buffer = size }
+}
+
+// WithPolicy sets what happens when the buffer is full, Block by default.
+// The drop policies buffer at least one tick.
+func WithPolicy(policy Policy) StreamOptions {
+	return func(s *Stream) { s.policy = policy }
+}
+
 // func WithMeta(meta map[string]any) SeriesOptions {
 // 	return func(s *Series) { s.meta = meta }
 // }
@@ -28,8 +46,9 @@ func WithName(name string) StreamOptions {
 // 	}
 // }
 
+// WithTicks sends the ticks and closes the stream.
 func WithTicks(ticks ...*tick.Tick) StreamOptions {
-	return func(s *Stream) {  }
+	return func(s *Stream) { s.init = append(make([]*tick.Tick, 0, len(ticks)), ticks...) }
 }
 
 // func From(series *Series) SeriesOptions {
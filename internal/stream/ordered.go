@@ -0,0 +1,221 @@
+package stream
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+type merger struct {
+	lateness time.Duration
+	idle     time.Duration
+	output   []StreamOptions
+	late     []StreamOptions
+}
+
+type MergeOptions func(*merger)
+
+// WithLateness sets how far behind its newest tick a source may still
+// deliver ticks in order. Larger bounds hold ticks back for longer.
+func WithLateness(lateness time.Duration) MergeOptions {
+	return func(m *merger) { m.lateness = lateness }
+}
+
+// WithIdle stops waiting for a source that sent nothing for the duration,
+// zero waits forever.
+func WithIdle(idle time.Duration) MergeOptions {
+	return func(m *merger) { m.idle = idle }
+}
+
+// WithOutput sets the options of the merged stream.
+func WithOutput(opts ...StreamOptions) MergeOptions {
+	return func(m *merger) { m.output = opts }
+}
+
+// WithLate sets the options of the late stream, by default a buffer of 1024
+// ticks dropping the oldest so an unread late stream never blocks the merge.
+func WithLate(opts ...StreamOptions) MergeOptions {
+	return func(m *merger) { m.late = opts }
+}
+
+// MergeOrdered merges the streams into one stream in event time order.
+// Each source has a watermark, its newest timestamp minus the lateness, and
+// ticks are emitted once they are at or before the watermark of every open
+// source. Ticks older than the last emitted tick cannot be ordered and are
+// sent to the late stream instead. The merged stream is closed with the
+// joined errors of the sources once they are all closed.
+func MergeOrdered(streams []*Stream, opts ...MergeOptions) (merged, late *Stream) {
+	m := &merger{late: []StreamOptions{WithBuffer(1024), WithPolicy(DropOldest)}}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if len(streams) == 0 {
+		merged, late = New("merge", m.output...), New("late", m.late...)
+		merged.Close()
+		late.Close()
+		return
+	}
+	merged = streams[0].derive(m.output)
+	late = streams[0].derive(m.late)
+
+	events := make(chan event)
+	for i, in := range streams {
+		go func() {
+			for t := range in.ticks {
+				select {
+				case events <- event{source: i, tick: t}:
+				case <-merged.Done():
+					in.Close()
+				}
+			}
+			select {
+			case events <- event{source: i, closed: true}:
+			case <-merged.Done():
+			}
+		}()
+	}
+
+	go m.run(streams, events, merged, late)
+	return
+}
+
+// event is a tick or the close of a source.
+type event struct {
+	source int
+	tick   *tick.Tick
+	closed bool
+}
+
+// source is the watermark state of one input.
+type source struct {
+	newest int64     // newest timestamp, math.MinInt64 before the first tick
+	seen   time.Time // arrival of the last tick
+	closed bool
+}
+
+func (m *merger) run(streams []*Stream, events <-chan event, merged, late *Stream) {
+	defer late.Close()
+
+	sources := make([]source, len(streams))
+	for i := range sources {
+		sources[i] = source{newest: math.MinInt64, seen: time.Now()}
+	}
+
+	var idle <-chan time.Time
+	if m.idle > 0 {
+		ticker := time.NewTicker(m.idle / 2)
+		defer ticker.Stop()
+		idle = ticker.C
+	}
+
+	pending := &pendingHeap{}
+	var seq uint64
+	last := int64(math.MinInt64)
+
+	// emit sends the pending ticks up to the watermark
+	emit := func(watermark int64) error {
+		for pending.Len() > 0 && (*pending)[0].ts <= watermark {
+			p := heap.Pop(pending).(pendingTick)
+			if err := merged.Send(p.tick); err != nil {
+				return err
+			}
+			last = p.ts
+		}
+		return nil
+	}
+
+	for open := len(streams); open > 0; {
+		select {
+		case e := <-events:
+			src := &sources[e.source]
+			if e.closed {
+				src.closed = true
+				open--
+				break
+			}
+			ts := e.tick.UnixNano()
+			src.newest = max(src.newest, ts)
+			src.seen = time.Now()
+			if ts < last {
+				late.Send(e.tick)
+				break
+			}
+			heap.Push(pending, pendingTick{ts: ts, source: e.source, seq: seq, tick: e.tick})
+			seq++
+		case <-idle:
+		case <-merged.Done():
+			for _, in := range streams {
+				in.CloseWithError(merged.Err())
+			}
+			return
+		}
+
+		if err := emit(m.watermark(sources)); err != nil {
+			for _, in := range streams {
+				in.CloseWithError(err)
+			}
+			return
+		}
+	}
+
+	if err := emit(math.MaxInt64); err != nil {
+		return
+	}
+	errs := make([]error, len(streams))
+	for i, in := range streams {
+		errs[i] = in.Err()
+	}
+	merged.CloseWithError(errors.Join(errs...))
+}
+
+// watermark returns the smallest watermark of the open sources that are not idle.
+func (m *merger) watermark(sources []source) int64 {
+	watermark := int64(math.MaxInt64)
+	for _, src := range sources {
+		if src.closed || (m.idle > 0 && time.Since(src.seen) > m.idle) {
+			continue
+		}
+		if src.newest == math.MinInt64 {
+			return math.MinInt64
+		}
+		watermark = min(watermark, src.newest-int64(m.lateness))
+	}
+	return watermark
+}
+
+// pendingTick is a tick waiting for the watermark, ordered by time, source and arrival.
+type pendingTick struct {
+	ts     int64
+	source int
+	seq    uint64
+	tick   *tick.Tick
+}
+
+type pendingHeap []pendingTick
+
+func (h pendingHeap) Len() int { return len(h) }
+
+func (h pendingHeap) Less(i, j int) bool {
+	if h[i].ts != h[j].ts {
+		return h[i].ts < h[j].ts
+	}
+	if h[i].source != h[j].source {
+		return h[i].source < h[j].source
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h pendingHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *pendingHeap) Push(x any) { *h = append(*h, x.(pendingTick)) }
+
+func (h *pendingHeap) Pop() any {
+	old := *h
+	p := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return p
+}
@@ -1,67 +1,228 @@
 package stream
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/rangertaha/gotal/internal/pkg/tick"
 )
 
-// Ticks represents a collection of market events, capturing the most granular form of market data.
+// ErrClosed is returned when sending to a closed stream.
+var ErrClosed = errors.New("stream closed")
+
+// Policy decides what a producer does when the stream buffer is full.
+type Policy int
+
+const (
+	Block      Policy = iota // wait until the consumer reads
+	DropOldest               // discard the oldest buffered tick
+	DropNewest               // discard the tick being sent
+)
+
+// Stream is a channel of ticks between one producer side and one consumer.
+// Producers call Send or Add from any goroutine and Close or CloseWithError
+// when done, the consumer ranges over Ticks and checks Err once the channel
+// is closed. Cancelling the stream context closes the stream with the
+// context error.
 type Stream struct {
-	name  string
-	ticks <-chan *tick.Tick
+	name   string
+	ctx    context.Context
+	buffer int
+	policy Policy
+	ticks  chan *tick.Tick
+	init   []*tick.Tick
+
+	mu      sync.RWMutex  // held for reading by senders and for writing by close
+	closing chan struct{} // closed first to wake up blocked senders
+	once    sync.Once
+	err     error
+	dropped atomic.Uint64
 
 	// metadata
 	tags map[string]string
 }
 
-
 func New(name string, opts ...StreamOptions) (s *Stream) {
 	s = &Stream{
-		name:  name,
-		ticks: make(chan *tick.Tick, 0),
-		tags:  make(map[string]string),
+		name:    name,
+		ctx:     context.Background(),
+		closing: make(chan struct{}),
+		tags:    make(map[string]string),
 	}
 
 	for _, opt := range opts {
 		opt(s)
 	}
 
+	// dropping needs somewhere to drop from
+	if s.policy != Block && s.buffer < 1 {
+		s.buffer = 1
+	}
+	s.ticks = make(chan *tick.Tick, s.buffer)
+
+	if s.ctx.Done() != nil {
+		go func() {
+			select {
+			case <-s.ctx.Done():
+				s.CloseWithError(s.ctx.Err())
+			case <-s.closing:
+			}
+		}()
+	}
+
+	if s.init != nil {
+		go func() {
+			s.CloseWithError(s.Add(s.init...))
+		}()
+	}
+
 	return
+}
+
+// Name returns the name of the stream.
+func (s *Stream) Name() string {
+	return s.name
+}
+
+// SetName sets the name of the stream.
+func (s *Stream) SetName(name string) {
+	s.name = name
+}
+
+// Context returns the context of the stream.
+func (s *Stream) Context() context.Context {
+	return s.ctx
+}
+
+// Ticks returns the channel the consumer reads from. It is closed after the
+// stream is closed and the buffered ticks are read.
+func (s *Stream) Ticks() <-chan *tick.Tick {
+	return s.ticks
+}
+
+// Send sends a tick under the backpressure policy. It returns ErrClosed, or
+// the context error, once the stream is closed.
+func (s *Stream) Send(t *tick.Tick) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-s.closing:
+		return s.closedErr()
+	default:
+	}
+
+	switch s.policy {
+	case DropNewest:
+		select {
+		case s.ticks <- t:
+		default:
+			s.dropped.Add(1)
+		}
+		return nil
+
+	case DropOldest:
+		for {
+			select {
+			case s.ticks <- t:
+				return nil
+			default:
+			}
+			select {
+			case <-s.ticks:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	}
+
+	select {
+	case s.ticks <- t:
+		return nil
+	case <-s.closing:
+		return s.closedErr()
+	}
+}
 
+// Add sends the ticks in order, stopping at the first error.
+func (s *Stream) Add(ticks ...*tick.Tick) error {
+	for _, t := range ticks {
+		if err := s.Send(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the stream. Ticks already buffered can still be read.
+func (s *Stream) Close() {
+	s.CloseWithError(nil)
+}
+
+// CloseWithError closes the stream and records the error returned by Err.
+// Only the first close has an effect.
+func (s *Stream) CloseWithError(err error) {
+	s.once.Do(func() {
+		close(s.closing)
+		s.mu.Lock()
+		s.err = err
+		close(s.ticks)
+		s.mu.Unlock()
+	})
 }
 
-// Name returns the name of the Ticks collection.
-func (t *Stream) Name() string {
-	return t.name
+// Done returns a channel closed when the stream is closed.
+func (s *Stream) Done() <-chan struct{} {
+	return s.closing
 }
 
-// SetName sets the name of the Ticks collection.
-func (t *Stream) SetName(name string) {
-	t.name = name
+// Err returns the error the stream was closed with, nil while open or after
+// a clean close.
+func (s *Stream) Err() error {
+	select {
+	case <-s.closing:
+	default:
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.err
+}
+
+// closedErr returns the error for sending to a closed stream.
+func (s *Stream) closedErr() error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	return ErrClosed
 }
 
-func (t *Stream) Ticks() <-chan *tick.Tick {
-	return t.ticks
+// Dropped returns the number of ticks discarded by the backpressure policy.
+func (s *Stream) Dropped() uint64 {
+	return s.dropped.Load()
 }
 
-func (t *Stream) Add(ticks ...*tick.Tick) {
-	ch := make(chan *tick.Tick)
-	for _, tick := range ticks {
-		ch <- tick
+// Collect reads the stream until it is closed and returns the ticks with the close error.
+func (s *Stream) Collect() ([]*tick.Tick, error) {
+	var ticks []*tick.Tick
+	for t := range s.ticks {
+		ticks = append(ticks, t)
 	}
-	close(ch)
-	t.ticks = ch
+	return ticks, s.Err()
 }
 
 // Save saves the Series collection to a file.
-func (t *Stream) Save(filename string, outputs ...string) error {
+func (s *Stream) Save(filename string, outputs ...string) error {
 	return nil
 }
 
-// Print prints the Series collection to the console.
-func (t *Stream) Print() {
-	for tick := range t.ticks {
+// Print prints the ticks until the stream is closed and returns the close error.
+func (s *Stream) Print() error {
+	for tick := range s.ticks {
 		fmt.Printf("%+v\n", tick)
 	}
+	return s.Err()
 }
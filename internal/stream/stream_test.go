@@ -0,0 +1,244 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+)
+
+func ticks(values ...float64) []*tick.Tick {
+	out := make([]*tick.Tick, len(values))
+	for i, v := range values {
+		out[i] = tick.New(tick.WithFields(map[string]float64{"price": v}))
+	}
+	return out
+}
+
+// prices collects the stream and returns the prices.
+func prices(t *testing.T, s *Stream) []float64 {
+	t.Helper()
+	ts, err := s.Collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := make([]float64, len(ts))
+	for i, t := range ts {
+		out[i] = t.GetField("price")
+	}
+	return out
+}
+
+func equal(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestWithTicks(t *testing.T) {
+	s := New("prices", WithTicks(ticks(1, 2, 3)...))
+	if got := prices(t, s); !equal(got, []float64{1, 2, 3}) {
+		t.Errorf("Collect() = %v, want [1 2 3]", got)
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	tests := []struct {
+		policy Policy
+		want   []float64
+	}{
+		{DropNewest, []float64{1, 2}},
+		{DropOldest, []float64{4, 5}},
+	}
+	for _, tt := range tests {
+		s := New("prices", WithBuffer(2), WithPolicy(tt.policy))
+		if err := s.Add(ticks(1, 2, 3, 4, 5)...); err != nil {
+			t.Fatal(err)
+		}
+		s.Close()
+		if got := prices(t, s); !equal(got, tt.want) || s.Dropped() != 3 {
+			t.Errorf("policy %d: got %v with %d dropped, want %v with 3 dropped", tt.policy, got, s.Dropped(), tt.want)
+		}
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := New("prices", WithContext(ctx))
+
+	sent := make(chan error)
+	go func() { sent <- s.Add(ticks(1)...) }()
+	cancel()
+
+	select {
+	case err := <-sent:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Send() = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocked send was not released by cancel")
+	}
+	if _, err := s.Collect(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Err() = %v, want context.Canceled", err)
+	}
+}
+
+func TestOperators(t *testing.T) {
+	source := New("prices", WithTicks(ticks(1, 2, 3, 4, 5, 6)...))
+	doubled := source.
+		Filter(func(t *tick.Tick) bool { return int(t.GetField("price"))%2 == 0 }).
+		Map(func(t *tick.Tick) *tick.Tick {
+			t.SetField("price", t.GetField("price")*2)
+			return t
+		})
+
+	tee := doubled.Tee(2)
+	merged := Merge([]*Stream{tee[0], tee[1].Map(func(t *tick.Tick) *tick.Tick {
+		return tick.New(tick.WithFields(map[string]float64{"price": -t.GetField("price")}))
+	})})
+
+	got := prices(t, merged)
+	sort.Float64s(got)
+	if want := []float64{-12, -8, -4, 4, 8, 12}; !equal(got, want) {
+		t.Errorf("merged = %v, want %v", got, want)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	source := New("prices", WithTicks(ticks(1, 2, 3)...))
+	repeated := source.FlatMap(func(t *tick.Tick) []*tick.Tick {
+		// each price one time less than its value
+		var values []float64
+		for range int(t.GetField("price")) - 1 {
+			values = append(values, t.GetField("price"))
+		}
+		return ticks(values...)
+	})
+	if got, want := prices(t, repeated), []float64{2, 3, 3}; !equal(got, want) {
+		t.Errorf("flattened = %v, want %v", got, want)
+	}
+}
+
+func TestFlatMapFlush(t *testing.T) {
+	// sums of pairs of prices, the odd one out flushed at the end
+	var held []float64
+	pair := func(t *tick.Tick) []*tick.Tick {
+		if held = append(held, t.GetField("price")); len(held) < 2 {
+			return nil
+		}
+		sum := held[0] + held[1]
+		held = nil
+		return ticks(sum)
+	}
+	flush := func() []*tick.Tick {
+		if len(held) == 0 {
+			return nil
+		}
+		return ticks(held...)
+	}
+
+	sums := New("prices", WithTicks(ticks(1, 2, 3, 4, 5)...)).FlatMapFlush(pair, flush)
+	if got, want := prices(t, sums), []float64{3, 7, 5}; !equal(got, want) {
+		t.Errorf("flushed = %v, want %v", got, want)
+	}
+
+	// nothing is flushed after a failure
+	failed := errors.New("feed disconnected")
+	held = nil
+	source := New("prices", WithBuffer(1))
+	sums = source.FlatMapFlush(pair, flush)
+	source.Send(ticks(1)[0])
+	source.CloseWithError(failed)
+	if got, err := sums.Collect(); !errors.Is(err, failed) || len(got) != 0 {
+		t.Errorf("failed stream = %d ticks, %v, want 0 ticks, %v", len(got), err, failed)
+	}
+}
+
+func TestErrorPropagation(t *testing.T) {
+	failed := errors.New("feed disconnected")
+	source := New("prices", WithBuffer(1))
+	mapped := source.Map(func(t *tick.Tick) *tick.Tick { return t })
+
+	source.Send(ticks(1)[0])
+	source.CloseWithError(failed)
+	if _, err := mapped.Collect(); !errors.Is(err, failed) {
+		t.Errorf("downstream Err() = %v, want %v", err, failed)
+	}
+
+	// closing the consumer side stops the producer
+	source = New("prices")
+	mapped = source.Map(func(t *tick.Tick) *tick.Tick { return t })
+	mapped.Close()
+	for i := 0; ; i++ {
+		if err := source.Send(ticks(1)[0]); errors.Is(err, ErrClosed) {
+			break
+		}
+		if i > 10 {
+			t.Fatal("producer was not stopped after the consumer closed")
+		}
+	}
+}
+
+func timed(seconds ...int) []*tick.Tick {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := make([]*tick.Tick, len(seconds))
+	for i, sec := range seconds {
+		out[i] = tick.New(
+			tick.WithTime(base.Add(time.Duration(sec)*time.Second)),
+			tick.WithFields(map[string]float64{"price": float64(sec)}),
+		)
+	}
+	return out
+}
+
+func TestMergeOrdered(t *testing.T) {
+	trades := New("trades", WithTicks(timed(1, 3, 5, 7, 9)...))
+	quotes := New("quotes", WithTicks(timed(2, 4, 6, 8)...))
+
+	merged, late := MergeOrdered([]*Stream{trades, quotes})
+	if got, want := prices(t, merged), []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}; !equal(got, want) {
+		t.Errorf("merged = %v, want %v", got, want)
+	}
+	if got := prices(t, late); len(got) != 0 {
+		t.Errorf("late = %v, want none", got)
+	}
+}
+
+func TestMergeOrderedLateness(t *testing.T) {
+	tests := []struct {
+		lateness time.Duration
+		merged   []float64
+		late     []float64
+	}{
+		{0, []float64{1, 5, 6}, []float64{3}},
+		{2 * time.Second, []float64{1, 3, 5, 6}, nil},
+	}
+	for _, tt := range tests {
+		source := New("trades")
+		merged, late := MergeOrdered([]*Stream{source}, WithLateness(tt.lateness))
+		go func() {
+			// give the merge time to handle each tick before the next
+			for _, t := range timed(1, 5, 3, 6) {
+				source.Send(t)
+				time.Sleep(10 * time.Millisecond)
+			}
+			source.Close()
+		}()
+
+		if got := prices(t, merged); !equal(got, tt.merged) {
+			t.Errorf("lateness %v: merged = %v, want %v", tt.lateness, got, tt.merged)
+		}
+		if got := prices(t, late); !equal(got, tt.late) {
+			t.Errorf("lateness %v: late = %v, want %v", tt.lateness, got, tt.late)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package stream
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+	"github.com/rangertaha/gotal/internal/series"
+)
+
+type windower struct {
+	key     []string
+	delay   time.Duration
+	partial bool
+	late    *Stream
+}
+
+type WindowOptions func(*windower)
+
+// WithKey aggregates the ticks of each combination of the tag values separately, e.g. per "symbol".
+func WithKey(tags ...string) WindowOptions {
+	return func(w *windower) { w.key = tags }
+}
+
+// WithDelay holds ticks back for the duration so ticks arriving out of order
+// within it are still aggregated in event time order.
+func WithDelay(delay time.Duration) WindowOptions {
+	return func(w *windower) { w.delay = delay }
+}
+
+// WithPartial emits the current result of a window after every tick, tagged
+// "partial", before the final result once the window closes.
+func WithPartial() WindowOptions {
+	return func(w *windower) { w.partial = true }
+}
+
+// WithLateStream sends ticks behind the watermark, whose windows may already
+// be closed, to the stream instead of discarding them. The stream is closed
+// with the window output.
+func WithLateStream(late *Stream) WindowOptions {
+	return func(w *windower) { w.late = late }
+}
+
+// Tumbling aggregates the ticks into consecutive windows of the given size,
+// aligned and stamped like Series.Resample so live and batch bars are the same.
+// A size that is not positive closes the stream and the output with an error.
+func (s *Stream) Tumbling(size time.Duration, aggregations []series.Aggregation, opts ...WindowOptions) *Stream {
+	if size <= 0 {
+		return s.invalid(fmt.Errorf("invalid tumbling window size: %v", size), opts)
+	}
+	return s.window(&tumbling{size: size}, aggregations, opts)
+}
+
+// Sliding aggregates the ticks into overlapping windows of the given size
+// starting every slide, each stamped at its start. The slide must be
+// positive and at most the size, or the stream and the output are closed
+// with an error.
+func (s *Stream) Sliding(size, slide time.Duration, aggregations []series.Aggregation, opts ...WindowOptions) *Stream {
+	if slide <= 0 || slide > size {
+		return s.invalid(fmt.Errorf("invalid sliding window: size %v every %v", size, slide), opts)
+	}
+	return s.window(&sliding{size: size, slide: slide}, aggregations, opts)
+}
+
+// Session aggregates the ticks into windows that close after a gap without
+// ticks, each stamped at its first tick.
+func (s *Stream) Session(gap time.Duration, aggregations []series.Aggregation, opts ...WindowOptions) *Stream {
+	return s.window(&session{gap: gap}, aggregations, opts)
+}
+
+// assigner decides the windows of a tick.
+type assigner interface {
+	// assign returns the windows the tick at ts falls into, opening new ones with open
+	assign(key string, ts int64, windows map[string][]*window, open func(start, end int64) *window) []*window
+	// stamp returns the result of a window
+	stamp(w *window) *tick.Tick
+}
+
+// window is the aggregation state of one key and time range.
+type window struct {
+	key        string
+	start, end int64 // [start, end) in unix nanoseconds
+	bucket     *series.Bucket
+}
+
+type tumbling struct {
+	size time.Duration
+}
+
+func (a *tumbling) assign(key string, ts int64, windows map[string][]*window, open func(start, end int64) *window) []*window {
+	start := time.Unix(0, ts).Truncate(a.size).UnixNano()
+	for _, w := range windows[key] {
+		if w.start == start {
+			return []*window{w}
+		}
+	}
+	return []*window{open(start, start+int64(a.size))}
+}
+
+func (a *tumbling) stamp(w *window) *tick.Tick {
+	return w.bucket.Tick(time.Unix(0, w.start), a.size)
+}
+
+type sliding struct {
+	size, slide time.Duration
+}
+
+func (a *sliding) assign(key string, ts int64, windows map[string][]*window, open func(start, end int64) *window) []*window {
+	var out []*window
+	first := time.Unix(0, ts).Truncate(a.slide).UnixNano()
+	for start := first; start+int64(a.size) > ts; start -= int64(a.slide) {
+		found := false
+		for _, w := range windows[key] {
+			if w.start == start {
+				out, found = append(out, w), true
+				break
+			}
+		}
+		if !found {
+			out = append(out, open(start, start+int64(a.size)))
+		}
+	}
+	return out
+}
+
+func (a *sliding) stamp(w *window) *tick.Tick {
+	t := w.bucket.Tick(time.Unix(0, w.start), a.size)
+	// the bucket truncates to the size, windows start at multiples of the slide
+	t.SetUnixNano(w.start)
+	return t
+}
+
+type session struct {
+	gap time.Duration
+}
+
+func (a *session) assign(key string, ts int64, windows map[string][]*window, open func(start, end int64) *window) []*window {
+	// ticks arrive in order, so only the newest session of the key can grow
+	if ws := windows[key]; len(ws) > 0 {
+		if w := ws[len(ws)-1]; ts < w.end {
+			w.end = ts + int64(a.gap)
+			return []*window{w}
+		}
+	}
+	return []*window{open(ts, ts+int64(a.gap))}
+}
+
+func (a *session) stamp(w *window) *tick.Tick {
+	return w.bucket.Tick(time.Unix(0, w.start), 0)
+}
+
+// invalid closes the stream, the late stream of the options and the returned
+// output with the error of a window that cannot be run.
+func (s *Stream) invalid(err error, opts []WindowOptions) *Stream {
+	w := &windower{}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if w.late != nil {
+		w.late.CloseWithError(err)
+	}
+	s.CloseWithError(err)
+	out := s.derive(nil)
+	out.CloseWithError(err)
+	return out
+}
+
+// window runs the windowing of the stream. Ticks are released in event time
+// order once the watermark, the newest timestamp minus the delay, passes
+// them, and windows close once the watermark passes their end.
+func (s *Stream) window(a assigner, aggregations []series.Aggregation, opts []WindowOptions) *Stream {
+	w := &windower{}
+	for _, opt := range opts {
+		opt(w)
+	}
+	out := s.derive(nil)
+
+	go func() {
+		defer func() {
+			if w.late != nil {
+				w.late.Close()
+			}
+		}()
+
+		windows := make(map[string][]*window)
+		open := func(key string) func(start, end int64) *window {
+			return func(start, end int64) *window {
+				win := &window{key: key, start: start, end: end, bucket: series.NewBucket(aggregations...)}
+				windows[key] = append(windows[key], win)
+				return win
+			}
+		}
+
+		// flush emits and removes the windows ending at or before the watermark
+		flush := func(watermark int64) error {
+			var closed []*window
+			for key, ws := range windows {
+				kept := ws[:0]
+				for _, win := range ws {
+					if win.end <= watermark {
+						closed = append(closed, win)
+					} else {
+						kept = append(kept, win)
+					}
+				}
+				if len(kept) == 0 {
+					delete(windows, key)
+				} else {
+					windows[key] = kept
+				}
+			}
+			sort.Slice(closed, func(i, j int) bool {
+				if closed[i].end != closed[j].end {
+					return closed[i].end < closed[j].end
+				}
+				if closed[i].start != closed[j].start {
+					return closed[i].start < closed[j].start
+				}
+				return closed[i].key < closed[j].key
+			})
+			for _, win := range closed {
+				if err := out.Send(a.stamp(win)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		// release aggregates a tick in event time order
+		release := func(t *tick.Tick) error {
+			key := w.keyOf(t)
+			for _, win := range a.assign(key, t.UnixNano(), windows, open(key)) {
+				win.bucket.Add(t)
+				if w.partial {
+					partial := a.stamp(win)
+					partial.SetTag("partial", "true")
+					if err := out.Send(partial); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+
+		pending := &pendingHeap{}
+		var seq uint64
+		newest, watermark := int64(math.MinInt64), int64(math.MinInt64)
+
+		process := func(t *tick.Tick) error {
+			ts := t.UnixNano()
+			if ts < watermark {
+				if w.late != nil {
+					w.late.Send(t)
+				}
+				return nil
+			}
+			heap.Push(pending, pendingTick{ts: ts, seq: seq, tick: t})
+			seq++
+
+			newest = max(newest, ts)
+			watermark = max(watermark, newest-int64(w.delay))
+			for pending.Len() > 0 && (*pending)[0].ts <= watermark {
+				p := heap.Pop(pending).(pendingTick)
+				if err := flush(p.ts); err != nil {
+					return err
+				}
+				if err := release(p.tick); err != nil {
+					return err
+				}
+			}
+			// the next released tick is after the watermark
+			return flush(watermark)
+		}
+
+		for t := range s.ticks {
+			if err := process(t); err != nil {
+				s.CloseWithError(err)
+				break
+			}
+		}
+
+		// flush everything still held back
+		for pending.Len() > 0 {
+			p := heap.Pop(pending).(pendingTick)
+			if flush(p.ts) != nil || release(p.tick) != nil {
+				break
+			}
+		}
+		flush(math.MaxInt64)
+		out.CloseWithError(s.Err())
+	}()
+	return out
+}
+
+// keyOf returns the values of the key tags of the tick.
+func (w *windower) keyOf(t *tick.Tick) string {
+	if len(w.key) == 0 {
+		return ""
+	}
+	values := make([]string, len(w.key))
+	for i, tag := range w.key {
+		values[i] = t.GetTag(tag)
+	}
+	return strings.Join(values, "\x00")
+}
@@ -0,0 +1,145 @@
+package stream
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/tick"
+	"github.com/rangertaha/gotal/internal/series"
+)
+
+func trades(n int) []*tick.Tick {
+	rng := rand.New(rand.NewSource(7))
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := make([]*tick.Tick, n)
+	for i := range out {
+		symbol := []string{"BTC", "ETH"}[rng.Intn(2)]
+		out[i] = tick.New(
+			tick.WithTime(base.Add(time.Duration(i)*7*time.Second)),
+			tick.WithFields(map[string]float64{"price": 100 + rng.Float64(), "volume": rng.Float64()}),
+			tick.WithTags(map[string]string{"symbol": symbol}),
+		)
+	}
+	// swap neighbours so ticks arrive out of order within the delay
+	for i := 1; i < n; i += 3 {
+		out[i], out[i-1] = out[i-1], out[i]
+	}
+	return out
+}
+
+func bars(ticks []*tick.Tick) map[string][]*tick.Tick {
+	out := make(map[string][]*tick.Tick)
+	for _, t := range ticks {
+		out[t.GetTag("symbol")] = append(out[t.GetTag("symbol")], t)
+	}
+	return out
+}
+
+func TestTumblingMatchesResample(t *testing.T) {
+	input := trades(500)
+	aggs := series.OHLCV("price", "volume")
+
+	live, err := New("trades", WithTicks(input...)).
+		Tumbling(time.Minute, aggs, WithKey("symbol"), WithDelay(10*time.Second)).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for symbol, got := range bars(live) {
+		batch := series.New(symbol)
+		for _, t := range input {
+			if t.GetTag("symbol") == symbol {
+				batch.Add(t)
+			}
+		}
+		want := batch.Resample(time.Minute, aggs).Ticks()
+		if len(got) != len(want) {
+			t.Fatalf("%s: %d live bars, want %d", symbol, len(got), len(want))
+		}
+		for i := range want {
+			if got[i].UnixNano() != want[i].UnixNano() || !reflect.DeepEqual(got[i].Fields(), want[i].Fields()) {
+				t.Errorf("%s bar %d = %v %v, want %v %v", symbol, i, got[i].Time(), got[i].Fields(), want[i].Time(), want[i].Fields())
+			}
+		}
+	}
+}
+
+func TestSlidingAndSession(t *testing.T) {
+	input := timed(0, 10, 20, 30, 100, 110)
+	count := []series.Aggregation{series.Agg("count", series.COUNT, "price")}
+
+	sliding, err := New("trades", WithTicks(input...)).Sliding(30*time.Second, 10*time.Second, count).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var counts []float64
+	for _, bar := range sliding {
+		counts = append(counts, bar.GetField("count"))
+	}
+	// windows starting at -20s, -10s, 0s, 10s, 20s, 30s, 80s, 90s, 100s, 110s
+	if want := []float64{1, 2, 3, 3, 2, 1, 1, 2, 2, 1}; !equal(counts, want) {
+		t.Errorf("sliding counts = %v, want %v", counts, want)
+	}
+
+	sessions, err := New("trades", WithTicks(input...)).Session(30*time.Second, count).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 || sessions[0].GetField("count") != 4 || sessions[1].GetField("count") != 2 {
+		t.Errorf("sessions = %v, want counts 4 and 2", sessions)
+	}
+	if got := sessions[1].Time().Sub(input[0].Time()); got != 100*time.Second {
+		t.Errorf("second session starts after %v, want 100s", got)
+	}
+}
+
+func TestInvalidWindows(t *testing.T) {
+	count := []series.Aggregation{series.Agg("count", series.COUNT, "price")}
+	for name, window := range map[string]func(*Stream) *Stream{
+		"tumbling of 0":      func(s *Stream) *Stream { return s.Tumbling(0, count) },
+		"sliding by 0":       func(s *Stream) *Stream { return s.Sliding(time.Minute, 0, count) },
+		"sliding over size":  func(s *Stream) *Stream { return s.Sliding(time.Minute, 2*time.Minute, count) },
+		"sliding of -1 by 1": func(s *Stream) *Stream { return s.Sliding(-time.Second, time.Second, count) },
+	} {
+		source := New("trades")
+		if _, err := window(source).Collect(); err == nil {
+			t.Errorf("%s: the output was closed without an error", name)
+		}
+		if err := source.Send(timed(0)[0]); err == nil {
+			t.Errorf("%s: the source is still open", name)
+		}
+	}
+}
+
+func TestPartialAndLate(t *testing.T) {
+	source := New("trades")
+	late := New("late", WithBuffer(8))
+	out := source.Tumbling(time.Minute, series.OHLC("price"), WithPartial(), WithLateStream(late))
+
+	go func() {
+		source.Add(timed(0, 30, 70, 10)...)
+		source.Close()
+	}()
+
+	ticks, err := out.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var partial, final int
+	for _, t := range ticks {
+		if t.GetTag("partial") == "true" {
+			partial++
+		} else {
+			final++
+		}
+	}
+	if partial != 3 || final != 2 {
+		t.Errorf("got %d partial and %d final results, want 3 and 2", partial, final)
+	}
+	if got := prices(t, late); !equal(got, []float64{10}) {
+		t.Errorf("late = %v, want [10]", got)
+	}
+}
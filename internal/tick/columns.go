@@ -0,0 +1,65 @@
+package tick
+
+import (
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/sig"
+)
+
+// Columns stores ticks by column, like a series. A tick bound to a row of the
+// columns is a view of it, reading and writing the row instead of its own
+// fields, tags and signals. Tags and Signals return the maps of the row,
+// never nil, and Fields returns a copy of the row fields.
+type Columns interface {
+	ID(row int) string
+	SetID(row int, id string)
+	UnixNano(row int) int64
+	SetUnixNano(row int, nsec int64)
+	Duration(row int) time.Duration
+	SetDuration(row int, duration time.Duration)
+
+	Field(row int, key string) (float64, bool)
+	SetField(row int, key string, value float64)
+	RemoveField(row int, key string)
+	Fields(row int) map[string]float64
+
+	Tags(row int) map[string]string
+	SetTags(row int, tags map[string]string)
+	Signals(row int) map[sig.Signal]sig.Strength
+	SetSignals(row int, signals map[sig.Signal]sig.Strength)
+}
+
+// View returns a tick that is a view of the row of the columns.
+func View(columns Columns, row int) *Tick {
+	t := &Tick{}
+	t.Bind(columns, row)
+	return t
+}
+
+// Bind makes the tick a view of the row of the columns. The tick drops its
+// own state, the row is expected to hold it already.
+func (t *Tick) Bind(columns Columns, row int) {
+	t.columns, t.row = columns, row
+	t.uuid, t.timestamp, t.duration = "", 0, 0
+	t.fields, t.tags, t.signals = nil, nil, nil
+	if t.idFunc == nil {
+		t.idFunc = func(t *Tick) string { return t.ID() }
+	}
+}
+
+// Unbind copies the row into the tick, which stops being a view of it.
+func (t *Tick) Unbind() {
+	if t.columns == nil {
+		return
+	}
+	c, row := t.columns, t.row
+	t.columns = nil
+	t.uuid, t.timestamp, t.duration = c.ID(row), c.UnixNano(row), c.Duration(row)
+	t.fields, t.tags, t.signals = c.Fields(row), c.Tags(row), c.Signals(row)
+}
+
+// Columns returns the columns and the row the tick is a view of, nil when the
+// tick holds its own state.
+func (t *Tick) Columns() (Columns, int) {
+	return t.columns, t.row
+}
@@ -0,0 +1,122 @@
+package tick
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rangertaha/gotal/internal/pkg/sig"
+)
+
+// jsonTick is the wire format of a Tick.
+type jsonTick struct {
+	ID       string                      `json:"id,omitempty"`
+	Time     time.Time                   `json:"time"`
+	Duration string                      `json:"duration,omitempty"`
+	Fields   map[string]Float            `json:"fields"`
+	Tags     map[string]string           `json:"tags,omitempty"`
+	Signals  map[sig.Signal]sig.Strength `json:"signals,omitempty"`
+}
+
+// Float is a float64 that survives a JSON round trip, NaN is encoded as null
+// and infinities as the strings "+Inf" and "-Inf".
+type Float float64
+
+func (f Float) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	switch {
+	case math.IsNaN(v):
+		return []byte("null"), nil
+	case math.IsInf(v, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(v, -1):
+		return []byte(`"-Inf"`), nil
+	}
+	return json.Marshal(v)
+}
+
+func (f *Float) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "null":
+		*f = Float(math.NaN())
+		return nil
+	case `"+Inf"`, `"Inf"`:
+		*f = Float(math.Inf(1))
+		return nil
+	case `"-Inf"`:
+		*f = Float(math.Inf(-1))
+		return nil
+	}
+
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("invalid field value %s: %w", data, err)
+	}
+	*f = Float(v)
+	return nil
+}
+
+// MarshalJSON encodes the tick with its timestamp, duration, fields, tags and signals.
+func (t *Tick) MarshalJSON() ([]byte, error) {
+	fields := t.Fields()
+	out := jsonTick{
+		ID:      t.ID(),
+		Time:    t.Time().UTC(),
+		Fields:  make(map[string]Float, len(fields)),
+		Tags:    t.Tags(),
+		Signals: t.Signals(),
+	}
+	if d := t.Duration(); d > 0 {
+		out.Duration = d.String()
+	}
+	for k, v := range fields {
+		out.Fields[k] = Float(v)
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a tick encoded by MarshalJSON.
+func (t *Tick) UnmarshalJSON(data []byte) error {
+	var in jsonTick
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	var duration time.Duration
+	if in.Duration != "" {
+		d, err := time.ParseDuration(in.Duration)
+		if err != nil {
+			return fmt.Errorf("invalid tick duration %q: %w", in.Duration, err)
+		}
+		duration = d
+	}
+
+	fields := make(map[string]float64, len(in.Fields))
+	for k, v := range in.Fields {
+		fields[k] = float64(v)
+	}
+	if in.Tags == nil {
+		in.Tags = map[string]string{}
+	}
+	if in.Signals == nil {
+		in.Signals = map[sig.Signal]sig.Strength{}
+	}
+	t.SetID(in.ID)
+	t.SetUnixNano(in.Time.UnixNano())
+	if t.columns != nil {
+		t.columns.SetDuration(t.row, duration)
+	} else {
+		t.duration = duration
+	}
+	t.SetFields(fields)
+	t.SetTags(in.Tags)
+	t.SetSignals(in.Signals)
+	if t.idFunc == nil {
+		t.SetIDFunc(func(t *Tick) string {
+			return t.ID()
+		})
+	}
+
+	return nil
+}
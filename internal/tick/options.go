@@ -16,24 +16,24 @@ func WithDuration(duration time.Duration) TickOptions {
 
 func WithFields(fields map[string]float64) TickOptions {
 	return func(t *Tick) {
-		t.fields = fields
+		t.SetFields(fields)
 	}
 }
 
 func WithTags(tags map[string]string) TickOptions {
 	return func(t *Tick) {
-		t.tags = tags
+		t.SetTags(tags)
 	}
 }
 
 func WithSignals(signals map[sig.Signal]sig.Strength) TickOptions {
 	return func(t *Tick) {
-		t.signals = signals
+		t.SetSignals(signals)
 	}
 }
 
 func WithTime(timestamp time.Time) TickOptions {
 	return func(t *Tick) {
-		t.timestamp = timestamp.Unix()
+		t.SetUnixNano(timestamp.UnixNano())
 	}
 }
@@ -12,18 +12,22 @@ type IDFunc func(t *Tick) string
 // Tick represents a single market event, capturing the most granular form of market data.
 type Tick struct {
 	uuid      string                      // The unique identifier for the tick
-	timestamp int64                       // The time at which the tick was recorded
+	timestamp int64                       // The time at which the tick was recorded, in nanoseconds since the Unix epoch
 	duration  time.Duration               // The duration of the tick, typically very short
 	fields    map[string]float64          // The numerical fields
 	tags      map[string]string           // The classification tags, e.g. market, symbol, exchange, currency, etc.
 	signals   map[sig.Signal]sig.Strength // The signals, e.g. buy, sell, etc.
 	idFunc    IDFunc
+
+	// the row the tick is a view of, the state above is unused when set
+	columns Columns
+	row     int
 }
 
 func New(opts ...TickOptions) *Tick {
 	tick := &Tick{
 		uuid:      "",
-		timestamp: time.Now().Unix(),
+		timestamp: time.Now().UnixNano(),
 		duration:  0,
 		fields:    map[string]float64{},
 		tags:      map[string]string{},
@@ -36,7 +40,7 @@ func New(opts ...TickOptions) *Tick {
 
 	if tick.idFunc == nil {
 		tick.SetIDFunc(func(t *Tick) string {
-			return t.uuid
+			return t.ID()
 		})
 	}
 
@@ -46,10 +50,17 @@ func New(opts ...TickOptions) *Tick {
 }
 
 func (t *Tick) ID() string {
+	if t.columns != nil {
+		return t.columns.ID(t.row)
+	}
 	return t.uuid
 }
 
 func (t *Tick) SetID(id string) {
+	if t.columns != nil {
+		t.columns.SetID(t.row, id)
+		return
+	}
 	t.uuid = id
 }
 
@@ -58,71 +69,118 @@ func (t *Tick) SetIDFunc(idFunc IDFunc) {
 }
 
 func (t *Tick) Time() time.Time {
-	return time.Unix(t.timestamp, 0)
+	return time.Unix(0, t.UnixNano())
 }
 
+// Epock returns the timestamp in seconds since the Unix epoch.
 func (t *Tick) Epock() int64 {
-	return t.timestamp
+	return t.Time().Unix()
 }
 
+// SetEpock sets the timestamp in seconds since the Unix epoch.
 func (t *Tick) SetEpock(epock int64) {
-	t.timestamp = epock
+	t.SetUnixNano(epock * int64(time.Second))
+}
+
+// UnixNano returns the timestamp in nanoseconds since the Unix epoch.
+func (t *Tick) UnixNano() int64 {
+	if t.columns != nil {
+		return t.columns.UnixNano(t.row)
+	}
+	return t.timestamp
+}
+
+// SetUnixNano sets the timestamp in nanoseconds since the Unix epoch.
+func (t *Tick) SetUnixNano(nsec int64) {
+	if t.columns != nil {
+		t.columns.SetUnixNano(t.row, nsec)
+		return
+	}
+	t.timestamp = nsec
 }
 
 func (t *Tick) SetTime(timestamp time.Time) {
-	t.timestamp = timestamp.Unix()
+	t.SetUnixNano(timestamp.UnixNano())
 
 	// Truncate the timestamp to the duration
-	if t.duration > 0 {
-		// Get the timestamp as a time.Time
-		timestamp := time.Unix(t.timestamp, 0)
-
-		// Truncate the timestamp to the duration
-		t.timestamp = timestamp.Truncate(t.duration).Unix()
-	}
+	t.truncate()
 }
 
 func (t *Tick) Duration() time.Duration {
+	if t.columns != nil {
+		return t.columns.Duration(t.row)
+	}
 	return t.duration
 }
 
 func (t *Tick) SetDuration(duration time.Duration) {
-	t.duration = duration
+	if t.columns != nil {
+		t.columns.SetDuration(t.row, duration)
+	} else {
+		t.duration = duration
+	}
 
 	// Truncate the timestamp to the duration
-	if t.duration > 0 {
-		// Get the timestamp as a time.Time
-		timestamp := time.Unix(t.timestamp, 0)
-		
-		// Truncate the timestamp to the duration
-		t.timestamp = timestamp.Truncate(t.duration).Unix()
+	t.truncate()
+}
+
+// truncate rounds the timestamp down to a multiple of the duration.
+func (t *Tick) truncate() {
+	if d := t.Duration(); d > 0 {
+		t.SetUnixNano(t.Time().Truncate(d).UnixNano())
 	}
 }
 
 // Field methods
 // ------------------------------------------------------------
 
+// Fields returns the fields of the tick, a copy when the tick is a view.
 func (t *Tick) Fields() map[string]float64 {
+	if t.columns != nil {
+		return t.columns.Fields(t.row)
+	}
 	return t.fields
 }
 
 func (t *Tick) GetField(key string) float64 {
-	if val, ok := t.fields[key]; ok {
+	if val, ok := t.field(key); ok {
 		return val
 	}
 	return math.NaN()
 }
 
+// field returns the value of the field and whether the tick has it.
+func (t *Tick) field(key string) (float64, bool) {
+	if t.columns != nil {
+		return t.columns.Field(t.row, key)
+	}
+	val, ok := t.fields[key]
+	return val, ok
+}
+
 func (t *Tick) SetField(key string, value float64) {
+	if t.columns != nil {
+		t.columns.SetField(t.row, key, value)
+		return
+	}
 	t.fields[key] = value
 }
 
 func (t *Tick) SetFields(fields map[string]float64) {
+	if t.columns != nil {
+		for key := range t.columns.Fields(t.row) {
+			t.columns.RemoveField(t.row, key)
+		}
+		for key, value := range fields {
+			t.columns.SetField(t.row, key, value)
+		}
+		return
+	}
 	t.fields = fields
 }
 
 func (t *Tick) HasField(key string) bool {
-	_, ok := t.fields[key]
+	_, ok := t.field(key)
 	return ok
 }
 
@@ -136,35 +194,40 @@ func (t *Tick) HasFields(keys ...string) bool {
 }
 
 func (t *Tick) RemoveField(key string) {
+	if t.columns != nil {
+		t.columns.RemoveField(t.row, key)
+		return
+	}
 	delete(t.fields, key)
 }
 
 func (t *Tick) FieldNames() []string {
-	keys := make([]string, 0, len(t.fields))
-	for k := range t.fields {
+	fields := t.Fields()
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
 		keys = append(keys, k)
 	}
 	return keys
 }
 
 func (t *Tick) Len() int {
-	return len(t.fields)
+	return len(t.Fields())
 }
 
 func (t *Tick) IsEmpty() bool {
-	if t == nil || t.fields == nil {
+	if t == nil || (t.columns == nil && t.fields == nil) {
 		return true
 	}
-	return len(t.fields) == 0
+	return t.Len() == 0
 }
 
 func (t *Tick) Reset() {
-	t.fields = map[string]float64{}
+	t.SetFields(map[string]float64{})
 }
 
 func (t *Tick) ForEach(fn func(key string, value float64) float64) {
-	for k, v := range t.fields {
-		t.fields[k] = fn(k, v)
+	for k, v := range t.Fields() {
+		t.SetField(k, fn(k, v))
 	}
 }
 
@@ -172,42 +235,51 @@ func (t *Tick) ForEach(fn func(key string, value float64) float64) {
 // ------------------------------------------------------------
 
 func (t *Tick) Tags() map[string]string {
+	if t.columns != nil {
+		return t.columns.Tags(t.row)
+	}
 	return t.tags
 }
 
 func (t *Tick) HasTag(key string) bool {
-	_, ok := t.tags[key]
+	_, ok := t.Tags()[key]
 	return ok
 }
 
 func (t *Tick) GetTag(key string) string {
-	if val, ok := t.tags[key]; ok {
+	if val, ok := t.Tags()[key]; ok {
 		return val
 	}
 	return ""
 }
 
 func (t *Tick) SetTag(key string, value string) {
-	t.tags[key] = value
+	t.Tags()[key] = value
 }
 
 func (t *Tick) SetTags(tags map[string]string) {
+	if t.columns != nil {
+		t.columns.SetTags(t.row, tags)
+		return
+	}
 	t.tags = tags
 }
 
 func (t *Tick) UpdateTags(tags map[string]string) {
+	own := t.Tags()
 	for k, v := range tags {
-		t.tags[k] = v
+		own[k] = v
 	}
 }
 
 func (t *Tick) RemoveTag(key string) {
-	delete(t.tags, key)
+	delete(t.Tags(), key)
 }
 
 func (t *Tick) TagNames() []string {
-	keys := make([]string, 0, len(t.tags))
-	for k := range t.tags {
+	tags := t.Tags()
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
 		keys = append(keys, k)
 	}
 	return keys
@@ -217,36 +289,44 @@ func (t *Tick) TagNames() []string {
 // ------------------------------------------------------------
 
 func (t *Tick) Signals() map[sig.Signal]sig.Strength {
+	if t.columns != nil {
+		return t.columns.Signals(t.row)
+	}
 	return t.signals
 }
 
 func (t *Tick) HasSignal(key sig.Signal) bool {
-	_, ok := t.signals[key]
+	_, ok := t.Signals()[key]
 	return ok
 }
 
 func (t *Tick) GetSignal(key sig.Signal) sig.Strength {
-	if val, ok := t.signals[key]; ok {
+	if val, ok := t.Signals()[key]; ok {
 		return val
 	}
 	return 0
 }
 
 func (t *Tick) SetSignal(key sig.Signal, value sig.Strength) {
-	t.signals[key] = value
+	t.Signals()[key] = value
 }
 
 func (t *Tick) SetSignals(signals map[sig.Signal]sig.Strength) {
+	if t.columns != nil {
+		t.columns.SetSignals(t.row, signals)
+		return
+	}
 	t.signals = signals
 }
 
 func (t *Tick) RemoveSignal(key sig.Signal) {
-	delete(t.signals, key)
+	delete(t.Signals(), key)
 }
 
 func (t *Tick) SignalNames() []sig.Signal {
-	keys := make([]sig.Signal, 0, len(t.signals))
-	for k := range t.signals {
+	signals := t.Signals()
+	keys := make([]sig.Signal, 0, len(signals))
+	for k := range signals {
 		keys = append(keys, k)
 	}
 	return keys
@@ -255,34 +335,34 @@ func (t *Tick) SignalNames() []sig.Signal {
 // Other methods
 // ------------------------------------------------------------
 
+// Clone returns a tick holding its own copy of the fields, a view included.
 func (t *Tick) Clone() *Tick {
 	clone := make(map[string]float64)
-	for k, v := range t.fields {
+	for k, v := range t.Fields() {
 		clone[k] = v
 	}
 	return &Tick{
 		fields:    clone,
-		tags:      t.tags,
-		signals:   t.signals,
-		uuid:      t.uuid,
-		timestamp: t.timestamp,
-		duration:  t.duration,
+		tags:      t.Tags(),
+		signals:   t.Signals(),
+		uuid:      t.ID(),
+		timestamp: t.UnixNano(),
+		duration:  t.Duration(),
 	}
 }
 
 func (t *Tick) Update(other *Tick) *Tick {
-	for k, v := range other.fields {
-		t.fields[k] = v
-	}
-	for k, v := range other.tags {
-		t.tags[k] = v
+	for k, v := range other.Fields() {
+		t.SetField(k, v)
 	}
-	for k, v := range other.signals {
-		t.signals[k] = v
+	t.UpdateTags(other.Tags())
+	signals := t.Signals()
+	for k, v := range other.Signals() {
+		signals[k] = v
 	}
-	t.SetEpock(other.timestamp)
-	t.SetDuration(other.duration)
-	t.SetID(other.uuid)
+	t.SetUnixNano(other.UnixNano())
+	t.SetDuration(other.Duration())
+	t.SetID(other.ID())
 	t.SetIDFunc(other.idFunc)
 
 	// update the uuid
@@ -293,11 +373,11 @@ func (t *Tick) Update(other *Tick) *Tick {
 
 func (t *Tick) Spawn(opts ...TickOptions) *Tick {
 	tick := &Tick{
-		uuid:      t.uuid,
-		timestamp: t.Time().Add(t.duration).Unix(),
-		duration:  t.duration,
+		uuid:      t.ID(),
+		timestamp: t.Time().Add(t.Duration()).UnixNano(),
+		duration:  t.Duration(),
 		fields:    map[string]float64{},
-		tags:      t.tags,
+		tags:      t.Tags(),
 		signals:   map[sig.Signal]sig.Strength{},
 		idFunc:    t.idFunc,
 	}
@@ -0,0 +1,42 @@
+package tick
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubSecondTime(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	a := New(WithTime(base.Add(1500 * time.Microsecond)))
+	b := New(WithTime(base.Add(1501 * time.Microsecond)))
+
+	if !a.Time().Before(b.Time()) {
+		t.Errorf("%v should be before %v", a.Time(), b.Time())
+	}
+	if got, want := a.UnixNano(), base.Add(1500*time.Microsecond).UnixNano(); got != want {
+		t.Errorf("UnixNano = %d, want %d", got, want)
+	}
+	if a.Epock() != base.Unix() {
+		t.Errorf("Epock = %d, want %d", a.Epock(), base.Unix())
+	}
+
+	a.SetEpock(base.Unix() + 1)
+	if !a.Time().Equal(base.Add(time.Second)) {
+		t.Errorf("SetEpock time = %v", a.Time())
+	}
+}
+
+func TestDurationTruncation(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tk := New(WithTime(base.Add(123*time.Millisecond + 456*time.Microsecond)))
+
+	tk.SetDuration(100 * time.Millisecond)
+	if want := base.Add(100 * time.Millisecond); !tk.Time().Equal(want) {
+		t.Errorf("time = %v, want %v", tk.Time(), want)
+	}
+
+	next := tk.Spawn()
+	if want := base.Add(200 * time.Millisecond); !next.Time().Equal(want) {
+		t.Errorf("spawned time = %v, want %v", next.Time(), want)
+	}
+}
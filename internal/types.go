@@ -11,6 +11,9 @@ import (
 type PluginOptions func(Options)
 type PluginFunc func(...PluginOptions) (*series.Series, *stream.Stream)
 
+// IndicatorFunc computes an indicator over a series with the options
+type IndicatorFunc func(input *series.Series, opts ...PluginOptions) *series.Series
+
 // ------------------------------------------------------------
 // type Plugin interface {
 // 	Init(opts ...OptFunc) error
@@ -35,6 +38,7 @@ type Options interface {
 	// Types
 	Int(key string, defaults ...any) int
 	String(key string, defaults ...any) string
+	Strings(key string, defaults ...any) []string
 	Float(key string, defaults ...any) float64
 	Bool(key string, defaults ...any) bool
 	Duration(key string, defaults ...any) time.Duration
@@ -47,6 +51,7 @@ type Options interface {
 	Period(n ...any) int
 	FastPeriod(n ...any) int
 	SlowPeriod(n ...any) int
+	SignalPeriod(n ...any) int
 	MAType(s ...any) string
 
 	//
@@ -152,6 +157,22 @@ type Plugin interface {
 	Compute(input *series.Series) *series.Series
 }
 
+// Indicator is a stateful incremental indicator. Update consumes one tick
+// and returns the output tick, empty until Warmup ticks have been consumed.
+// Process is Update and Compute replays a series through Update after a
+// Reset, so batch and live results are bit-identical.
+type Indicator interface {
+	Plugin
+
+	Update(input *tick.Tick) *tick.Tick
+	Reset()
+	Warmup() int
+
+	// Snapshot encodes the state so Restore can resume from it, e.g. after a restart
+	Snapshot() ([]byte, error)
+	Restore(state []byte) error
+}
+
 // type PluginOption func(Options)
 
 type Streamer interface {
@@ -4,7 +4,7 @@ import (
 	"fmt"
 
 	"github.com/rangertaha/gotal/internal"
-	"github.com/rangertaha/gotal/internal/pkg/opt"
+	"github.com/rangertaha/gotal/internal/opt"
 	"github.com/rangertaha/gotal/internal/plugins/indicators"
 	_ "github.com/rangertaha/gotal/internal/plugins/indicators/all"
 )
@@ -29,52 +29,310 @@ var (
 
 	WithMAType = opt.WithMAType
 
+	// for MACDEXT, per line
+	WithFastMAType   = opt.WithFastMAType
+	WithSlowMAType   = opt.WithSlowMAType
+	WithSignalMAType = opt.WithSignalMAType
+
 	// Group of indicators
 	// TREND  []internal.SeriesFunc
 
 	// Mock indicator
+	//
+	// Deprecated: no mock indicator is registered, MOCK is always nil. Use a
+	// registered indicator such as SMA instead.
 	MOCK internal.IndicatorFunc
 
 	// Moving Average indicators
-	OHLC, OHLCV, SMA, EMA, WMA, DEMA, TEMA, TRIMA, KAMA, MAMA, T3 internal.IndicatorFunc
+	OHLC, OHLCV, MA, SMA, EMA, WMA, DEMA, TEMA, TRIMA, KAMA, MAMA, T3 internal.IndicatorFunc
 
 	// MACD
-	MACD internal.IndicatorFunc
+	MACD, MACDEXT, MACDFIX internal.IndicatorFunc
+
+	// Directional movement indicators
+	PLUS_DM, MINUS_DM, PLUS_DI, MINUS_DI, DX, ADX, ADXR internal.IndicatorFunc
+
+	// Parabolic SAR
+	SAR, SAREXT internal.IndicatorFunc
+
+	// Ichimoku Kinko Hyo
+	ICHIMOKU internal.IndicatorFunc
+
+	// Momentum indicators
+	RSI, STOCH, STOCHF, STOCHRSI, CCI, MFI, WILLR, ROC, ROCP, ROCR, ROCR100, MOM, CMO, APO, PPO, BOP, AROON, AROONOSC internal.IndicatorFunc
+
+	// Volatility indicators
+	TRANGE, ATR, NATR, BBANDS, KELTNER, DONCHIAN, CHV internal.IndicatorFunc
+
+	// Volume indicators
+	OBV, AD, ADOSC, VWAP, RVWAP, CMF, FORCE, EOM, VWMA internal.IndicatorFunc
+
+	// Cycle indicators
+	HT_DCPERIOD, HT_DCPHASE, HT_PHASOR, HT_SINE, HT_TRENDMODE, HT_TRENDLINE internal.IndicatorFunc
+
+	// Pivot points, swings and support and resistance levels
+	PIVOT, PIVOT_FIB, PIVOT_CAMARILLA, PIVOT_WOODIE, PIVOT_DEMARK, FRACTAL, FIBLEVELS, SRLEVELS internal.IndicatorFunc
+
+	// Alternative bar types
+	HEIKINASHI, RENKO, KAGI, PNF, RANGEBARS, TICKBARS, VOLUMEBARS, DOLLARBARS internal.IndicatorFunc
+
+	// Candlestick patterns
+	CANDLES internal.IndicatorFunc
+	CDL2CROWS, CDL3BLACKCROWS, CDL3INSIDE, CDL3LINESTRIKE, CDL3OUTSIDE, CDL3STARSINSOUTH, CDL3WHITESOLDIERS, CDLABANDONEDBABY,
+	CDLADVANCEBLOCK, CDLBELTHOLD, CDLBREAKAWAY, CDLCLOSINGMARUBOZU, CDLCONCEALBABYSWALL, CDLCOUNTERATTACK, CDLDARKCLOUDCOVER, CDLDOJI,
+	CDLDOJISTAR, CDLDRAGONFLYDOJI, CDLENGULFING, CDLEVENINGDOJISTAR, CDLEVENINGSTAR, CDLGAPSIDESIDEWHITE, CDLGRAVESTONEDOJI, CDLHAMMER,
+	CDLHANGINGMAN, CDLHARAMI, CDLHARAMICROSS, CDLHIGHWAVE, CDLHIKKAKE, CDLHIKKAKEMOD, CDLHOMINGPIGEON, CDLIDENTICAL3CROWS,
+	CDLINNECK, CDLINVERTEDHAMMER, CDLKICKING, CDLKICKINGBYLENGTH, CDLLADDERBOTTOM, CDLLONGLEGGEDDOJI, CDLLONGLINE, CDLMARUBOZU,
+	CDLMATCHINGLOW, CDLMATHOLD, CDLMORNINGDOJISTAR, CDLMORNINGSTAR, CDLONNECK, CDLPIERCING, CDLRICKSHAWMAN, CDLRISEFALL3METHODS,
+	CDLSEPARATINGLINES, CDLSHOOTINGSTAR, CDLSHORTLINE, CDLSPINNINGTOP, CDLSTALLEDPATTERN, CDLSTICKSANDWICH, CDLTAKURI, CDLTASUKIGAP,
+	CDLTHRUSTING, CDLTRISTAR, CDLUNIQUE3RIVER, CDLUPSIDEGAP2CROWS, CDLXSIDEGAP3METHODS internal.IndicatorFunc
 )
 
-func init() {
+// series returns the indicator function, keeping the first error
+func series(id string) internal.IndicatorFunc {
+	fn, e := indicators.Series(id)
+	if e != nil && err == nil {
+		err = e
+	}
+	return fn
+}
 
-	// Mock indicator
-	MOCK, err = indicators.Get("mock")
+func init() {
 
 	// OHLC, OHLCV
-	OHLC, err = indicators.Series("ohlc")
-	OHLCV, err = ih.Series("ohlcv")
+	OHLC = series("ohlc")
+	OHLCV = series("ohlcv")
 
 	// TREND, err = ind.Group(ind.TREND)
 
+	// Moving Average of the type selected with WithMAType
+	MA = series("ma")
+
 	// Simple Moving Average
-	SMA, err = i.Series("sma")
+	SMA = series("sma")
 
 	// Exponential Moving Average
-	EMA, err = i.Series("ema")
+	EMA = series("ema")
 
 	// Weighted Moving Average
-	WMA, err = i.Series("wma")
+	WMA = series("wma")
 
-	// // Double Exponential Moving Average
-	// DEMA, err = ind.Series("dema")
-	// DEMAs, err = ind.Stream("dema")
+	// Double Exponential Moving Average
+	DEMA = series("dema")
 
 	// Triple Exponential Moving Average
-	// TEMA, err = ind.Get("tema")
-	// TRIMA, err = ind.Get("trima")
-	// KAMA, err = ind.Get("kama")
-	// MAMA, err = ind.Get("mama")
-	// T3, err = ind.Get("t3")
+	TEMA = series("tema")
+
+	// Triangular Moving Average
+	TRIMA = series("trima")
+
+	// Kaufman Adaptive Moving Average
+	KAMA = series("kama")
+
+	// MESA Adaptive Moving Average
+	MAMA = series("mama")
+
+	// Triple Exponential Moving Average (T3)
+	T3 = series("t3")
 
 	// Moving Average Convergence Divergence
-	MACD, err = i.Series("macd")
+	MACD = series("macd")
+
+	// MACD with a moving average type per line
+	MACDEXT = series("macdext")
+
+	// MACD 12/26 with fixed smoothing factors
+	MACDFIX = series("macdfix")
+
+	// Directional movement and indicators
+	PLUS_DM = series("plus_dm")
+	MINUS_DM = series("minus_dm")
+	PLUS_DI = series("plus_di")
+	MINUS_DI = series("minus_di")
+
+	// Directional Movement Index, its average and the average rating
+	DX = series("dx")
+	ADX = series("adx")
+	ADXR = series("adxr")
+
+	// Parabolic SAR and its extended form
+	SAR = series("sar")
+	SAREXT = series("sarext")
+
+	// Ichimoku Kinko Hyo, with the cloud projected after the last bar
+	ICHIMOKU = series("ichimoku")
+
+	// Relative Strength Index
+	RSI = series("rsi")
+
+	// Stochastic, fast Stochastic and Stochastic RSI
+	STOCH = series("stoch")
+	STOCHF = series("stochf")
+	STOCHRSI = series("stochrsi")
+
+	// Commodity Channel Index
+	CCI = series("cci")
+
+	// Money Flow Index
+	MFI = series("mfi")
+
+	// Williams' %R
+	WILLR = series("willr")
+
+	// Rate of Change and Momentum
+	ROC = series("roc")
+	ROCP = series("rocp")
+	ROCR = series("rocr")
+	ROCR100 = series("rocr100")
+	MOM = series("mom")
+
+	// Chande Momentum Oscillator
+	CMO = series("cmo")
+
+	// Absolute and Percentage Price Oscillators
+	APO = series("apo")
+	PPO = series("ppo")
+
+	// Balance Of Power
+	BOP = series("bop")
+
+	// Aroon and Aroon Oscillator
+	AROON = series("aroon")
+	AROONOSC = series("aroonosc")
+
+	// True Range, Average True Range and Normalized Average True Range
+	TRANGE = series("trange")
+	ATR = series("atr")
+	NATR = series("natr")
+
+	// Bollinger Bands, Keltner Channels and Donchian Channels
+	BBANDS = series("bbands")
+	KELTNER = series("keltner")
+	DONCHIAN = series("donchian")
+
+	// Chaikin Volatility
+	CHV = series("chv")
+
+	// On Balance Volume
+	OBV = series("obv")
+
+	// Chaikin A/D Line and Oscillator
+	AD = series("ad")
+	ADOSC = series("adosc")
+
+	// Session and rolling Volume Weighted Average Price
+	VWAP = series("vwap")
+	RVWAP = series("rvwap")
+
+	// Chaikin Money Flow
+	CMF = series("cmf")
+
+	// Force Index
+	FORCE = series("force")
+
+	// Ease of Movement
+	EOM = series("eom")
+
+	// Volume Weighted Moving Average
+	VWMA = series("vwma")
+
+	// Hilbert Transform dominant cycle period and phase
+	HT_DCPERIOD = series("ht_dcperiod")
+	HT_DCPHASE = series("ht_dcphase")
+
+	// Hilbert Transform phasor components and SineWave
+	HT_PHASOR = series("ht_phasor")
+	HT_SINE = series("ht_sine")
+
+	// Hilbert Transform trend vs cycle mode and instantaneous trendline
+	HT_TRENDMODE = series("ht_trendmode")
+	HT_TRENDLINE = series("ht_trendline")
+
+	// Pivot points of the previous session
+	PIVOT = series("pivot")
+	PIVOT_FIB = series("pivot_fib")
+	PIVOT_CAMARILLA = series("pivot_camarilla")
+	PIVOT_WOODIE = series("pivot_woodie")
+	PIVOT_DEMARK = series("pivot_demark")
+
+	// Swing highs and lows, the Fibonacci levels between them and the levels they cluster at
+	FRACTAL = series("fractal")
+	FIBLEVELS = series("fiblevels")
+	SRLEVELS = series("srlevels")
+
+	// Heikin-Ashi, Renko, Kagi, Point and Figure and threshold bars
+	HEIKINASHI = series("heikinashi")
+	RENKO = series("renko")
+	KAGI = series("kagi")
+	PNF = series("pnf")
+	RANGEBARS = series("rangebars")
+	TICKBARS = series("tickbars")
+	VOLUMEBARS = series("volumebars")
+	DOLLARBARS = series("dollarbars")
+
+	// Candlestick patterns, all at once or one by one
+	CANDLES = series("candles")
+	CDL2CROWS = series("cdl2crows")
+	CDL3BLACKCROWS = series("cdl3blackcrows")
+	CDL3INSIDE = series("cdl3inside")
+	CDL3LINESTRIKE = series("cdl3linestrike")
+	CDL3OUTSIDE = series("cdl3outside")
+	CDL3STARSINSOUTH = series("cdl3starsinsouth")
+	CDL3WHITESOLDIERS = series("cdl3whitesoldiers")
+	CDLABANDONEDBABY = series("cdlabandonedbaby")
+	CDLADVANCEBLOCK = series("cdladvanceblock")
+	CDLBELTHOLD = series("cdlbelthold")
+	CDLBREAKAWAY = series("cdlbreakaway")
+	CDLCLOSINGMARUBOZU = series("cdlclosingmarubozu")
+	CDLCONCEALBABYSWALL = series("cdlconcealbabyswall")
+	CDLCOUNTERATTACK = series("cdlcounterattack")
+	CDLDARKCLOUDCOVER = series("cdldarkcloudcover")
+	CDLDOJI = series("cdldoji")
+	CDLDOJISTAR = series("cdldojistar")
+	CDLDRAGONFLYDOJI = series("cdldragonflydoji")
+	CDLENGULFING = series("cdlengulfing")
+	CDLEVENINGDOJISTAR = series("cdleveningdojistar")
+	CDLEVENINGSTAR = series("cdleveningstar")
+	CDLGAPSIDESIDEWHITE = series("cdlgapsidesidewhite")
+	CDLGRAVESTONEDOJI = series("cdlgravestonedoji")
+	CDLHAMMER = series("cdlhammer")
+	CDLHANGINGMAN = series("cdlhangingman")
+	CDLHARAMI = series("cdlharami")
+	CDLHARAMICROSS = series("cdlharamicross")
+	CDLHIGHWAVE = series("cdlhighwave")
+	CDLHIKKAKE = series("cdlhikkake")
+	CDLHIKKAKEMOD = series("cdlhikkakemod")
+	CDLHOMINGPIGEON = series("cdlhomingpigeon")
+	CDLIDENTICAL3CROWS = series("cdlidentical3crows")
+	CDLINNECK = series("cdlinneck")
+	CDLINVERTEDHAMMER = series("cdlinvertedhammer")
+	CDLKICKING = series("cdlkicking")
+	CDLKICKINGBYLENGTH = series("cdlkickingbylength")
+	CDLLADDERBOTTOM = series("cdlladderbottom")
+	CDLLONGLEGGEDDOJI = series("cdllongleggeddoji")
+	CDLLONGLINE = series("cdllongline")
+	CDLMARUBOZU = series("cdlmarubozu")
+	CDLMATCHINGLOW = series("cdlmatchinglow")
+	CDLMATHOLD = series("cdlmathold")
+	CDLMORNINGDOJISTAR = series("cdlmorningdojistar")
+	CDLMORNINGSTAR = series("cdlmorningstar")
+	CDLONNECK = series("cdlonneck")
+	CDLPIERCING = series("cdlpiercing")
+	CDLRICKSHAWMAN = series("cdlrickshawman")
+	CDLRISEFALL3METHODS = series("cdlrisefall3methods")
+	CDLSEPARATINGLINES = series("cdlseparatinglines")
+	CDLSHOOTINGSTAR = series("cdlshootingstar")
+	CDLSHORTLINE = series("cdlshortline")
+	CDLSPINNINGTOP = series("cdlspinningtop")
+	CDLSTALLEDPATTERN = series("cdlstalledpattern")
+	CDLSTICKSANDWICH = series("cdlsticksandwich")
+	CDLTAKURI = series("cdltakuri")
+	CDLTASUKIGAP = series("cdltasukigap")
+	CDLTHRUSTING = series("cdlthrusting")
+	CDLTRISTAR = series("cdltristar")
+	CDLUNIQUE3RIVER = series("cdlunique3river")
+	CDLUPSIDEGAP2CROWS = series("cdlupsidegap2crows")
+	CDLXSIDEGAP3METHODS = series("cdlxsidegap3methods")
 
 	if err != nil {
 		fmt.Println("Error initializing indicators:", err)